@@ -5,10 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"order-service/config"
+	"order-service/internal/adapter/fxrate"
 	"order-service/internal/adapter/grpcclient"
+	"order-service/internal/adapter/payment"
 	"order-service/internal/adapter/repository"
 	rest "order-service/internal/adapter/restapi"
+	"order-service/internal/adapter/shipping"
+	"order-service/internal/adapter/tax"
+	"order-service/internal/adapter/webhook"
+	"order-service/internal/domain/returnpolicy"
 	"order-service/internal/domain/service"
+	"order-service/internal/jobqueue"
+	"order-service/internal/worker"
 	"order-service/pkg/apmtracer"
 	"order-service/pkg/bundb"
 	"order-service/pkg/logger"
@@ -66,13 +74,51 @@ func (a *App) Run() error {
 		return fmt.Errorf("failed to setup repository: %w", err)
 	}
 
+	deregisterPoolMetrics := a.tracer.Tracer().RegisterMetricsGatherer(bundb.PoolMetrics(repo.Postgres().PoolStats))
+
 	// Initialize service
 	inventorySvcClient, err := grpcclient.NewInventoryServiceClient(a.config)
 	if err != nil {
 		return fmt.Errorf("failed to create inventory service client: %w", err)
 	}
 
-	service, err := service.NewService(a.config, repo, a.logger, inventorySvcClient)
+	fxRateProvider, err := fxrate.NewFXRateProvider(a.config)
+	if err != nil {
+		return fmt.Errorf("failed to create fx rate provider: %w", err)
+	}
+
+	taxCalculator, err := tax.NewTaxCalculator(a.config)
+	if err != nil {
+		return fmt.Errorf("failed to create tax calculator: %w", err)
+	}
+
+	shippingFeeCalculator, err := shipping.NewFeeCalculator(a.config)
+	if err != nil {
+		return fmt.Errorf("failed to create shipping fee calculator: %w", err)
+	}
+
+	paymentProvider, err := payment.NewPaymentProvider(a.config)
+	if err != nil {
+		return fmt.Errorf("failed to create payment provider: %w", err)
+	}
+
+	returnPolicy, err := returnpolicy.Load(a.config.Return.PolicyFile, a.config.Return.WindowDays)
+	if err != nil {
+		return fmt.Errorf("failed to load return policy: %w", err)
+	}
+
+	service, err := service.NewService(
+		a.config,
+		repo,
+		a.logger,
+		inventorySvcClient,
+		fxRateProvider,
+		taxCalculator,
+		shippingFeeCalculator,
+		paymentProvider,
+		returnPolicy,
+		webhook.NewSender(a.config),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to setup service: %w", err)
 	}
@@ -89,6 +135,55 @@ func (a *App) Run() error {
 
 	a.logger.Info().Msgf("Server started at %s:%d", a.config.HTTP.Host, a.config.HTTP.Port)
 
+	// Start order expiry sweeper
+	sweeperDone := make(chan struct{})
+	if a.config.Expiry.Enabled {
+		sweeper := worker.NewOrderExpirySweeper(a.config.Expiry, repo, service, a.logger)
+		go func() {
+			defer close(sweeperDone)
+			sweeper.Run(ctx)
+		}()
+	} else {
+		close(sweeperDone)
+	}
+
+	// Start background job workers
+	jobsDone := make(chan struct{})
+	if a.config.Jobs.Enabled {
+		queue := jobqueue.NewQueue(a.config.Jobs, repo, a.logger)
+		service.RegisterJobHandlers(queue)
+		go func() {
+			defer close(jobsDone)
+			queue.Run(ctx)
+		}()
+	} else {
+		close(jobsDone)
+	}
+
+	// Start audit log retention purger
+	auditDone := make(chan struct{})
+	if a.config.Audit.Retention > 0 {
+		purger := worker.NewAuditRetentionPurger(a.config.Audit, repo, a.logger)
+		go func() {
+			defer close(auditDone)
+			purger.Run(ctx)
+		}()
+	} else {
+		close(auditDone)
+	}
+
+	// Start order partition maintainer
+	partitionsDone := make(chan struct{})
+	if a.config.Partition.Enabled {
+		maintainer := worker.NewPartitionMaintainer(a.config.Partition, repo, a.logger)
+		go func() {
+			defer close(partitionsDone)
+			maintainer.Run(ctx)
+		}()
+	} else {
+		close(partitionsDone)
+	}
+
 	// Wait for shutdown signal
 	<-ctx.Done()
 	a.logger.Info().Msg("Shutdown signal received, starting graceful shutdown...")
@@ -103,7 +198,15 @@ func (a *App) Run() error {
 		a.logger.Info().Msg("REST server shut down gracefully")
 	}
 
+	// Wait for the background workers to finish their current work
+	a.waitForShutdown(shutdownCtx, sweeperDone, "order expiry sweeper")
+	a.waitForShutdown(shutdownCtx, jobsDone, "job workers")
+	a.waitForShutdown(shutdownCtx, auditDone, "audit log purger")
+	a.waitForShutdown(shutdownCtx, partitionsDone, "order partition maintainer")
+
 	// Close repository
+	deregisterPoolMetrics()
+
 	if err := repo.Close(); err != nil {
 		a.logger.Error().Err(err).Msg("Failed to gracefully close repository")
 	} else {
@@ -115,6 +218,15 @@ func (a *App) Run() error {
 	return nil
 }
 
+func (a *App) waitForShutdown(ctx context.Context, done <-chan struct{}, name string) {
+	select {
+	case <-done:
+		a.logger.Info().Msgf("Stopped %s", name)
+	case <-ctx.Done():
+		a.logger.Error().Msgf("Timed out waiting for %s to stop", name)
+	}
+}
+
 func (a *App) Migrate(reset bool) error {
 	db, err := bundb.NewBunDB(a.config, a.logger)
 	if err != nil {
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+	"order-service/internal/adapter/repository"
+	"time"
+)
+
+// ArchiveOrders moves closed orders last updated before closedBefore to the
+// archive tables, batchSize orders per transaction, and returns how many
+// were archived.
+func (a *App) ArchiveOrders(ctx context.Context, closedBefore time.Time, batchSize int) (int, error) {
+	var archived int
+
+	err := a.withRepository(func(repo repository.Repository) error {
+		for {
+			ids, err := repo.Postgres().OrderArchive().Archive(ctx, closedBefore, batchSize)
+			if err != nil {
+				return err
+			}
+
+			archived += len(ids)
+
+			if len(ids) > 0 {
+				a.logger.Info().Msgf("Archived orders %d to %d", ids[0], ids[len(ids)-1])
+			}
+
+			if len(ids) < batchSize {
+				return nil
+			}
+		}
+	})
+
+	return archived, err
+}
+
+// RestoreArchivedOrders moves the given orders back from the archive tables
+// and returns the IDs of those that were archived.
+func (a *App) RestoreArchivedOrders(ctx context.Context, ids []uint32) ([]uint32, error) {
+	var restored []uint32
+
+	err := a.withRepository(func(repo repository.Repository) error {
+		var err error
+
+		restored, err = repo.Postgres().OrderArchive().Restore(ctx, ids)
+
+		return err
+	})
+
+	return restored, err
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"order-service/internal/adapter/repository"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/domain/entity"
+	"time"
+)
+
+// ListJobs returns the jobs matching filter, newest first, with the total
+// number of matches.
+func (a *App) ListJobs(ctx context.Context, filter *postgresrepository.FilterJobPayload) ([]*entity.Job, int, error) {
+	var (
+		jobs  []*entity.Job
+		total int
+	)
+
+	err := a.withRepository(func(repo repository.Repository) error {
+		var err error
+
+		jobs, total, err = repo.Postgres().Job().Find(ctx, filter)
+
+		return err
+	})
+
+	return jobs, total, err
+}
+
+// RetryJobs queues the given dead jobs again, or every dead job without ids.
+func (a *App) RetryJobs(ctx context.Context, ids []uint32) (int, error) {
+	var retried int
+
+	err := a.withRepository(func(repo repository.Repository) error {
+		var err error
+
+		retried, err = repo.Postgres().Job().Retry(ctx, ids)
+
+		return err
+	})
+
+	return retried, err
+}
+
+// PurgeJobs deletes jobs in one of statuses last updated before before.
+func (a *App) PurgeJobs(ctx context.Context, statuses []string, before time.Time) (int, error) {
+	var purged int
+
+	err := a.withRepository(func(repo repository.Repository) error {
+		var err error
+
+		purged, err = repo.Postgres().Job().Purge(ctx, statuses, before)
+
+		return err
+	})
+
+	return purged, err
+}
+
+func (a *App) withRepository(fn func(repo repository.Repository) error) error {
+	repo, err := repository.NewRepository(a.config, a.logger)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := repo.Close(); err != nil {
+			a.logger.Error().Err(err).Msg("Failed to close repository")
+		}
+	}()
+
+	return fn(repo)
+}
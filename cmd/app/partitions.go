@@ -0,0 +1,16 @@
+package app
+
+import (
+	"context"
+	"order-service/internal/adapter/repository"
+	"order-service/internal/worker"
+)
+
+// MaintainPartitions creates the upcoming monthly partitions of the orders
+// tables and expires old ones, as the partition maintainer does on its
+// schedule.
+func (a *App) MaintainPartitions(ctx context.Context) error {
+	return a.withRepository(func(repo repository.Repository) error {
+		return worker.NewPartitionMaintainer(a.config.Partition, repo, a.logger).Maintain(ctx)
+	})
+}
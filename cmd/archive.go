@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Move closed orders to the archive tables",
+	Long: "Move delivered, rejected and cancelled orders, with their items, payments, refunds and returns, " +
+		"to the archive tables. Orders with a refund or return in progress are skipped.",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		olderThan, _ := cmd.Flags().GetDuration("older-than")
+		batchSize, _ := cmd.Flags().GetInt("batch-size")
+
+		if olderThan <= 0 {
+			return fmt.Errorf("--older-than must be positive")
+		}
+
+		if batchSize <= 0 {
+			return fmt.Errorf("--batch-size must be positive")
+		}
+
+		archived, err := newCommandApp(cmd).ArchiveOrders(context.Background(), time.Now().Add(-olderThan), batchSize)
+		if err != nil {
+			fmt.Println("Failed to archive orders:", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Archived %d orders\n", archived)
+
+		return nil
+	},
+}
+
+var archiveRestoreCmd = &cobra.Command{
+	Use:   "restore id...",
+	Short: "Move archived orders back to the live tables",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids := make([]uint32, 0, len(args))
+		for _, arg := range args {
+			id, err := strconv.ParseUint(arg, 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid order id %q", arg)
+			}
+
+			ids = append(ids, uint32(id))
+		}
+
+		restored, err := newCommandApp(cmd).RestoreArchivedOrders(context.Background(), ids)
+		if err != nil {
+			fmt.Println("Failed to restore orders:", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Restored %d of %d orders\n", len(restored), len(ids))
+
+		return nil
+	},
+}
+
+func init() {
+	archiveCmd.PersistentFlags().StringP("config", "c", ".env", "Specify the config file (optional)")
+
+	archiveCmd.Flags().Duration("older-than", 365*24*time.Hour, "Only archive orders last updated longer ago than this")
+	archiveCmd.Flags().Int("batch-size", 500, "Number of orders to archive per transaction")
+
+	archiveCmd.AddCommand(archiveRestoreCmd)
+
+	rootCmd.AddCommand(archiveCmd)
+}
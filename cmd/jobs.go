@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"order-service/cmd/app"
+	"order-service/config"
+	"order-service/constant"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// purgeableJobStatuses are the statuses of jobs that no worker will pick up
+// again on its own, and which may therefore be purged.
+var purgeableJobStatuses = []string{string(constant.JobStatusSucceeded), string(constant.JobStatusDead)}
+
+var jobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "Inspect and manage background jobs",
+}
+
+var jobsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List background jobs, newest first",
+	Run: func(cmd *cobra.Command, _ []string) {
+		status, _ := cmd.Flags().GetString("status")
+		jobType, _ := cmd.Flags().GetString("type")
+		limit, _ := cmd.Flags().GetInt("limit")
+		page, _ := cmd.Flags().GetInt("page")
+
+		jobs, total, err := newCommandApp(cmd).ListJobs(context.Background(), &postgresrepository.FilterJobPayload{
+			Status:  strings.ToUpper(status),
+			Type:    jobType,
+			Page:    page,
+			PerPage: limit,
+		})
+		if err != nil {
+			fmt.Println("Failed to list jobs:", err)
+			os.Exit(1)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTYPE\tSTATUS\tATTEMPTS\tRUN AT\tLAST ERROR")
+
+		for _, job := range jobs {
+			maxAttempts := "default"
+			if job.MaxAttempts > 0 {
+				maxAttempts = strconv.Itoa(job.MaxAttempts)
+			}
+
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d/%s\t%s\t%s\n",
+				job.ID, job.Type, job.Status, job.Attempts, maxAttempts, job.RunAt.Format(time.RFC3339), truncate(job.LastError, 80))
+		}
+
+		if err := w.Flush(); err != nil {
+			fmt.Println("Failed to print jobs:", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("%d of %d jobs\n", len(jobs), total)
+	},
+}
+
+var jobsRetryCmd = &cobra.Command{
+	Use:   "retry [id...]",
+	Short: "Queue dead jobs again with their attempts reset",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		if all == (len(args) > 0) {
+			return fmt.Errorf("pass either job ids or --all")
+		}
+
+		ids := make([]uint32, 0, len(args))
+		for _, arg := range args {
+			id, err := strconv.ParseUint(arg, 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid job id %q", arg)
+			}
+
+			ids = append(ids, uint32(id))
+		}
+
+		retried, err := newCommandApp(cmd).RetryJobs(context.Background(), ids)
+		if err != nil {
+			fmt.Println("Failed to retry jobs:", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Queued %d dead jobs again\n", retried)
+
+		return nil
+	},
+}
+
+var jobsPurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Delete finished jobs",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		statuses, _ := cmd.Flags().GetStringSlice("status")
+		olderThan, _ := cmd.Flags().GetDuration("older-than")
+
+		for i, status := range statuses {
+			statuses[i] = strings.ToUpper(status)
+			if !slices.Contains(purgeableJobStatuses, statuses[i]) {
+				return fmt.Errorf("invalid status %s. purgeable statuses are: %v", status, strings.Join(purgeableJobStatuses, ", "))
+			}
+		}
+
+		purged, err := newCommandApp(cmd).PurgeJobs(context.Background(), statuses, time.Now().Add(-olderThan))
+		if err != nil {
+			fmt.Println("Failed to purge jobs:", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Purged %d jobs\n", purged)
+
+		return nil
+	},
+}
+
+func newCommandApp(cmd *cobra.Command) *app.App {
+	configFile, err := cmd.Flags().GetString("config")
+	if err != nil {
+		fmt.Println("Failed to get config flag:", err)
+		os.Exit(1)
+	}
+
+	config, err := config.LoadConfig(configFile)
+	if err != nil {
+		fmt.Println("Failed to load config:", err)
+		os.Exit(1)
+	}
+
+	app, err := app.NewApp(config, newLogger(config))
+	if err != nil {
+		fmt.Println("Failed to create app:", err)
+		os.Exit(1)
+	}
+
+	return app
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	return s[:n-3] + "..."
+}
+
+func init() {
+	jobsCmd.PersistentFlags().StringP("config", "c", ".env", "Specify the config file (optional)")
+
+	jobsListCmd.Flags().StringP("status", "s", "", "Only list jobs with this status (optional)")
+	jobsListCmd.Flags().StringP("type", "t", "", "Only list jobs of this type (optional)")
+	jobsListCmd.Flags().IntP("limit", "l", 50, "Number of jobs to list")
+	jobsListCmd.Flags().IntP("page", "p", 1, "Page of jobs to list")
+
+	jobsRetryCmd.Flags().Bool("all", false, "Retry every dead job")
+
+	jobsPurgeCmd.Flags().StringSliceP("status", "s", purgeableJobStatuses, "Statuses of the jobs to purge")
+	jobsPurgeCmd.Flags().Duration("older-than", 7*24*time.Hour, "Only purge jobs last updated longer ago than this")
+
+	jobsCmd.AddCommand(jobsListCmd)
+	jobsCmd.AddCommand(jobsRetryCmd)
+	jobsCmd.AddCommand(jobsPurgeCmd)
+
+	rootCmd.AddCommand(jobsCmd)
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var partitionsCmd = &cobra.Command{
+	Use:   "partitions",
+	Short: "Create upcoming and expire old monthly partitions of the orders tables",
+	Long: "Create the monthly partitions of orders and order_items for the current month and PARTITION_PREMAKE_MONTHS ahead, " +
+		"and detach, or drop with PARTITION_DROP_EXPIRED, those older than PARTITION_RETENTION_MONTHS.",
+	Run: func(cmd *cobra.Command, _ []string) {
+		if err := newCommandApp(cmd).MaintainPartitions(context.Background()); err != nil {
+			fmt.Println("Failed to maintain partitions:", err)
+			os.Exit(1)
+		}
+
+		fmt.Println("Partitions are up to date")
+	},
+}
+
+func init() {
+	partitionsCmd.Flags().StringP("config", "c", ".env", "Specify the config file (optional)")
+
+	rootCmd.AddCommand(partitionsCmd)
+}
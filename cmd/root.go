@@ -48,8 +48,6 @@ var runCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		logger := logger.NewZerologLogger(debug)
-
 		config, err := config.LoadConfig(configFile)
 		if err != nil {
 			fmt.Println("Failed to load config:", err)
@@ -59,7 +57,7 @@ var runCmd = &cobra.Command{
 		config.App.Environment = env
 		config.App.Debug = debug
 
-		app, err := app.NewApp(config, logger)
+		app, err := app.NewApp(config, newLogger(config))
 		if err != nil {
 			fmt.Println("Failed to create app:", err)
 			os.Exit(1)
@@ -88,15 +86,13 @@ var migrateCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		logger := logger.NewZerologLogger(true)
-
 		config, err := config.LoadConfig(configFile)
 		if err != nil {
 			fmt.Println("Failed to load config:", err)
 			os.Exit(1)
 		}
 
-		app, err := app.NewApp(config, logger)
+		app, err := app.NewApp(config, newLogger(config))
 		if err != nil {
 			fmt.Println("Failed to create app:", err)
 			os.Exit(1)
@@ -109,6 +105,38 @@ var migrateCmd = &cobra.Command{
 	},
 }
 
+// newLogger returns the logger configured by config, logging at debug level
+// in debug mode.
+func newLogger(config *config.Config) logger.Logger {
+	level := config.Log.Level
+	if config.App.Debug {
+		level = "debug"
+	}
+
+	logger, err := logger.New(&logger.Config{
+		Format:         config.Log.Format,
+		Level:          level,
+		File:           config.Log.File,
+		FileMaxSizeMB:  config.Log.FileMaxSizeMB,
+		FileMaxBackups: config.Log.FileMaxBackups,
+		FileMaxAgeDays: config.Log.FileMaxAgeDays,
+		FileCompress:   config.Log.FileCompress,
+		SamplingBurst:  config.Log.SamplingBurst,
+		SamplingPeriod: config.Log.SamplingPeriod,
+		SamplingEvery:  config.Log.SamplingEvery,
+		RedactKeys:     config.Log.RedactKeys,
+		ServiceName:    config.App.Name,
+		ServiceVersion: config.App.Version,
+		Environment:    config.App.Environment,
+	})
+	if err != nil {
+		fmt.Println("Failed to create logger:", err)
+		os.Exit(1)
+	}
+
+	return logger
+}
+
 func runCmdPreRunE(cmd *cobra.Command, _ []string) error {
 	env, err := cmd.Flags().GetString("env")
 	if err != nil {
@@ -1,18 +1,34 @@
 package config
 
 import (
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/labstack/gommon/bytes"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	App      *AppConfig
-	Tracer   *TracerConfig
-	HTTP     *HTTPConfig
-	Postgres *DatabaseConfig
-	GRPC     *GRPCConfig
+	App       *AppConfig
+	Tracer    *TracerConfig
+	HTTP      *HTTPConfig
+	Postgres  *DatabaseConfig
+	GRPC      *GRPCConfig
+	FX        *FXConfig
+	Tax       *TaxConfig
+	Shipping  *ShippingConfig
+	Payment   *PaymentConfig
+	Return    *ReturnConfig
+	Expiry    *ExpiryConfig
+	Jobs      *JobsConfig
+	Webhook   *WebhookConfig
+	Stream    *StreamConfig
+	Audit     *AuditConfig
+	Partition *PartitionConfig
+	Log       *LogConfig
+	RateLimit *RateLimitConfig
 }
 
 type AppConfig struct {
@@ -33,15 +49,27 @@ type TracerConfig struct {
 	NodeName       string
 }
 
+// DatabaseConfig configures the Postgres primary at DSN and its optional
+// read replicas, which are health checked every ReplicaCheckInterval. The
+// pool and timeout settings apply to the primary and each replica alike; a
+// zero timeout or threshold turns it off. Plans of slow queries are only
+// captured when ExplainSlowQueries is set in debug mode.
 type DatabaseConfig struct {
-	DSN                string
-	MigrateDSN         string
-	DBName             string
-	MaxOpenConns       int
-	MaxIdleConns       int
-	ConnMaxLifetime    int
-	SlowQueryThreshold int
-	Debug              bool
+	DSN                  string
+	ReplicaDSNs          []string
+	ReplicaCheckInterval time.Duration
+	MigrateDSN           string
+	DBName               string
+	MaxOpenConns         int
+	MaxIdleConns         int
+	ConnMaxLifetime      time.Duration
+	ConnMaxIdleTime      time.Duration
+	StatementTimeout     time.Duration
+	LockTimeout          time.Duration
+	PoolWaitThreshold    time.Duration
+	SlowQueryThreshold   time.Duration
+	ExplainSlowQueries   bool
+	Debug                bool
 }
 
 type HTTPConfig struct {
@@ -50,6 +78,33 @@ type HTTPConfig struct {
 	BasePath           string
 	DomainName         string
 	EnableMigrationAPI bool
+	AdminAPIKey        string
+
+	// Cross-origin requests are allowed from AllowedOrigins ("*" for any),
+	// with AllowedMethods and AllowedHeaders.
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+
+	// MaxBodySize bounds request bodies, in bytes.
+	MaxBodySize int64
+
+	// ReadTimeout bounds reading a request, WriteTimeout writing its
+	// response, and IdleTimeout how long a kept-alive connection waits for
+	// the next request. Order streams are exempt from the first two.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+
+	// HSTSMaxAge is how long browsers only use HTTPS once they have been
+	// sent the Strict-Transport-Security header; zero leaves it out.
+	HSTSMaxAge time.Duration
+	// FrameOptions is the X-Frame-Options header, DENY by default.
+	FrameOptions string
+
+	// ValidateRequests rejects requests that do not match the OpenAPI
+	// document served at /openapi.json.
+	ValidateRequests bool
 }
 
 type GRPCConfig struct {
@@ -57,6 +112,146 @@ type GRPCConfig struct {
 	InventoryPort int
 }
 
+type FXConfig struct {
+	BaseCurrency string
+	Provider     string
+	RatesFile    string
+}
+
+type PaymentConfig struct {
+	Provider         string
+	WebhookSecret    string
+	WebhookTolerance time.Duration
+	// RefundMaxAttempts is how many times the job queue sends a refund to
+	// the provider before it is marked failed.
+	RefundMaxAttempts int
+}
+
+// ReturnConfig sets how many days after delivery items can be returned.
+// PolicyFile may override the window per product.
+type ReturnConfig struct {
+	WindowDays int
+	PolicyFile string
+}
+
+// ExpiryConfig controls the sweeper that cancels orders still awaiting
+// payment PendingTTL after they were placed. It runs every Interval and
+// cancels at most BatchSize orders per run.
+type ExpiryConfig struct {
+	Enabled    bool
+	PendingTTL time.Duration
+	Interval   time.Duration
+	BatchSize  int
+}
+
+// JobsConfig controls the background job workers. Concurrency workers poll
+// for due jobs every PollInterval. A failed job is retried after BackoffBase,
+// doubling per attempt up to BackoffMax, and is dead-lettered after
+// MaxAttempts. A job running for longer than LockTimeout is cancelled and
+// may be claimed by another worker; a zero LockTimeout never times one out.
+type JobsConfig struct {
+	Enabled      bool
+	Concurrency  int
+	PollInterval time.Duration
+	MaxAttempts  int
+	BackoffBase  time.Duration
+	BackoffMax   time.Duration
+	LockTimeout  time.Duration
+}
+
+// WebhookConfig controls outbound webhook deliveries. Each request times out
+// after DeliveryTimeout and a delivery is given up after MaxAttempts. A
+// subscription is disabled once DisableAfterFailures deliveries in a row have
+// been given up.
+type WebhookConfig struct {
+	DeliveryTimeout      time.Duration
+	MaxAttempts          int
+	DisableAfterFailures int
+}
+
+// StreamConfig controls the server-sent event stream of order updates. Idle
+// streams get a comment every Heartbeat, events are kept for Retention so
+// reconnecting clients can resume, and a client more than BufferSize events
+// behind is disconnected.
+type StreamConfig struct {
+	Heartbeat  time.Duration
+	Retention  time.Duration
+	BufferSize int
+}
+
+// AuditConfig controls how long the audit log is kept. Entries older than
+// Retention are purged every PurgeInterval; a zero Retention keeps them
+// forever.
+type AuditConfig struct {
+	Retention     time.Duration
+	PurgeInterval time.Duration
+}
+
+// PartitionConfig controls the monthly partitions of the orders tables. Every
+// Interval, partitions are created for the current month and PremakeMonths
+// ahead, and partitions entirely older than RetentionMonths are detached, or
+// dropped when DropExpired is set; a zero RetentionMonths keeps them forever.
+// The payments, refunds, returns and other rows of a detached month's orders
+// are moved to the archive tables, and deleted with a dropped month. Months
+// that still hold open orders are never expired.
+type PartitionConfig struct {
+	Enabled         bool
+	Interval        time.Duration
+	PremakeMonths   int
+	RetentionMonths int
+	DropExpired     bool
+}
+
+type ShippingConfig struct {
+	Calculator string
+	RatesFile  string
+}
+
+// LogConfig configures the log output. Format is console, json or ecs. Logs
+// go to File, rotated once it reaches FileMaxSizeMB, instead of stdout when it
+// is set. Beyond SamplingBurst info and debug logs per SamplingPeriod, only
+// one in SamplingEvery is kept; a zero burst keeps them all. Fields whose
+// key is in RedactKeys are logged redacted.
+type LogConfig struct {
+	Format         string
+	Level          string
+	File           string
+	FileMaxSizeMB  int
+	FileMaxBackups int
+	FileMaxAgeDays int
+	FileCompress   bool
+	SamplingBurst  int
+	SamplingPeriod time.Duration
+	SamplingEvery  int
+	RedactKeys     []string
+}
+
+// RateLimitRule allows a client Requests requests per Period, in bursts of
+// up to Requests. The zero rule does not limit.
+type RateLimitRule struct {
+	Requests int
+	Period   time.Duration
+}
+
+// RateLimitConfig limits each client per route to the rule of the route in
+// Routes, keyed by method and path pattern such as "POST /api/v1/orders", or
+// else to Default. Store is memory, for a single replica, or postgres, to
+// share the limits between replicas.
+type RateLimitConfig struct {
+	Enabled bool
+	Store   string
+	Default RateLimitRule
+	Routes  map[string]RateLimitRule
+}
+
+type TaxConfig struct {
+	Calculator       string
+	RatesFile        string
+	DefaultRegion    string
+	PricesIncludeTax bool
+	Rounding         string
+}
+
 func LoadConfig(envPath string) (*Config, error) {
 	if envPath == "" {
 		envPath = ".env"
@@ -66,6 +261,75 @@ func LoadConfig(envPath string) (*Config, error) {
 	viper.AutomaticEnv()
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
+	viper.SetDefault("HTTP_ALLOWED_ORIGINS", "*")
+	viper.SetDefault("HTTP_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS")
+	viper.SetDefault("HTTP_ALLOWED_HEADERS", "Origin,Content-Type,Accept,Authorization,X-API-Key,X-Read-Your-Writes,Last-Event-ID")
+	viper.SetDefault("HTTP_MAX_BODY_SIZE", "1MB")
+	viper.SetDefault("HTTP_READ_TIMEOUT", "15s")
+	viper.SetDefault("HTTP_WRITE_TIMEOUT", "30s")
+	viper.SetDefault("HTTP_IDLE_TIMEOUT", "2m")
+	viper.SetDefault("HTTP_HSTS_MAX_AGE", "8760h")
+	viper.SetDefault("HTTP_FRAME_OPTIONS", "DENY")
+	viper.SetDefault("HTTP_VALIDATE_REQUESTS", false)
+	viper.SetDefault("FX_BASE_CURRENCY", "IDR")
+	viper.SetDefault("FX_PROVIDER", "memory")
+	viper.SetDefault("TAX_CALCULATOR", "none")
+	viper.SetDefault("TAX_DEFAULT_REGION", "ID")
+	viper.SetDefault("TAX_ROUNDING", "line")
+	viper.SetDefault("SHIPPING_CALCULATOR", "free")
+	viper.SetDefault("PAYMENT_PROVIDER", "fake")
+	viper.SetDefault("PAYMENT_WEBHOOK_TOLERANCE", "5m")
+	viper.SetDefault("PAYMENT_REFUND_MAX_ATTEMPTS", 3)
+	viper.SetDefault("RETURN_WINDOW_DAYS", 30)
+	viper.SetDefault("ORDER_EXPIRY_ENABLED", true)
+	viper.SetDefault("ORDER_EXPIRY_PENDING_TTL", "30m")
+	viper.SetDefault("ORDER_EXPIRY_INTERVAL", "1m")
+	viper.SetDefault("ORDER_EXPIRY_BATCH_SIZE", 100)
+	viper.SetDefault("JOBS_ENABLED", true)
+	viper.SetDefault("JOBS_CONCURRENCY", 4)
+	viper.SetDefault("JOBS_POLL_INTERVAL", "1s")
+	viper.SetDefault("JOBS_MAX_ATTEMPTS", 5)
+	viper.SetDefault("JOBS_BACKOFF_BASE", "10s")
+	viper.SetDefault("JOBS_BACKOFF_MAX", "1h")
+	viper.SetDefault("JOBS_LOCK_TIMEOUT", "15m")
+	viper.SetDefault("WEBHOOK_DELIVERY_TIMEOUT", "10s")
+	viper.SetDefault("WEBHOOK_MAX_ATTEMPTS", 6)
+	viper.SetDefault("WEBHOOK_DISABLE_AFTER_FAILURES", 5)
+	viper.SetDefault("STREAM_HEARTBEAT", "15s")
+	viper.SetDefault("STREAM_RETENTION", "5m")
+	viper.SetDefault("STREAM_BUFFER_SIZE", 32)
+	viper.SetDefault("AUDIT_RETENTION", "2160h")
+	viper.SetDefault("AUDIT_PURGE_INTERVAL", "1h")
+	viper.SetDefault("POSTGRES_REPLICA_CHECK_INTERVAL", "5s")
+	viper.SetDefault("POSTGRES_MAX_OPEN_CONNS", 25)
+	viper.SetDefault("POSTGRES_MAX_IDLE_CONNS", 10)
+	viper.SetDefault("POSTGRES_CONN_MAX_LIFETIME", "30m")
+	viper.SetDefault("POSTGRES_CONN_MAX_IDLE_TIME", "5m")
+	viper.SetDefault("POSTGRES_STATEMENT_TIMEOUT", "60s")
+	viper.SetDefault("POSTGRES_LOCK_TIMEOUT", "10s")
+	viper.SetDefault("POSTGRES_POOL_WAIT_THRESHOLD", "100ms")
+	viper.SetDefault("POSTGRES_SLOW_QUERY_THRESHOLD", "100ms")
+	viper.SetDefault("POSTGRES_EXPLAIN_SLOW_QUERIES", false)
+	viper.SetDefault("PARTITION_ENABLED", true)
+	viper.SetDefault("PARTITION_INTERVAL", "24h")
+	viper.SetDefault("PARTITION_PREMAKE_MONTHS", 3)
+	viper.SetDefault("PARTITION_RETENTION_MONTHS", 0)
+	viper.SetDefault("PARTITION_DROP_EXPIRED", false)
+	viper.SetDefault("RATE_LIMIT_ENABLED", true)
+	viper.SetDefault("RATE_LIMIT_STORE", "memory")
+	viper.SetDefault("RATE_LIMIT_DEFAULT", "300/1m")
+	viper.SetDefault("RATE_LIMIT_ROUTES", "POST /api/v1/orders=10/1m,POST /api/v1/payments/webhook=off")
+	viper.SetDefault("LOG_FORMAT", "json")
+	viper.SetDefault("LOG_LEVEL", "info")
+	viper.SetDefault("LOG_FILE_MAX_SIZE_MB", 100)
+	viper.SetDefault("LOG_FILE_MAX_BACKUPS", 5)
+	viper.SetDefault("LOG_FILE_MAX_AGE_DAYS", 30)
+	viper.SetDefault("LOG_FILE_COMPRESS", false)
+	viper.SetDefault("LOG_SAMPLING_BURST", 0)
+	viper.SetDefault("LOG_SAMPLING_PERIOD", "1s")
+	viper.SetDefault("LOG_SAMPLING_EVERY", 100)
+	viper.SetDefault("LOG_REDACT_KEYS", "authorization,cookie,set-cookie,x-api-key,api_key,password,secret,token,access_token,refresh_token,query_args")
+
 	if err := viper.ReadInConfig(); err != nil {
 		var cfgErr viper.ConfigFileNotFoundError
 		if !errors.As(err, &cfgErr) {
@@ -73,6 +337,33 @@ func LoadConfig(envPath string) (*Config, error) {
 		}
 	}
 
+	maxBodySize, err := bytes.Parse(viper.GetString("HTTP_MAX_BODY_SIZE"))
+	if err != nil || maxBodySize <= 0 {
+		return nil, errors.Newf("invalid HTTP_MAX_BODY_SIZE %q", viper.GetString("HTTP_MAX_BODY_SIZE"))
+	}
+
+	// POSTGRES_CONN_MAX_LIFETIME used to be a number of seconds, and
+	// POSTGRES_SLOW_QUERY_THRESHOLD one of milliseconds.
+	connMaxLifetime, err := getLegacyDuration("POSTGRES_CONN_MAX_LIFETIME", time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	slowQueryThreshold, err := getLegacyDuration("POSTGRES_SLOW_QUERY_THRESHOLD", time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultRateLimit, err := parseRateLimitRule(viper.GetString("RATE_LIMIT_DEFAULT"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid RATE_LIMIT_DEFAULT")
+	}
+
+	routeRateLimits, err := parseRateLimitRoutes(viper.GetString("RATE_LIMIT_ROUTES"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid RATE_LIMIT_ROUTES")
+	}
+
 	config := &Config{
 		App: &AppConfig{
 			Name:        viper.GetString("APP_NAME"),
@@ -96,22 +387,205 @@ func LoadConfig(envPath string) (*Config, error) {
 			BasePath:           viper.GetString("HTTP_BASE_PATH"),
 			DomainName:         viper.GetString("HTTP_DOMAIN_NAME"),
 			EnableMigrationAPI: viper.GetBool("HTTP_ENABLE_MIGRATION_API"),
+			AdminAPIKey:        viper.GetString("HTTP_ADMIN_API_KEY"),
+			AllowedOrigins:     splitList(viper.GetString("HTTP_ALLOWED_ORIGINS")),
+			AllowedMethods:     splitList(viper.GetString("HTTP_ALLOWED_METHODS")),
+			AllowedHeaders:     splitList(viper.GetString("HTTP_ALLOWED_HEADERS")),
+			MaxBodySize:        maxBodySize,
+			ReadTimeout:        viper.GetDuration("HTTP_READ_TIMEOUT"),
+			WriteTimeout:       viper.GetDuration("HTTP_WRITE_TIMEOUT"),
+			IdleTimeout:        viper.GetDuration("HTTP_IDLE_TIMEOUT"),
+			HSTSMaxAge:         viper.GetDuration("HTTP_HSTS_MAX_AGE"),
+			FrameOptions:       viper.GetString("HTTP_FRAME_OPTIONS"),
+			ValidateRequests:   viper.GetBool("HTTP_VALIDATE_REQUESTS"),
 		},
 		Postgres: &DatabaseConfig{
-			DSN:                viper.GetString("POSTGRES_DSN"),
-			MigrateDSN:         viper.GetString("POSTGRES_MIGRATE_DSN"),
-			DBName:             viper.GetString("POSTGRES_DB_NAME"),
-			MaxOpenConns:       viper.GetInt("POSTGRES_MAX_OPEN_CONNS"),
-			MaxIdleConns:       viper.GetInt("POSTGRES_MAX_IDLE_CONNS"),
-			ConnMaxLifetime:    viper.GetInt("POSTGRES_CONN_MAX_LIFETIME"),
-			SlowQueryThreshold: viper.GetInt("POSTGRES_SLOW_QUERY_THRESHOLD"),
-			Debug:              viper.GetBool("POSTGRES_DEBUG"),
+			DSN:                  viper.GetString("POSTGRES_DSN"),
+			ReplicaDSNs:          splitList(viper.GetString("POSTGRES_REPLICA_DSNS")),
+			ReplicaCheckInterval: viper.GetDuration("POSTGRES_REPLICA_CHECK_INTERVAL"),
+			MigrateDSN:           viper.GetString("POSTGRES_MIGRATE_DSN"),
+			DBName:               viper.GetString("POSTGRES_DB_NAME"),
+			MaxOpenConns:         viper.GetInt("POSTGRES_MAX_OPEN_CONNS"),
+			MaxIdleConns:         viper.GetInt("POSTGRES_MAX_IDLE_CONNS"),
+			ConnMaxLifetime:      connMaxLifetime,
+			ConnMaxIdleTime:      viper.GetDuration("POSTGRES_CONN_MAX_IDLE_TIME"),
+			StatementTimeout:     viper.GetDuration("POSTGRES_STATEMENT_TIMEOUT"),
+			LockTimeout:          viper.GetDuration("POSTGRES_LOCK_TIMEOUT"),
+			PoolWaitThreshold:    viper.GetDuration("POSTGRES_POOL_WAIT_THRESHOLD"),
+			SlowQueryThreshold:   slowQueryThreshold,
+			ExplainSlowQueries:   viper.GetBool("POSTGRES_EXPLAIN_SLOW_QUERIES"),
+			Debug:                viper.GetBool("POSTGRES_DEBUG"),
 		},
 		GRPC: &GRPCConfig{
 			InventoryHost: viper.GetString("GRPC_INVENTORY_HOST"),
 			InventoryPort: viper.GetInt("GRPC_INVENTORY_PORT"),
 		},
+		FX: &FXConfig{
+			BaseCurrency: strings.ToUpper(viper.GetString("FX_BASE_CURRENCY")),
+			Provider:     viper.GetString("FX_PROVIDER"),
+			RatesFile:    viper.GetString("FX_RATES_FILE"),
+		},
+		Tax: &TaxConfig{
+			Calculator:       viper.GetString("TAX_CALCULATOR"),
+			RatesFile:        viper.GetString("TAX_RATES_FILE"),
+			DefaultRegion:    strings.ToUpper(viper.GetString("TAX_DEFAULT_REGION")),
+			PricesIncludeTax: viper.GetBool("TAX_PRICES_INCLUDE_TAX"),
+			Rounding:         viper.GetString("TAX_ROUNDING"),
+		},
+		Shipping: &ShippingConfig{
+			Calculator: viper.GetString("SHIPPING_CALCULATOR"),
+			RatesFile:  viper.GetString("SHIPPING_RATES_FILE"),
+		},
+		Payment: &PaymentConfig{
+			Provider:          viper.GetString("PAYMENT_PROVIDER"),
+			WebhookSecret:     viper.GetString("PAYMENT_WEBHOOK_SECRET"),
+			WebhookTolerance:  viper.GetDuration("PAYMENT_WEBHOOK_TOLERANCE"),
+			RefundMaxAttempts: viper.GetInt("PAYMENT_REFUND_MAX_ATTEMPTS"),
+		},
+		Return: &ReturnConfig{
+			WindowDays: viper.GetInt("RETURN_WINDOW_DAYS"),
+			PolicyFile: viper.GetString("RETURN_POLICY_FILE"),
+		},
+		Expiry: &ExpiryConfig{
+			Enabled:    viper.GetBool("ORDER_EXPIRY_ENABLED"),
+			PendingTTL: viper.GetDuration("ORDER_EXPIRY_PENDING_TTL"),
+			Interval:   viper.GetDuration("ORDER_EXPIRY_INTERVAL"),
+			BatchSize:  viper.GetInt("ORDER_EXPIRY_BATCH_SIZE"),
+		},
+		Jobs: &JobsConfig{
+			Enabled:      viper.GetBool("JOBS_ENABLED"),
+			Concurrency:  viper.GetInt("JOBS_CONCURRENCY"),
+			PollInterval: viper.GetDuration("JOBS_POLL_INTERVAL"),
+			MaxAttempts:  viper.GetInt("JOBS_MAX_ATTEMPTS"),
+			BackoffBase:  viper.GetDuration("JOBS_BACKOFF_BASE"),
+			BackoffMax:   viper.GetDuration("JOBS_BACKOFF_MAX"),
+			LockTimeout:  viper.GetDuration("JOBS_LOCK_TIMEOUT"),
+		},
+		Webhook: &WebhookConfig{
+			DeliveryTimeout:      viper.GetDuration("WEBHOOK_DELIVERY_TIMEOUT"),
+			MaxAttempts:          viper.GetInt("WEBHOOK_MAX_ATTEMPTS"),
+			DisableAfterFailures: viper.GetInt("WEBHOOK_DISABLE_AFTER_FAILURES"),
+		},
+		Stream: &StreamConfig{
+			Heartbeat:  viper.GetDuration("STREAM_HEARTBEAT"),
+			Retention:  viper.GetDuration("STREAM_RETENTION"),
+			BufferSize: viper.GetInt("STREAM_BUFFER_SIZE"),
+		},
+		Audit: &AuditConfig{
+			Retention:     viper.GetDuration("AUDIT_RETENTION"),
+			PurgeInterval: viper.GetDuration("AUDIT_PURGE_INTERVAL"),
+		},
+		Partition: &PartitionConfig{
+			Enabled:         viper.GetBool("PARTITION_ENABLED"),
+			Interval:        viper.GetDuration("PARTITION_INTERVAL"),
+			PremakeMonths:   viper.GetInt("PARTITION_PREMAKE_MONTHS"),
+			RetentionMonths: viper.GetInt("PARTITION_RETENTION_MONTHS"),
+			DropExpired:     viper.GetBool("PARTITION_DROP_EXPIRED"),
+		},
+		RateLimit: &RateLimitConfig{
+			Enabled: viper.GetBool("RATE_LIMIT_ENABLED"),
+			Store:   strings.ToLower(viper.GetString("RATE_LIMIT_STORE")),
+			Default: defaultRateLimit,
+			Routes:  routeRateLimits,
+		},
+		Log: &LogConfig{
+			Format:         strings.ToLower(viper.GetString("LOG_FORMAT")),
+			Level:          strings.ToLower(viper.GetString("LOG_LEVEL")),
+			File:           viper.GetString("LOG_FILE"),
+			FileMaxSizeMB:  viper.GetInt("LOG_FILE_MAX_SIZE_MB"),
+			FileMaxBackups: viper.GetInt("LOG_FILE_MAX_BACKUPS"),
+			FileMaxAgeDays: viper.GetInt("LOG_FILE_MAX_AGE_DAYS"),
+			FileCompress:   viper.GetBool("LOG_FILE_COMPRESS"),
+			SamplingBurst:  viper.GetInt("LOG_SAMPLING_BURST"),
+			SamplingPeriod: viper.GetDuration("LOG_SAMPLING_PERIOD"),
+			SamplingEvery:  viper.GetInt("LOG_SAMPLING_EVERY"),
+			RedactKeys:     splitList(viper.GetString("LOG_REDACT_KEYS")),
+		},
 	}
 
 	return config, nil
 }
+
+// getLegacyDuration reads key as a duration such as 30m. A bare integer, as
+// the setting was written before it took a duration, is read in unit, so an
+// existing value keeps its meaning instead of becoming nanoseconds.
+func getLegacyDuration(key string, unit time.Duration) (time.Duration, error) {
+	s := strings.TrimSpace(viper.GetString(key))
+
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Duration(n) * unit, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, errors.Newf("invalid %s %q", key, s)
+	}
+
+	return d, nil
+}
+
+// splitList splits a comma separated list, dropping empty entries.
+func splitList(s string) []string {
+	var list []string
+
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// parseRateLimitRule parses a rule written as requests/period, such as
+// 10/1m, or off.
+func parseRateLimitRule(s string) (RateLimitRule, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.EqualFold(s, "off") {
+		return RateLimitRule{}, nil
+	}
+
+	requests, period, ok := strings.Cut(s, "/")
+	if !ok {
+		return RateLimitRule{}, errors.Newf("rate limit %q is not requests/period", s)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n <= 0 {
+		return RateLimitRule{}, errors.Newf("rate limit %q has an invalid number of requests", s)
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || d <= 0 {
+		return RateLimitRule{}, errors.Newf("rate limit %q has an invalid period", s)
+	}
+
+	return RateLimitRule{Requests: n, Period: d}, nil
+}
+
+// parseRateLimitRoutes parses a comma separated list of route=rule pairs,
+// such as "POST /api/v1/orders=10/1m".
+func parseRateLimitRoutes(s string) (map[string]RateLimitRule, error) {
+	routes := make(map[string]RateLimitRule)
+
+	for _, item := range splitList(s) {
+		route, rule, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, errors.Newf("rate limit %q is not route=rule", item)
+		}
+
+		method, path, ok := strings.Cut(strings.TrimSpace(route), " ")
+		if !ok {
+			return nil, errors.Newf("rate limited route %q is not method path", route)
+		}
+
+		limit, err := parseRateLimitRule(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		routes[strings.ToUpper(method)+" "+strings.TrimSpace(path)] = limit
+	}
+
+	return routes, nil
+}
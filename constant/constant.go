@@ -3,12 +3,149 @@ package constant
 type OrderStatus string
 
 const (
-	OrderStatusConfirmed OrderStatus = "CONFIRMED"
-	OrderStatusRejected  OrderStatus = "REJECTED"
-	OrderStatusCancelled OrderStatus = "CANCELLED"
+	OrderStatusPendingPayment OrderStatus = "PENDING_PAYMENT"
+	OrderStatusConfirmed      OrderStatus = "CONFIRMED"
+	OrderStatusDelivered      OrderStatus = "DELIVERED"
+	OrderStatusRejected       OrderStatus = "REJECTED"
+	OrderStatusCancelled      OrderStatus = "CANCELLED"
+)
+
+// ClosedOrderStatuses are the statuses an order no longer leaves. Only closed
+// orders may be deleted or archived.
+var ClosedOrderStatuses = []string{string(OrderStatusDelivered), string(OrderStatusRejected), string(OrderStatusCancelled)}
+
+// CancellationReason records why an order was cancelled.
+type CancellationReason string
+
+const (
+	CancellationReasonRequested        CancellationReason = "requested"
+	CancellationReasonItemsCancelled   CancellationReason = "items_cancelled"
+	CancellationReasonStockUnavailable CancellationReason = "stock_unavailable"
+	CancellationReasonExpired          CancellationReason = "expired"
+)
+
+type PaymentStatus string
+
+const (
+	PaymentStatusPending   PaymentStatus = "PENDING"
+	PaymentStatusSucceeded PaymentStatus = "SUCCEEDED"
+	PaymentStatusFailed    PaymentStatus = "FAILED"
+)
+
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "REQUESTED"
+	ReturnStatusApproved  ReturnStatus = "APPROVED"
+	ReturnStatusRejected  ReturnStatus = "REJECTED"
+	ReturnStatusReceived  ReturnStatus = "RECEIVED"
+)
+
+type ReturnReasonCode string
+
+const (
+	ReturnReasonDamaged        ReturnReasonCode = "DAMAGED"
+	ReturnReasonDefective      ReturnReasonCode = "DEFECTIVE"
+	ReturnReasonWrongItem      ReturnReasonCode = "WRONG_ITEM"
+	ReturnReasonNotAsDescribed ReturnReasonCode = "NOT_AS_DESCRIBED"
+	ReturnReasonNoLongerNeeded ReturnReasonCode = "NO_LONGER_NEEDED"
+	ReturnReasonOther          ReturnReasonCode = "OTHER"
+)
+
+type RefundStatus string
+
+const (
+	RefundStatusRequested RefundStatus = "REQUESTED"
+	RefundStatusSucceeded RefundStatus = "SUCCEEDED"
+	RefundStatusFailed    RefundStatus = "FAILED"
+)
+
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "QUEUED"
+	JobStatusRunning   JobStatus = "RUNNING"
+	JobStatusSucceeded JobStatus = "SUCCEEDED"
+	JobStatusDead      JobStatus = "DEAD"
+)
+
+type WebhookEventType string
+
+const (
+	WebhookEventOrderCreated       WebhookEventType = "order.created"
+	WebhookEventOrderUpdated       WebhookEventType = "order.updated"
+	WebhookEventOrderConfirmed     WebhookEventType = "order.confirmed"
+	WebhookEventOrderItemCancelled WebhookEventType = "order.item_cancelled"
+	WebhookEventOrderCancelled     WebhookEventType = "order.cancelled"
+	WebhookEventOrderDelivered     WebhookEventType = "order.delivered"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+type AuditEntityType string
+
+const (
+	AuditEntityOrder               AuditEntityType = "order"
+	AuditEntityCoupon              AuditEntityType = "coupon"
+	AuditEntityRefund              AuditEntityType = "refund"
+	AuditEntityReturn              AuditEntityType = "return"
+	AuditEntityWebhookSubscription AuditEntityType = "webhook_subscription"
+)
+
+type AuditAction string
+
+const (
+	AuditActionOrderCreate     AuditAction = "order.create"
+	AuditActionOrderUpdate     AuditAction = "order.update_items"
+	AuditActionOrderCancel     AuditAction = "order.cancel"
+	AuditActionOrderCancelItem AuditAction = "order.cancel_item"
+	AuditActionOrderConfirm    AuditAction = "order.confirm"
+	AuditActionOrderDeliver    AuditAction = "order.deliver"
+	AuditActionOrderDelete     AuditAction = "order.delete"
+	AuditActionOrderRestore    AuditAction = "order.restore"
+
+	AuditActionCouponCreate AuditAction = "coupon.create"
+	AuditActionCouponUpdate AuditAction = "coupon.update"
+	AuditActionCouponDelete AuditAction = "coupon.delete"
+
+	AuditActionRefundCreate AuditAction = "refund.create"
+	AuditActionRefundRetry  AuditAction = "refund.retry"
+
+	AuditActionReturnCreate  AuditAction = "return.create"
+	AuditActionReturnApprove AuditAction = "return.approve"
+	AuditActionReturnReject  AuditAction = "return.reject"
+	AuditActionReturnReceive AuditAction = "return.receive"
+
+	AuditActionWebhookSubscriptionCreate AuditAction = "webhook_subscription.create"
+	AuditActionWebhookSubscriptionUpdate AuditAction = "webhook_subscription.update"
+	AuditActionWebhookSubscriptionDelete AuditAction = "webhook_subscription.delete"
+)
+
+type CouponType string
+
+const (
+	CouponTypePercentage CouponType = "PERCENTAGE"
+	CouponTypeFixed      CouponType = "FIXED"
+	CouponTypeFreeItem   CouponType = "FREE_ITEM"
+)
+
+type AdjustmentType string
+
+const (
+	AdjustmentTypeDiscount AdjustmentType = "DISCOUNT"
+	AdjustmentTypeShipping AdjustmentType = "SHIPPING"
+
+	// AdjustmentTypeCancellation gives back the discount share of cancelled
+	// units, whose price has been taken out of the order subtotal.
+	AdjustmentTypeCancellation AdjustmentType = "CANCELLATION"
 )
 
 const (
 	CtxKeyRequestID = "request_id"
-	CtxKeySubLogger = "sub_logger"
 )
@@ -0,0 +1,90 @@
+package fxrate
+
+import (
+	"context"
+	"fmt"
+	"order-service/config"
+	"order-service/pkg/money"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	ProviderStatic = "static"
+	ProviderMemory = "memory"
+)
+
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// FXRateProvider converts amounts from one currency into another. Rates are
+// expressed as units of To per one unit of From.
+type FXRateProvider interface {
+	GetRate(ctx context.Context, from, to string) (*FXRate, error)
+}
+
+type FXRate struct {
+	From   string
+	To     string
+	Rate   money.Rate
+	Source string
+	AsOf   time.Time
+}
+
+func NewFXRateProvider(cfg *config.Config) (FXRateProvider, error) {
+	switch cfg.FX.Provider {
+	case "", ProviderMemory:
+		return NewInMemoryProvider(cfg.FX.BaseCurrency, nil), nil
+	case ProviderStatic:
+		return NewStaticFileProvider(cfg.FX.RatesFile)
+	default:
+		return nil, fmt.Errorf("unknown fx rate provider %q", cfg.FX.Provider)
+	}
+}
+
+// rateTable resolves direct, inverse and cross rates from a set of quotes
+// against a single base currency.
+type rateTable struct {
+	base   string
+	quotes map[string]money.Rate
+	asOf   time.Time
+	source string
+}
+
+func (t *rateTable) lookup(from, to string) (*FXRate, error) {
+	if from == to {
+		return &FXRate{From: from, To: to, Rate: money.OneRate(), Source: "identity", AsOf: t.asOf}, nil
+	}
+
+	fromBase, err := t.perBase(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toBase, err := t.perBase(to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FXRate{
+		From:   from,
+		To:     to,
+		Rate:   toBase.Mul(fromBase.Inverse()).Rounded(),
+		Source: t.source,
+		AsOf:   t.asOf,
+	}, nil
+}
+
+// perBase returns how many units of currency one unit of the base buys.
+func (t *rateTable) perBase(currency string) (money.Rate, error) {
+	if currency == t.base {
+		return money.OneRate(), nil
+	}
+
+	r, ok := t.quotes[currency]
+	if !ok {
+		return money.Rate{}, errors.Wrapf(ErrRateNotFound, "no %s/%s quote", t.base, currency)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,58 @@
+package fxrate_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"order-service/internal/adapter/fxrate"
+	"order-service/pkg/money"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticFileProvider_DirectInverseAndCrossRates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"base": "IDR",
+		"as_of": "2025-01-01T00:00:00Z",
+		"rates": {"USD": "0.00005", "SGD": "0.0001"}
+	}`), 0o600))
+
+	p, err := fxrate.NewStaticFileProvider(path)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	direct, err := p.GetRate(ctx, "IDR", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, "0.00005", direct.Rate.String())
+	assert.Equal(t, 2025, direct.AsOf.Year())
+
+	inverse, err := p.GetRate(ctx, "USD", "IDR")
+	require.NoError(t, err)
+	assert.Equal(t, "20000", inverse.Rate.String())
+
+	cross, err := p.GetRate(ctx, "USD", "SGD")
+	require.NoError(t, err)
+	assert.Equal(t, "2", cross.Rate.String())
+
+	_, err = p.GetRate(ctx, "IDR", "EUR")
+	require.ErrorIs(t, err, fxrate.ErrRateNotFound)
+}
+
+func TestInMemoryProvider_Set(t *testing.T) {
+	p := fxrate.NewInMemoryProvider("IDR", nil)
+
+	_, err := p.GetRate(context.Background(), "IDR", "USD")
+	require.ErrorIs(t, err, fxrate.ErrRateNotFound)
+
+	p.Set("USD", money.MustParseRate("0.00006"))
+
+	r, err := p.GetRate(context.Background(), "IDR", "USD")
+	require.NoError(t, err)
+	assert.True(t, r.Rate.Equal(money.MustParseRate("0.00006")))
+	assert.Equal(t, fxrate.ProviderMemory, r.Source)
+}
@@ -0,0 +1,48 @@
+package fxrate
+
+import (
+	"context"
+	"maps"
+	"order-service/pkg/money"
+	"sync"
+	"time"
+)
+
+var _ FXRateProvider = (*InMemoryProvider)(nil)
+
+// InMemoryProvider keeps quotes in process memory. It is meant for tests and
+// for deployments that push rates in at runtime.
+type InMemoryProvider struct {
+	mu    sync.RWMutex
+	table rateTable
+}
+
+func NewInMemoryProvider(base string, quotes map[string]money.Rate) *InMemoryProvider {
+	q := make(map[string]money.Rate, len(quotes))
+	maps.Copy(q, quotes)
+
+	return &InMemoryProvider{
+		table: rateTable{
+			base:   base,
+			quotes: q,
+			asOf:   time.Now(),
+			source: ProviderMemory,
+		},
+	}
+}
+
+// Set stores the number of units of currency one unit of the base buys.
+func (p *InMemoryProvider) Set(currency string, rate money.Rate) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.table.quotes[currency] = rate
+	p.table.asOf = time.Now()
+}
+
+func (p *InMemoryProvider) GetRate(_ context.Context, from, to string) (*FXRate, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.table.lookup(from, to)
+}
@@ -0,0 +1,64 @@
+package fxrate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"order-service/pkg/money"
+	"os"
+	"time"
+)
+
+var _ FXRateProvider = (*StaticFileProvider)(nil)
+
+// StaticFileProvider serves rates loaded once from a JSON file such as:
+//
+//	{"base": "IDR", "as_of": "2025-01-01T00:00:00Z", "rates": {"USD": "0.0000615"}}
+type StaticFileProvider struct {
+	table rateTable
+}
+
+type staticFile struct {
+	Base  string                `json:"base"`
+	AsOf  time.Time             `json:"as_of"`
+	Rates map[string]money.Rate `json:"rates"`
+}
+
+func NewStaticFileProvider(path string) (*StaticFileProvider, error) {
+	if path == "" {
+		return nil, fmt.Errorf("fx rates file is required for the %s provider", ProviderStatic)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fx rates file: %w", err)
+	}
+
+	var file staticFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse fx rates file: %w", err)
+	}
+
+	if !money.IsCurrencyCode(file.Base) {
+		return nil, fmt.Errorf("fx rates file has invalid base currency %q", file.Base)
+	}
+
+	for code := range file.Rates {
+		if !money.IsCurrencyCode(code) {
+			return nil, fmt.Errorf("fx rates file has invalid currency %q", code)
+		}
+	}
+
+	return &StaticFileProvider{
+		table: rateTable{
+			base:   file.Base,
+			quotes: file.Rates,
+			asOf:   file.AsOf,
+			source: ProviderStatic + ":" + path,
+		},
+	}, nil
+}
+
+func (p *StaticFileProvider) GetRate(_ context.Context, from, to string) (*FXRate, error) {
+	return p.table.lookup(from, to)
+}
@@ -0,0 +1,99 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"order-service/internal/shared"
+	"order-service/pkg/money"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+var _ PaymentProvider = (*FakeProvider)(nil)
+
+// FakeProvider is a local stand-in for a payment gateway. Payments it starts
+// never complete on their own; complete them by posting a signed webhook such
+// as:
+//
+//	{"id": "evt_1", "type": "payment.succeeded",
+//	 "data": {"reference": "pay_...", "amount": "100000.00", "currency": "IDR"}}
+//
+// signed with Sign and the configured secret.
+type FakeProvider struct {
+	secret    string
+	tolerance time.Duration
+}
+
+type fakeWebhook struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Reference     string      `json:"reference"`
+		Amount        money.Money `json:"amount"`
+		Currency      string      `json:"currency"`
+		FailureReason string      `json:"failure_reason"`
+	} `json:"data"`
+}
+
+func NewFakeProvider(secret string, tolerance time.Duration) *FakeProvider {
+	return &FakeProvider{secret: secret, tolerance: tolerance}
+}
+
+func (p *FakeProvider) Name() string {
+	return ProviderFake
+}
+
+func (p *FakeProvider) CreatePayment(_ context.Context, req *CreatePaymentRequest) (*CreatePaymentResponse, error) {
+	id, err := shared.GenerateUUIDString()
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreatePaymentResponse{
+		ProviderReference: "fake_" + id,
+		CheckoutURL:       "https://payments.invalid/checkout/" + req.Reference,
+	}, nil
+}
+
+// Refund succeeds immediately; the fake gateway has no settlement delay.
+func (p *FakeProvider) Refund(_ context.Context, req *RefundRequest) (*RefundResponse, error) {
+	if req.Amount.IsNegative() || req.Amount.IsZero() {
+		return nil, errors.New("refund amount must be positive")
+	}
+
+	return &RefundResponse{ProviderReference: "fake_" + req.Reference}, nil
+}
+
+func (p *FakeProvider) ParseWebhook(header http.Header, body []byte, now time.Time) (*WebhookEvent, error) {
+	err := Verify(p.secret, header.Get(HeaderTimestamp), header.Get(HeaderSignature), body, now, p.tolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	var hook fakeWebhook
+	if err := json.Unmarshal(body, &hook); err != nil {
+		return nil, errors.Wrap(ErrInvalidWebhook, "malformed body")
+	}
+
+	if hook.ID == "" || hook.Data.Reference == "" {
+		return nil, errors.Wrap(ErrInvalidWebhook, "event id and reference are required")
+	}
+
+	switch hook.Type {
+	case EventPaymentSucceeded, EventPaymentFailed:
+	default:
+		return nil, errors.Wrapf(ErrInvalidWebhook, "unsupported event type %q", hook.Type)
+	}
+
+	return &WebhookEvent{
+		ID:            hook.ID,
+		Type:          hook.Type,
+		Reference:     hook.Data.Reference,
+		Amount:        hook.Data.Amount,
+		Currency:      hook.Data.Currency,
+		FailureReason: hook.Data.FailureReason,
+		Payload:       body,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package payment_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"order-service/internal/adapter/payment"
+	"order-service/pkg/money"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signedHeader(secret string, ts time.Time, body []byte) http.Header {
+	h := http.Header{}
+	h.Set(payment.HeaderTimestamp, strconv.FormatInt(ts.Unix(), 10))
+	h.Set(payment.HeaderSignature, payment.Sign(secret, ts, body))
+
+	return h
+}
+
+func TestFakeProvider_ParseWebhook(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	p := payment.NewFakeProvider("secret", 5*time.Minute)
+	body := []byte(`{"id":"evt_1","type":"payment.succeeded","data":{"reference":"pay_1","amount":"100.50","currency":"IDR"}}`)
+
+	event, err := p.ParseWebhook(signedHeader("secret", now, body), body, now)
+	require.NoError(t, err)
+	assert.Equal(t, "evt_1", event.ID)
+	assert.Equal(t, payment.EventPaymentSucceeded, event.Type)
+	assert.Equal(t, "pay_1", event.Reference)
+	assert.Equal(t, money.MustParse("100.50"), event.Amount)
+	assert.Equal(t, "IDR", event.Currency)
+}
+
+func TestFakeProvider_ParseWebhook_Rejects(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	body := []byte(`{"id":"evt_1","type":"payment.succeeded","data":{"reference":"pay_1","amount":"1","currency":"IDR"}}`)
+
+	tests := []struct {
+		name   string
+		secret string
+		header http.Header
+		body   []byte
+	}{
+		{name: "wrong secret", secret: "secret", header: signedHeader("other", now, body), body: body},
+		{name: "tampered body", secret: "secret", header: signedHeader("secret", now, body), body: append([]byte(" "), body...)},
+		{name: "replayed", secret: "secret", header: signedHeader("secret", now.Add(-6*time.Minute), body), body: body},
+		{name: "from the future", secret: "secret", header: signedHeader("secret", now.Add(6*time.Minute), body), body: body},
+		{name: "unsigned", secret: "secret", header: http.Header{}, body: body},
+		{name: "no secret configured", secret: "", header: signedHeader("", now, body), body: body},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := payment.NewFakeProvider(tt.secret, 5*time.Minute)
+
+			_, err := p.ParseWebhook(tt.header, tt.body, now)
+			assert.ErrorIs(t, err, payment.ErrInvalidWebhook)
+		})
+	}
+}
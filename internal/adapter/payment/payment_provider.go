@@ -0,0 +1,81 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"order-service/config"
+	"order-service/pkg/money"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	ProviderFake = "fake"
+
+	EventPaymentSucceeded = "payment.succeeded"
+	EventPaymentFailed    = "payment.failed"
+)
+
+var ErrInvalidWebhook = errors.New("invalid payment webhook")
+
+// PaymentProvider starts payments with an external gateway, refunds them and
+// verifies the callbacks it sends back.
+type PaymentProvider interface {
+	Name() string
+	CreatePayment(ctx context.Context, req *CreatePaymentRequest) (*CreatePaymentResponse, error)
+	// Refund returns part or all of a captured payment. Reference identifies
+	// the refund and must make retries of the same refund idempotent.
+	Refund(ctx context.Context, req *RefundRequest) (*RefundResponse, error)
+	// ParseWebhook authenticates a callback and decodes it. It returns an
+	// error wrapping ErrInvalidWebhook when the request cannot be trusted.
+	ParseWebhook(header http.Header, body []byte, now time.Time) (*WebhookEvent, error)
+}
+
+type CreatePaymentRequest struct {
+	// Reference is generated by us and echoed back in webhooks, so a callback
+	// can be matched even if it arrives before CreatePayment returns.
+	Reference   string
+	OrderID     uint32
+	Amount      money.Money
+	Currency    string
+	Description string
+}
+
+type CreatePaymentResponse struct {
+	ProviderReference string
+	CheckoutURL       string
+}
+
+type RefundRequest struct {
+	Reference                string
+	PaymentReference         string
+	PaymentProviderReference string
+	Amount                   money.Money
+	Currency                 string
+	Reason                   string
+}
+
+type RefundResponse struct {
+	ProviderReference string
+}
+
+type WebhookEvent struct {
+	ID            string
+	Type          string
+	Reference     string
+	Amount        money.Money
+	Currency      string
+	FailureReason string
+	Payload       []byte
+}
+
+func NewPaymentProvider(cfg *config.Config) (PaymentProvider, error) {
+	switch cfg.Payment.Provider {
+	case "", ProviderFake:
+		return NewFakeProvider(cfg.Payment.WebhookSecret, cfg.Payment.WebhookTolerance), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.Payment.Provider)
+	}
+}
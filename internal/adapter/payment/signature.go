@@ -0,0 +1,52 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	HeaderSignature = "X-Payment-Signature"
+	HeaderTimestamp = "X-Payment-Timestamp"
+)
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>". Binding the
+// timestamp into the signature lets receivers reject replayed requests.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks signature against body and rejects timestamps more than
+// tolerance away from now.
+func Verify(secret, timestamp, signature string, body []byte, now time.Time, tolerance time.Duration) error {
+	if secret == "" {
+		return errors.Wrap(ErrInvalidWebhook, "webhook secret is not configured")
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.Wrap(ErrInvalidWebhook, "missing or malformed timestamp")
+	}
+
+	ts := time.Unix(unix, 0)
+	if d := now.Sub(ts); d > tolerance || d < -tolerance {
+		return errors.Wrap(ErrInvalidWebhook, "timestamp is outside the replay window")
+	}
+
+	expected := Sign(secret, ts, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return errors.Wrap(ErrInvalidWebhook, "signature mismatch")
+	}
+
+	return nil
+}
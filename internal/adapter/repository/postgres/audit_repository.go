@@ -0,0 +1,137 @@
+package postgresrepository
+
+import (
+	"context"
+	"order-service/internal/adapter/repository/postgres/model"
+	"order-service/internal/domain/entity"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/logger"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+var _ AuditRepository = (*auditRepository)(nil)
+
+type AuditRepository interface {
+	Find(ctx context.Context, filter *FilterAuditLogPayload) ([]*entity.AuditLog, int, error)
+	Create(ctx context.Context, entry *entity.AuditLog) error
+	Purge(ctx context.Context, before time.Time) (int, error)
+}
+
+type auditRepository struct {
+	db     bun.IDB
+	logger logger.Logger
+}
+
+func NewAuditRepository(db bun.IDB, logger logger.Logger) *auditRepository {
+	return &auditRepository{db: db, logger: logger}
+}
+
+func (r *auditRepository) GetTableName() string {
+	return "audit_log"
+}
+
+// FilterAuditLogPayload selects audit entries. Zero fields match everything;
+// From is inclusive and To exclusive.
+type FilterAuditLogPayload struct {
+	EntityType string
+	EntityID   uint32
+	ActorType  string
+	ActorID    string
+	Action     string
+	From       time.Time
+	To         time.Time
+	Page       int
+	PerPage    int
+}
+
+func (r *auditRepository) Find(ctx context.Context, filter *FilterAuditLogPayload) ([]*entity.AuditLog, int, error) {
+	var entries []*model.AuditLog
+
+	query := r.db.NewSelect().Model(&entries)
+
+	if filter.EntityType != "" {
+		query = query.Where("?TableAlias.entity_type = ?", filter.EntityType)
+	}
+
+	if filter.EntityID > 0 {
+		query = query.Where("?TableAlias.entity_id = ?", filter.EntityID)
+	}
+
+	if filter.ActorType != "" {
+		query = query.Where("?TableAlias.actor_type = ?", filter.ActorType)
+	}
+
+	if filter.ActorID != "" {
+		query = query.Where("?TableAlias.actor_id = ?", filter.ActorID)
+	}
+
+	if filter.Action != "" {
+		query = query.Where("?TableAlias.action = ?", filter.Action)
+	}
+
+	if !filter.From.IsZero() {
+		query = query.Where("?TableAlias.created_at >= ?", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		query = query.Where("?TableAlias.created_at < ?", filter.To)
+	}
+
+	totalCount, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, 0, exception.NewDBError(err, r.GetTableName(), "count audit log")
+	}
+
+	if totalCount == 0 {
+		return []*entity.AuditLog{}, 0, nil
+	}
+
+	if filter.PerPage > 0 {
+		query = query.Limit(filter.PerPage)
+	}
+
+	if filter.Page > 0 && filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query = query.Offset(offset)
+	}
+
+	query = query.OrderExpr("?TableAlias.id DESC")
+	if err := query.Scan(ctx); err != nil {
+		return nil, 0, exception.NewDBError(err, r.GetTableName(), "find audit log")
+	}
+
+	return model.ToAuditLogsDomain(entries), totalCount, nil
+}
+
+func (r *auditRepository) Create(ctx context.Context, entry *entity.AuditLog) error {
+	if entry == nil {
+		return exception.ErrDataNull
+	}
+
+	if _, err := r.db.NewInsert().Model(model.AsAuditLog(entry)).Exec(ctx); err != nil {
+		return exception.NewDBError(err, r.GetTableName(), "create audit log")
+	}
+
+	return nil
+}
+
+// Purge deletes entries recorded before before. It returns the number of
+// entries deleted.
+func (r *auditRepository) Purge(ctx context.Context, before time.Time) (int, error) {
+	res, err := r.db.NewDelete().
+		Model((*model.AuditLog)(nil)).
+		Where("?TableAlias.created_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, exception.NewDBError(err, r.GetTableName(), "purge audit log")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, exception.NewDBError(err, r.GetTableName(), "purge audit log")
+	}
+
+	return int(affected), nil
+}
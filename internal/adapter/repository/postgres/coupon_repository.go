@@ -0,0 +1,303 @@
+package postgresrepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"order-service/internal/adapter/repository/postgres/model"
+	"order-service/internal/domain/entity"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/logger"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+var _ CouponRepository = (*couponRepository)(nil)
+
+type CouponRepository interface {
+	FindByID(ctx context.Context, id uint32) (*entity.Coupon, error)
+	FindByCode(ctx context.Context, code string) (*entity.Coupon, error)
+	FindByCodesForUpdate(ctx context.Context, codes []string) ([]*entity.Coupon, error)
+	Find(ctx context.Context, filter *FilterCouponPayload) ([]*entity.Coupon, int, error)
+	Create(ctx context.Context, coupon *entity.Coupon) (*entity.Coupon, error)
+	Update(ctx context.Context, coupon *entity.Coupon) (*entity.Coupon, error)
+	Delete(ctx context.Context, id uint32) error
+	IncrementUsage(ctx context.Context, id uint32) (bool, error)
+	CountUsagesByUser(ctx context.Context, couponID, userID uint32) (int, error)
+	CreateUsage(ctx context.Context, usage *entity.CouponUsage) error
+}
+
+type couponRepository struct {
+	db     bun.IDB
+	logger logger.Logger
+}
+
+func NewCouponRepository(db bun.IDB, logger logger.Logger) *couponRepository {
+	return &couponRepository{db: db, logger: logger}
+}
+
+func (r *couponRepository) GetTableName() string {
+	return "coupons"
+}
+
+type FilterCouponPayload struct {
+	Search   string
+	IsActive *bool
+	Page     int
+	PerPage  int
+}
+
+func (r *couponRepository) Find(ctx context.Context, filter *FilterCouponPayload) ([]*entity.Coupon, int, error) {
+	var coupons []*model.Coupon
+
+	query := r.db.NewSelect().Model(&coupons).Relation("Products")
+
+	if filter.Search != "" {
+		query = query.Where("(coupon.code ILIKE ? OR coupon.name ILIKE ?)", "%"+filter.Search+"%", "%"+filter.Search+"%")
+	}
+
+	if filter.IsActive != nil {
+		query = query.Where("coupon.is_active = ?", *filter.IsActive)
+	}
+
+	totalCount, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, 0, exception.NewDBError(err, r.GetTableName(), "count coupon")
+	}
+
+	if totalCount == 0 {
+		return []*entity.Coupon{}, 0, nil
+	}
+
+	if filter.PerPage > 0 {
+		query = query.Limit(filter.PerPage)
+	}
+
+	if filter.Page > 0 && filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query = query.Offset(offset)
+	}
+
+	query = query.Order("id DESC")
+	if err := query.Scan(ctx); err != nil {
+		return nil, 0, exception.NewDBError(err, r.GetTableName(), "find coupon")
+	}
+
+	return model.ToCouponsDomain(coupons), totalCount, nil
+}
+
+func (r *couponRepository) FindByID(ctx context.Context, id uint32) (*entity.Coupon, error) {
+	var coupon model.Coupon
+
+	err := r.db.NewSelect().Model(&coupon).Where("id = ?", id).Relation("Products").Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, exception.NewDBError(err, r.GetTableName(), "FindByID")
+	}
+
+	return coupon.ToDomain(), nil
+}
+
+func (r *couponRepository) FindByCode(ctx context.Context, code string) (*entity.Coupon, error) {
+	var coupon model.Coupon
+
+	err := r.db.NewSelect().Model(&coupon).Where("code = ?", code).Relation("Products").Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, exception.NewDBError(err, r.GetTableName(), "FindByCode")
+	}
+
+	return coupon.ToDomain(), nil
+}
+
+// FindByCodesForUpdate locks the matching coupon rows until the surrounding
+// transaction ends, so usage checks and increments for the same coupon are
+// serialized across concurrent checkouts. Rows are locked in id order to
+// avoid deadlocks between orders that use the same coupons.
+func (r *couponRepository) FindByCodesForUpdate(ctx context.Context, codes []string) ([]*entity.Coupon, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	var coupons []*model.Coupon
+
+	err := r.db.NewSelect().
+		Model(&coupons).
+		Where("code IN (?)", bun.In(codes)).
+		Order("id ASC").
+		For("UPDATE").
+		Scan(ctx)
+	if err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "find coupon for update")
+	}
+
+	if len(coupons) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint32, 0, len(coupons))
+	for _, c := range coupons {
+		ids = append(ids, c.ID)
+	}
+
+	var products []*model.CouponProduct
+
+	err = r.db.NewSelect().Model(&products).Where("coupon_id IN (?)", bun.In(ids)).Scan(ctx)
+	if err != nil {
+		return nil, exception.NewDBError(err, "coupon_products", "find coupon products")
+	}
+
+	byCoupon := make(map[uint32][]*model.CouponProduct, len(coupons))
+	for _, p := range products {
+		byCoupon[p.CouponID] = append(byCoupon[p.CouponID], p)
+	}
+
+	for _, c := range coupons {
+		c.Products = byCoupon[c.ID]
+	}
+
+	return model.ToCouponsDomain(coupons), nil
+}
+
+func (r *couponRepository) Create(ctx context.Context, coupon *entity.Coupon) (*entity.Coupon, error) {
+	if coupon == nil {
+		return nil, exception.ErrDataNull
+	}
+
+	dbCoupon := model.AsCoupon(coupon)
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(dbCoupon).Exec(ctx); err != nil {
+			return exception.NewDBError(err, r.GetTableName(), "create coupon")
+		}
+
+		return r.replaceProducts(ctx, tx, dbCoupon)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbCoupon.ToDomain(), nil
+}
+
+func (r *couponRepository) Update(ctx context.Context, coupon *entity.Coupon) (*entity.Coupon, error) {
+	if coupon == nil || coupon.ID == 0 {
+		return nil, exception.ErrDataNull
+	}
+
+	dbCoupon := model.AsCoupon(coupon)
+	dbCoupon.UpdatedAt = time.Now()
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// used_count is owned by IncrementUsage and must not be overwritten
+		// with a stale value.
+		_, err := tx.NewUpdate().
+			Model(dbCoupon).
+			ExcludeColumn("used_count", "created_at").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return exception.NewDBError(err, r.GetTableName(), "update coupon")
+		}
+
+		return r.replaceProducts(ctx, tx, dbCoupon)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbCoupon.ToDomain(), nil
+}
+
+func (r *couponRepository) replaceProducts(ctx context.Context, tx bun.Tx, coupon *model.Coupon) error {
+	_, err := tx.NewDelete().Model((*model.CouponProduct)(nil)).Where("coupon_id = ?", coupon.ID).Exec(ctx)
+	if err != nil {
+		return exception.NewDBError(err, "coupon_products", "delete coupon products")
+	}
+
+	if len(coupon.Products) == 0 {
+		return nil
+	}
+
+	for _, p := range coupon.Products {
+		p.CouponID = coupon.ID
+	}
+
+	if _, err := tx.NewInsert().Model(&coupon.Products).Exec(ctx); err != nil {
+		return exception.NewDBError(err, "coupon_products", "create coupon products")
+	}
+
+	return nil
+}
+
+func (r *couponRepository) Delete(ctx context.Context, id uint32) error {
+	if id == 0 {
+		return exception.ErrIDNull
+	}
+
+	dbCoupon := &model.Coupon{Base: model.Base{ID: id}}
+
+	_, err := r.db.NewDelete().Model(dbCoupon).WherePK().Exec(ctx)
+	if err != nil {
+		return exception.NewDBError(err, r.GetTableName(), "delete coupon")
+	}
+
+	return nil
+}
+
+// IncrementUsage consumes one use of the coupon. It reports false when the
+// global usage limit has already been reached.
+func (r *couponRepository) IncrementUsage(ctx context.Context, id uint32) (bool, error) {
+	if id == 0 {
+		return false, exception.ErrIDNull
+	}
+
+	res, err := r.db.NewUpdate().
+		Model((*model.Coupon)(nil)).
+		Set("used_count = used_count + 1").
+		Where("id = ?", id).
+		Where("usage_limit = 0 OR used_count < usage_limit").
+		Exec(ctx)
+	if err != nil {
+		return false, exception.NewDBError(err, r.GetTableName(), "increment coupon usage")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, exception.NewDBError(err, r.GetTableName(), "increment coupon usage")
+	}
+
+	return affected == 1, nil
+}
+
+func (r *couponRepository) CountUsagesByUser(ctx context.Context, couponID, userID uint32) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*model.CouponUsage)(nil)).
+		Where("coupon_id = ?", couponID).
+		Where("user_id = ?", userID).
+		Count(ctx)
+	if err != nil {
+		return 0, exception.NewDBError(err, "coupon_usages", "count coupon usage")
+	}
+
+	return count, nil
+}
+
+func (r *couponRepository) CreateUsage(ctx context.Context, usage *entity.CouponUsage) error {
+	if usage == nil {
+		return exception.ErrDataNull
+	}
+
+	if _, err := r.db.NewInsert().Model(model.AsCouponUsage(usage)).Exec(ctx); err != nil {
+		return exception.NewDBError(err, "coupon_usages", "create coupon usage")
+	}
+
+	return nil
+}
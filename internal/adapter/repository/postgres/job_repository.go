@@ -0,0 +1,266 @@
+package postgresrepository
+
+import (
+	"context"
+	"order-service/constant"
+	"order-service/internal/adapter/repository/postgres/model"
+	"order-service/internal/domain/entity"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/logger"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+var _ JobRepository = (*jobRepository)(nil)
+
+type JobRepository interface {
+	Find(ctx context.Context, filter *FilterJobPayload) ([]*entity.Job, int, error)
+	Create(ctx context.Context, job *entity.Job) (*entity.Job, error)
+	Claim(ctx context.Context, limit int, lockTimeout time.Duration) ([]*entity.Job, error)
+	Complete(ctx context.Context, id uint32, lockedAt time.Time) (bool, error)
+	Reschedule(ctx context.Context, id uint32, lockedAt, runAt time.Time, lastError string) (bool, error)
+	DeadLetter(ctx context.Context, id uint32, lockedAt time.Time, lastError string) (bool, error)
+	Retry(ctx context.Context, ids []uint32) (int, error)
+	Purge(ctx context.Context, statuses []string, before time.Time) (int, error)
+}
+
+type jobRepository struct {
+	db     bun.IDB
+	logger logger.Logger
+}
+
+func NewJobRepository(db bun.IDB, logger logger.Logger) *jobRepository {
+	return &jobRepository{db: db, logger: logger}
+}
+
+func (r *jobRepository) GetTableName() string {
+	return "jobs"
+}
+
+type FilterJobPayload struct {
+	Status  string
+	Type    string
+	Page    int
+	PerPage int
+}
+
+func (r *jobRepository) Find(ctx context.Context, filter *FilterJobPayload) ([]*entity.Job, int, error) {
+	var jobs []*model.Job
+
+	query := r.db.NewSelect().Model(&jobs)
+
+	if filter.Status != "" {
+		query = query.Where("?TableAlias.status = ?", filter.Status)
+	}
+
+	if filter.Type != "" {
+		query = query.Where("?TableAlias.type = ?", filter.Type)
+	}
+
+	totalCount, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, 0, exception.NewDBError(err, r.GetTableName(), "count job")
+	}
+
+	if totalCount == 0 {
+		return []*entity.Job{}, 0, nil
+	}
+
+	if filter.PerPage > 0 {
+		query = query.Limit(filter.PerPage)
+	}
+
+	if filter.Page > 0 && filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query = query.Offset(offset)
+	}
+
+	query = query.OrderExpr("?TableAlias.id DESC")
+	if err := query.Scan(ctx); err != nil {
+		return nil, 0, exception.NewDBError(err, r.GetTableName(), "find job")
+	}
+
+	return model.ToJobsDomain(jobs), totalCount, nil
+}
+
+// Create queues a job. A job without a RunAt is due immediately.
+func (r *jobRepository) Create(ctx context.Context, job *entity.Job) (*entity.Job, error) {
+	if job == nil {
+		return nil, exception.ErrDataNull
+	}
+
+	dbJob := model.AsJob(job)
+	dbJob.Status = string(constant.JobStatusQueued)
+
+	if dbJob.RunAt.IsZero() {
+		dbJob.RunAt = time.Now()
+	}
+
+	if _, err := r.db.NewInsert().Model(dbJob).Exec(ctx); err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "create job")
+	}
+
+	return dbJob.ToDomain(), nil
+}
+
+// Claim marks up to limit due jobs as running and returns them, counting the
+// attempt. Rows locked by another worker are skipped rather than waited for.
+// A job that has been running for longer than lockTimeout is assumed to have
+// lost its worker and is claimed again; a zero lockTimeout, like for the
+// queue, never times a job out, so running jobs are left alone. Each claim
+// sets a new LockedAt, which the worker passes back when it records the
+// outcome.
+func (r *jobRepository) Claim(ctx context.Context, limit int, lockTimeout time.Duration) ([]*entity.Job, error) {
+	var jobs []*model.Job
+
+	now := time.Now()
+
+	due := r.db.NewSelect().
+		Model((*model.Job)(nil)).
+		Column("id").
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			q = q.Where("?TableAlias.status = ? AND ?TableAlias.run_at <= ?", string(constant.JobStatusQueued), now)
+
+			if lockTimeout > 0 {
+				q = q.WhereOr("?TableAlias.status = ? AND ?TableAlias.locked_at < ?", string(constant.JobStatusRunning), now.Add(-lockTimeout))
+			}
+
+			return q
+		}).
+		OrderExpr("?TableAlias.run_at ASC, ?TableAlias.id ASC").
+		Limit(limit).
+		For("UPDATE SKIP LOCKED")
+
+	_, err := r.db.NewUpdate().
+		Model((*model.Job)(nil)).
+		Set("status = ?", string(constant.JobStatusRunning)).
+		Set("attempts = ?TableAlias.attempts + 1").
+		Set("locked_at = ?", now).
+		Set("updated_at = ?", now).
+		Where("?TableAlias.id IN (?)", due).
+		Returning("*").
+		Exec(ctx, &jobs)
+	if err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "claim job")
+	}
+
+	return model.ToJobsDomain(jobs), nil
+}
+
+// Complete marks a job claimed at lockedAt as succeeded. The outcome of every
+// update below is only recorded while the job is still held by that claim; a
+// worker that overran the lock timeout and lost the job to another one gets
+// false instead.
+func (r *jobRepository) Complete(ctx context.Context, id uint32, lockedAt time.Time) (bool, error) {
+	now := time.Now()
+
+	query := r.db.NewUpdate().
+		Model((*model.Job)(nil)).
+		Set("status = ?", string(constant.JobStatusSucceeded)).
+		Set("locked_at = NULL").
+		Set("last_error = ''").
+		Set("completed_at = ?", now).
+		Set("updated_at = ?", now)
+
+	return r.finish(ctx, query, id, lockedAt, "complete job")
+}
+
+// Reschedule puts a failed job back in the queue to be retried at runAt.
+func (r *jobRepository) Reschedule(ctx context.Context, id uint32, lockedAt, runAt time.Time, lastError string) (bool, error) {
+	query := r.db.NewUpdate().
+		Model((*model.Job)(nil)).
+		Set("status = ?", string(constant.JobStatusQueued)).
+		Set("run_at = ?", runAt).
+		Set("locked_at = NULL").
+		Set("last_error = ?", lastError).
+		Set("updated_at = ?", time.Now())
+
+	return r.finish(ctx, query, id, lockedAt, "reschedule job")
+}
+
+// DeadLetter parks a job that will not be retried automatically.
+func (r *jobRepository) DeadLetter(ctx context.Context, id uint32, lockedAt time.Time, lastError string) (bool, error) {
+	now := time.Now()
+
+	query := r.db.NewUpdate().
+		Model((*model.Job)(nil)).
+		Set("status = ?", string(constant.JobStatusDead)).
+		Set("locked_at = NULL").
+		Set("last_error = ?", lastError).
+		Set("failed_at = ?", now).
+		Set("updated_at = ?", now)
+
+	return r.finish(ctx, query, id, lockedAt, "dead-letter job")
+}
+
+// finish runs an update that records the outcome of a job, restricted to the
+// claim made at lockedAt, and reports whether the job was still held by it.
+func (r *jobRepository) finish(ctx context.Context, query *bun.UpdateQuery, id uint32, lockedAt time.Time, action string) (bool, error) {
+	res, err := query.
+		Where("?TableAlias.id = ?", id).
+		Where("?TableAlias.status = ?", string(constant.JobStatusRunning)).
+		Where("?TableAlias.locked_at = ?", lockedAt).
+		Exec(ctx)
+	if err != nil {
+		return false, exception.NewDBError(err, r.GetTableName(), action)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, exception.NewDBError(err, r.GetTableName(), action)
+	}
+
+	return affected == 1, nil
+}
+
+// Retry queues dead jobs again with their attempts reset. Without ids, every
+// dead job is retried. It returns the number of jobs queued.
+func (r *jobRepository) Retry(ctx context.Context, ids []uint32) (int, error) {
+	now := time.Now()
+
+	query := r.db.NewUpdate().
+		Model((*model.Job)(nil)).
+		Set("status = ?", string(constant.JobStatusQueued)).
+		Set("attempts = 0").
+		Set("run_at = ?", now).
+		Set("failed_at = NULL").
+		Set("updated_at = ?", now).
+		Where("?TableAlias.status = ?", string(constant.JobStatusDead))
+
+	if len(ids) > 0 {
+		query = query.Where("?TableAlias.id IN (?)", bun.In(ids))
+	}
+
+	res, err := query.Exec(ctx)
+	if err != nil {
+		return 0, exception.NewDBError(err, r.GetTableName(), "retry job")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, exception.NewDBError(err, r.GetTableName(), "retry job")
+	}
+
+	return int(affected), nil
+}
+
+// Purge deletes jobs in one of statuses that were last updated before before.
+// It returns the number of jobs deleted.
+func (r *jobRepository) Purge(ctx context.Context, statuses []string, before time.Time) (int, error) {
+	res, err := r.db.NewDelete().
+		Model((*model.Job)(nil)).
+		Where("?TableAlias.status IN (?)", bun.In(statuses)).
+		Where("?TableAlias.updated_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, exception.NewDBError(err, r.GetTableName(), "purge job")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, exception.NewDBError(err, r.GetTableName(), "purge job")
+	}
+
+	return int(affected), nil
+}
@@ -0,0 +1,58 @@
+package postgresrepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"order-service/internal/adapter/repository/postgres/model"
+	"order-service/internal/domain/entity"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/logger"
+
+	"github.com/uptrace/bun"
+)
+
+var _ LocationRepository = (*locationRepository)(nil)
+
+type LocationRepository interface {
+	FindDistrictByID(ctx context.Context, id uint32) (*entity.District, error)
+}
+
+type locationRepository struct {
+	db     bun.IDB
+	logger logger.Logger
+}
+
+func NewLocationRepository(db bun.IDB, logger logger.Logger) *locationRepository {
+	return &locationRepository{db: db, logger: logger}
+}
+
+func (r *locationRepository) GetTableName() string {
+	return "districts"
+}
+
+// FindDistrictByID returns the district with its city and province. A
+// district whose city or province is missing is treated as not found.
+func (r *locationRepository) FindDistrictByID(ctx context.Context, id uint32) (*entity.District, error) {
+	var district model.District
+
+	err := r.db.NewSelect().
+		Model(&district).
+		Relation("City").
+		Relation("City.Province").
+		Where("district.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, exception.NewDBError(err, r.GetTableName(), "FindDistrictByID")
+	}
+
+	if district.City == nil || district.City.ID == 0 || district.City.Province == nil || district.City.Province.ID == 0 {
+		return nil, nil
+	}
+
+	return district.ToDomain(), nil
+}
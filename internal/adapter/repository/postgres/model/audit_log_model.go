@@ -0,0 +1,84 @@
+package model
+
+import (
+	"encoding/json"
+	"order-service/internal/domain/entity"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// AuditLog entries are never updated, so the table has no updated_at or
+// deleted_at column.
+type AuditLog struct {
+	bun.BaseModel `bun:"table:audit_log,alias:al"`
+	ID            uint64          `bun:"id,pk,autoincrement"`
+	ActorType     string          `bun:"actor_type,notnull"`
+	ActorID       string          `bun:"actor_id,notnull"`
+	RequestID     string          `bun:"request_id,notnull"`
+	IP            string          `bun:"ip,notnull"`
+	UserAgent     string          `bun:"user_agent,notnull"`
+	Action        string          `bun:"action,notnull"`
+	EntityType    string          `bun:"entity_type,notnull"`
+	EntityID      uint32          `bun:"entity_id,notnull"`
+	Changes       json.RawMessage `bun:"changes,type:jsonb,notnull"`
+	CreatedAt     time.Time       `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+func (m *AuditLog) ToDomain() *entity.AuditLog {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.AuditLog{
+		ID:         m.ID,
+		ActorType:  m.ActorType,
+		ActorID:    m.ActorID,
+		RequestID:  m.RequestID,
+		IP:         m.IP,
+		UserAgent:  m.UserAgent,
+		Action:     m.Action,
+		EntityType: m.EntityType,
+		EntityID:   m.EntityID,
+		Changes:    m.Changes,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+func ToAuditLogsDomain(arg []*AuditLog) []*entity.AuditLog {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*entity.AuditLog, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, arg[i].ToDomain())
+	}
+
+	return res
+}
+
+func AsAuditLog(arg *entity.AuditLog) *AuditLog {
+	if arg == nil {
+		return nil
+	}
+
+	return &AuditLog{
+		ID:         arg.ID,
+		ActorType:  arg.ActorType,
+		ActorID:    arg.ActorID,
+		RequestID:  arg.RequestID,
+		IP:         arg.IP,
+		UserAgent:  arg.UserAgent,
+		Action:     arg.Action,
+		EntityType: arg.EntityType,
+		EntityID:   arg.EntityID,
+		Changes:    arg.Changes,
+		CreatedAt:  arg.CreatedAt,
+	}
+}
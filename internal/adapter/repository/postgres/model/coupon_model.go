@@ -0,0 +1,134 @@
+package model
+
+import (
+	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Coupon struct {
+	bun.BaseModel `bun:"table:coupons,alias:coupon"`
+	Base
+	Code              string        `bun:"code,notnull"`
+	Name              string        `bun:"name,notnull"`
+	Description       string        `bun:"description,notnull"`
+	Type              string        `bun:"type,notnull"`
+	PercentOff        money.Percent `bun:"percent_off,type:numeric(9,4),notnull"`
+	AmountOff         money.Money   `bun:"amount_off,type:numeric(19,4),notnull"`
+	MaxDiscount       money.Money   `bun:"max_discount,type:numeric(19,4),notnull"`
+	MinSpend          money.Money   `bun:"min_spend,type:numeric(19,4),notnull"`
+	FreeProductID     string        `bun:"free_product_id,notnull"`
+	FreeQuantity      int           `bun:"free_quantity,notnull"`
+	StartsAt          *time.Time    `bun:"starts_at"`
+	EndsAt            *time.Time    `bun:"ends_at"`
+	UsageLimit        int           `bun:"usage_limit,notnull"`
+	UsageLimitPerUser int           `bun:"usage_limit_per_user,notnull"`
+	UsedCount         int           `bun:"used_count,notnull"`
+	IsActive          bool          `bun:"is_active,notnull"`
+
+	Products []*CouponProduct `bun:"rel:has-many,join:id=coupon_id"`
+}
+
+type CouponProduct struct {
+	bun.BaseModel `bun:"table:coupon_products"`
+	CouponID      uint32 `bun:"coupon_id,pk"`
+	ProductID     string `bun:"product_id,pk"`
+}
+
+func (m *Coupon) ToDomain() *entity.Coupon {
+	if m == nil {
+		return nil
+	}
+
+	res := &entity.Coupon{
+		Base: entity.Base{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			DeletedAt: m.DeletedAt,
+		},
+		Code:              m.Code,
+		Name:              m.Name,
+		Description:       m.Description,
+		Type:              m.Type,
+		PercentOff:        m.PercentOff,
+		AmountOff:         m.AmountOff,
+		MaxDiscount:       m.MaxDiscount,
+		MinSpend:          m.MinSpend,
+		FreeProductID:     m.FreeProductID,
+		FreeQuantity:      m.FreeQuantity,
+		StartsAt:          m.StartsAt,
+		EndsAt:            m.EndsAt,
+		UsageLimit:        m.UsageLimit,
+		UsageLimitPerUser: m.UsageLimitPerUser,
+		UsedCount:         m.UsedCount,
+		IsActive:          m.IsActive,
+	}
+
+	for _, p := range m.Products {
+		if p == nil {
+			continue
+		}
+
+		res.EligibleProductIDs = append(res.EligibleProductIDs, p.ProductID)
+	}
+
+	return res
+}
+
+func ToCouponsDomain(arg []*Coupon) []*entity.Coupon {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*entity.Coupon, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, arg[i].ToDomain())
+	}
+
+	return res
+}
+
+func AsCoupon(arg *entity.Coupon) *Coupon {
+	if arg == nil {
+		return nil
+	}
+
+	res := &Coupon{
+		Base: Base{
+			ID:        arg.ID,
+			CreatedAt: arg.CreatedAt,
+			UpdatedAt: arg.UpdatedAt,
+			DeletedAt: arg.DeletedAt,
+		},
+		Code:              arg.Code,
+		Name:              arg.Name,
+		Description:       arg.Description,
+		Type:              arg.Type,
+		PercentOff:        arg.PercentOff,
+		AmountOff:         arg.AmountOff,
+		MaxDiscount:       arg.MaxDiscount,
+		MinSpend:          arg.MinSpend,
+		FreeProductID:     arg.FreeProductID,
+		FreeQuantity:      arg.FreeQuantity,
+		StartsAt:          arg.StartsAt,
+		EndsAt:            arg.EndsAt,
+		UsageLimit:        arg.UsageLimit,
+		UsageLimitPerUser: arg.UsageLimitPerUser,
+		UsedCount:         arg.UsedCount,
+		IsActive:          arg.IsActive,
+	}
+
+	for _, productID := range arg.EligibleProductIDs {
+		res.Products = append(res.Products, &CouponProduct{CouponID: arg.ID, ProductID: productID})
+	}
+
+	return res
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"order-service/internal/domain/entity"
+
+	"github.com/uptrace/bun"
+)
+
+type CouponUsage struct {
+	bun.BaseModel `bun:"table:coupon_usages,alias:coupon_usage"`
+	Base
+	CouponID uint32 `bun:"coupon_id,notnull"`
+	UserID   uint32 `bun:"user_id,notnull"`
+	OrderID  uint32 `bun:"order_id,notnull"`
+}
+
+func (m *CouponUsage) ToDomain() *entity.CouponUsage {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.CouponUsage{
+		Base: entity.Base{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			DeletedAt: m.DeletedAt,
+		},
+		CouponID: m.CouponID,
+		UserID:   m.UserID,
+		OrderID:  m.OrderID,
+	}
+}
+
+func AsCouponUsage(arg *entity.CouponUsage) *CouponUsage {
+	if arg == nil {
+		return nil
+	}
+
+	return &CouponUsage{
+		Base: Base{
+			ID:        arg.ID,
+			CreatedAt: arg.CreatedAt,
+			UpdatedAt: arg.UpdatedAt,
+			DeletedAt: arg.DeletedAt,
+		},
+		CouponID: arg.CouponID,
+		UserID:   arg.UserID,
+		OrderID:  arg.OrderID,
+	}
+}
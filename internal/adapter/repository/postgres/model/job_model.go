@@ -0,0 +1,91 @@
+package model
+
+import (
+	"encoding/json"
+	"order-service/internal/domain/entity"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Job has no deleted_at column: purged jobs are removed for good.
+type Job struct {
+	bun.BaseModel `bun:"table:jobs,alias:job"`
+	ID            uint32          `bun:"id,pk,autoincrement"`
+	Type          string          `bun:"type,notnull"`
+	Payload       json.RawMessage `bun:"payload,type:jsonb,notnull"`
+	Status        string          `bun:"status,notnull"`
+	Attempts      int             `bun:"attempts,notnull"`
+	MaxAttempts   int             `bun:"max_attempts,notnull"`
+	RunAt         time.Time       `bun:"run_at,notnull"`
+	LockedAt      *time.Time      `bun:"locked_at"`
+	LastError     string          `bun:"last_error,notnull"`
+	CompletedAt   *time.Time      `bun:"completed_at"`
+	FailedAt      *time.Time      `bun:"failed_at"`
+	CreatedAt     time.Time       `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt     time.Time       `bun:"updated_at,notnull,default:current_timestamp"`
+}
+
+func (m *Job) ToDomain() *entity.Job {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.Job{
+		Base: entity.Base{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+		Type:        m.Type,
+		Payload:     m.Payload,
+		Status:      m.Status,
+		Attempts:    m.Attempts,
+		MaxAttempts: m.MaxAttempts,
+		RunAt:       m.RunAt,
+		LockedAt:    m.LockedAt,
+		LastError:   m.LastError,
+		CompletedAt: m.CompletedAt,
+		FailedAt:    m.FailedAt,
+	}
+}
+
+func ToJobsDomain(arg []*Job) []*entity.Job {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*entity.Job, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, arg[i].ToDomain())
+	}
+
+	return res
+}
+
+func AsJob(arg *entity.Job) *Job {
+	if arg == nil {
+		return nil
+	}
+
+	return &Job{
+		ID:          arg.ID,
+		Type:        arg.Type,
+		Payload:     arg.Payload,
+		Status:      arg.Status,
+		Attempts:    arg.Attempts,
+		MaxAttempts: arg.MaxAttempts,
+		RunAt:       arg.RunAt,
+		LockedAt:    arg.LockedAt,
+		LastError:   arg.LastError,
+		CompletedAt: arg.CompletedAt,
+		FailedAt:    arg.FailedAt,
+		CreatedAt:   arg.CreatedAt,
+		UpdatedAt:   arg.UpdatedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"order-service/internal/domain/entity"
+
+	"github.com/uptrace/bun"
+)
+
+type Province struct {
+	bun.BaseModel `bun:"table:provinces,alias:province"`
+	ID            uint32 `bun:"id,pk,autoincrement"`
+	Name          string `bun:"name,notnull"`
+}
+
+type City struct {
+	bun.BaseModel `bun:"table:cities,alias:city"`
+	ID            uint32 `bun:"id,pk,autoincrement"`
+	ProvinceID    uint32 `bun:"province_id,notnull"`
+	Name          string `bun:"name,notnull"`
+
+	Province *Province `bun:"rel:belongs-to,join:province_id=id"`
+}
+
+type District struct {
+	bun.BaseModel `bun:"table:districts,alias:district"`
+	ID            uint32 `bun:"id,pk,autoincrement"`
+	CityID        uint32 `bun:"city_id,notnull"`
+	Name          string `bun:"name,notnull"`
+
+	City *City `bun:"rel:belongs-to,join:city_id=id"`
+}
+
+func (m *Province) ToDomain() *entity.Province {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.Province{
+		ID:   m.ID,
+		Name: m.Name,
+	}
+}
+
+func (m *City) ToDomain() *entity.City {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.City{
+		ID:         m.ID,
+		ProvinceID: m.ProvinceID,
+		Name:       m.Name,
+		Province:   m.Province.ToDomain(),
+	}
+}
+
+func (m *District) ToDomain() *entity.District {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.District{
+		ID:     m.ID,
+		CityID: m.CityID,
+		Name:   m.Name,
+		City:   m.City.ToDomain(),
+	}
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
+
+	"github.com/uptrace/bun"
+)
+
+type OrderAdjustment struct {
+	bun.BaseModel `bun:"table:order_adjustments,alias:order_adjustment"`
+	Base
+	OrderID     uint32      `bun:"order_id,notnull"`
+	OrderItemID *uint32     `bun:"order_item_id"`
+	CouponID    *uint32     `bun:"coupon_id"`
+	Type        string      `bun:"type,notnull"`
+	Code        string      `bun:"code,notnull"`
+	Description string      `bun:"description,notnull"`
+	Amount      money.Money `bun:"amount,type:numeric(19,4),notnull"`
+}
+
+func (m *OrderAdjustment) ToDomain() *entity.OrderAdjustment {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.OrderAdjustment{
+		Base: entity.Base{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			DeletedAt: m.DeletedAt,
+		},
+		OrderID:     m.OrderID,
+		OrderItemID: m.OrderItemID,
+		CouponID:    m.CouponID,
+		Type:        m.Type,
+		Code:        m.Code,
+		Description: m.Description,
+		Amount:      m.Amount,
+	}
+}
+
+func ToOrderAdjustmentsDomain(arg []*OrderAdjustment) []*entity.OrderAdjustment {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*entity.OrderAdjustment, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, arg[i].ToDomain())
+	}
+
+	return res
+}
+
+func AsOrderAdjustment(arg *entity.OrderAdjustment) *OrderAdjustment {
+	if arg == nil {
+		return nil
+	}
+
+	res := &OrderAdjustment{
+		Base: Base{
+			ID:        arg.ID,
+			CreatedAt: arg.CreatedAt,
+			UpdatedAt: arg.UpdatedAt,
+			DeletedAt: arg.DeletedAt,
+		},
+		OrderID:     arg.OrderID,
+		OrderItemID: arg.OrderItemID,
+		CouponID:    arg.CouponID,
+		Type:        arg.Type,
+		Code:        arg.Code,
+		Description: arg.Description,
+		Amount:      arg.Amount,
+	}
+
+	if res.OrderItemID == nil && arg.OrderItem != nil && arg.OrderItem.ID != 0 {
+		itemID := arg.OrderItem.ID
+		res.OrderItemID = &itemID
+	}
+
+	return res
+}
+
+func AsOrderAdjustments(arg []*entity.OrderAdjustment) []*OrderAdjustment {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*OrderAdjustment, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, AsOrderAdjustment(arg[i]))
+	}
+
+	return res
+}
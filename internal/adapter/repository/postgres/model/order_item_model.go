@@ -2,6 +2,8 @@ package model
 
 import (
 	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
+	"time"
 
 	"github.com/uptrace/bun"
 )
@@ -9,11 +11,24 @@ import (
 type OrderItem struct {
 	bun.BaseModel `bun:"table:order_items"`
 	Base
-	OrderID   uint32  `bun:"order_id,notnull"`
-	ProductID string  `bun:"product_id,notnull"`
-	Quantity  int     `bun:"quantity,notnull"`
-	Price     float64 `bun:"price,notnull"`
-	Subtotal  float64 `bun:"subtotal,notnull"`
+	OrderID   uint32      `bun:"order_id,notnull"`
+	ProductID string      `bun:"product_id,notnull"`
+	Quantity  int         `bun:"quantity,notnull"`
+	Price     money.Money `bun:"price,type:numeric(19,4),notnull"`
+	BasePrice money.Money `bun:"base_price,type:numeric(19,4),notnull"`
+	Subtotal  money.Money `bun:"subtotal,type:numeric(19,4),notnull"`
+
+	CancelledQuantity int     `bun:"cancelled_quantity,notnull"`
+	ReservationID     *uint32 `bun:"reservation_id"`
+
+	// OrderCreatedAt is the created_at of the order. order_items are
+	// partitioned by it, so items share their order's partition.
+	OrderCreatedAt time.Time `bun:"order_created_at,notnull"`
+
+	TaxClass      string        `bun:"tax_class,notnull"`
+	TaxRate       money.Percent `bun:"tax_rate,type:numeric(9,4),notnull"`
+	TaxableAmount money.Money   `bun:"taxable_amount,type:numeric(19,4),notnull"`
+	TaxAmount     money.Money   `bun:"tax_amount,type:numeric(19,4),notnull"`
 
 	Order *Order `bun:"rel:belongs-to,join:order_id=id"`
 }
@@ -34,7 +49,16 @@ func (m *OrderItem) ToDomain() *entity.OrderItem {
 		OrderID:   m.OrderID,
 		Quantity:  m.Quantity,
 		Price:     m.Price,
+		BasePrice: m.BasePrice,
 		Subtotal:  m.Subtotal,
+
+		CancelledQuantity: m.CancelledQuantity,
+		ReservationID:     m.ReservationID,
+
+		TaxClass:      m.TaxClass,
+		TaxRate:       m.TaxRate,
+		TaxableAmount: m.TaxableAmount,
+		TaxAmount:     m.TaxAmount,
 	}
 
 	if m.Order != nil {
@@ -78,8 +102,17 @@ func AsOrderItem(arg *entity.OrderItem) *OrderItem {
 		OrderID:   arg.OrderID,
 		Quantity:  arg.Quantity,
 		Price:     arg.Price,
+		BasePrice: arg.BasePrice,
 		Subtotal:  arg.Subtotal,
 		Order:     AsOrder(arg.Order),
+
+		CancelledQuantity: arg.CancelledQuantity,
+		ReservationID:     arg.ReservationID,
+
+		TaxClass:      arg.TaxClass,
+		TaxRate:       arg.TaxRate,
+		TaxableAmount: arg.TaxableAmount,
+		TaxAmount:     arg.TaxAmount,
 	}
 }
 
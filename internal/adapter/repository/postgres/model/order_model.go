@@ -2,18 +2,41 @@ package model
 
 import (
 	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
+	"time"
 
 	"github.com/uptrace/bun"
 )
 
 type Order struct {
-    bun.BaseModel `bun:"table:orders,alias:order"`
+	bun.BaseModel `bun:"table:orders,alias:order"`
 	Base
-    UserID     uint32      `bun:"user_id,notnull"`
-    Status     string      `bun:"status,notnull"`
-    TotalPrice float64     `bun:"total_price,notnull"`
-
-    Items      []*OrderItem `bun:"rel:has-many,join:id=order_id"`
+	UserID         uint32      `bun:"user_id,notnull"`
+	Status         string      `bun:"status,notnull"`
+	Currency       string      `bun:"currency,notnull"`
+	Subtotal       money.Money `bun:"subtotal,type:numeric(19,4),notnull"`
+	DiscountTotal  money.Money `bun:"discount_total,type:numeric(19,4),notnull"`
+	ShippingTotal  money.Money `bun:"shipping_total,type:numeric(19,4),notnull"`
+	TaxTotal       money.Money `bun:"tax_total,type:numeric(19,4),notnull"`
+	TaxRegion      string      `bun:"tax_region,notnull"`
+	TaxInclusive   bool        `bun:"tax_inclusive,notnull"`
+	TotalPrice     money.Money `bun:"total_price,type:numeric(19,4),notnull"`
+	BaseCurrency   string      `bun:"base_currency,notnull"`
+	BaseTotalPrice money.Money `bun:"base_total_price,type:numeric(19,4),notnull"`
+	FXRate         money.Rate  `bun:"fx_rate,type:numeric(24,12),notnull"`
+	FXRateSource   string      `bun:"fx_rate_source,notnull"`
+	FXRateAt       time.Time   `bun:"fx_rate_at,notnull"`
+	DeliveredAt    *time.Time  `bun:"delivered_at"`
+
+	CancellationReason string     `bun:"cancellation_reason,notnull"`
+	CancelledAt        *time.Time `bun:"cancelled_at"`
+
+	ShippingAddress *ShippingAddress   `bun:"rel:has-one,join:id=order_id"`
+	Items           []*OrderItem       `bun:"rel:has-many,join:id=order_id"`
+	Adjustments     []*OrderAdjustment `bun:"rel:has-many,join:id=order_id"`
+	Payments        []*Payment         `bun:"rel:has-many,join:id=order_id"`
+	Refunds         []*Refund          `bun:"rel:has-many,join:id=order_id"`
+	Returns         []*OrderReturn     `bun:"rel:has-many,join:id=order_id"`
 }
 
 func (m *Order) ToDomain() *entity.Order {
@@ -28,10 +51,31 @@ func (m *Order) ToDomain() *entity.Order {
 			UpdatedAt: m.UpdatedAt,
 			DeletedAt: m.DeletedAt,
 		},
-		UserID:     m.UserID,
-		Status:     m.Status,
-		TotalPrice: m.TotalPrice,
-		Items:      ToOrderItemsDomain(m.Items),
+		UserID:          m.UserID,
+		Status:          m.Status,
+		Currency:        m.Currency,
+		Subtotal:        m.Subtotal,
+		DiscountTotal:   m.DiscountTotal,
+		ShippingTotal:   m.ShippingTotal,
+		TaxTotal:        m.TaxTotal,
+		TaxRegion:       m.TaxRegion,
+		TaxInclusive:    m.TaxInclusive,
+		TotalPrice:      m.TotalPrice,
+		BaseCurrency:    m.BaseCurrency,
+		BaseTotalPrice:  m.BaseTotalPrice,
+		FXRate:          m.FXRate,
+		FXRateSource:    m.FXRateSource,
+		FXRateAt:        m.FXRateAt,
+		DeliveredAt:     m.DeliveredAt,
+		ShippingAddress: m.ShippingAddress.ToDomain(),
+		Items:           ToOrderItemsDomain(m.Items),
+		Adjustments:     ToOrderAdjustmentsDomain(m.Adjustments),
+		Payments:        ToPaymentsDomain(m.Payments),
+		Refunds:         ToRefundsDomain(m.Refunds),
+		Returns:         ToOrderReturnsDomain(m.Returns),
+
+		CancellationReason: m.CancellationReason,
+		CancelledAt:        m.CancelledAt,
 	}
 }
 
@@ -65,10 +109,28 @@ func AsOrder(arg *entity.Order) *Order {
 			UpdatedAt: arg.UpdatedAt,
 			DeletedAt: arg.DeletedAt,
 		},
-		UserID:     arg.UserID,
-		Status:     arg.Status,
-		TotalPrice: arg.TotalPrice,
-		Items:      AsOrderItems(arg.Items),
+		UserID:          arg.UserID,
+		Status:          arg.Status,
+		Currency:        arg.Currency,
+		Subtotal:        arg.Subtotal,
+		DiscountTotal:   arg.DiscountTotal,
+		ShippingTotal:   arg.ShippingTotal,
+		TaxTotal:        arg.TaxTotal,
+		TaxRegion:       arg.TaxRegion,
+		TaxInclusive:    arg.TaxInclusive,
+		TotalPrice:      arg.TotalPrice,
+		BaseCurrency:    arg.BaseCurrency,
+		BaseTotalPrice:  arg.BaseTotalPrice,
+		FXRate:          arg.FXRate,
+		FXRateSource:    arg.FXRateSource,
+		FXRateAt:        arg.FXRateAt,
+		DeliveredAt:     arg.DeliveredAt,
+		ShippingAddress: AsShippingAddress(arg.ShippingAddress),
+		Items:           AsOrderItems(arg.Items),
+		Adjustments:     AsOrderAdjustments(arg.Adjustments),
+
+		CancellationReason: arg.CancellationReason,
+		CancelledAt:        arg.CancelledAt,
 	}
 }
 
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type OrderReturn struct {
+	bun.BaseModel `bun:"table:order_returns,alias:order_return"`
+	Base
+	OrderID         uint32      `bun:"order_id,notnull"`
+	OrderItemID     uint32      `bun:"order_item_id,notnull"`
+	Quantity        int         `bun:"quantity,notnull"`
+	ReasonCode      string      `bun:"reason_code,notnull"`
+	Note            string      `bun:"note,notnull"`
+	Status          string      `bun:"status,notnull"`
+	RejectionReason string      `bun:"rejection_reason,notnull"`
+	RefundID        *uint32     `bun:"refund_id"`
+	RefundAmount    money.Money `bun:"refund_amount,type:numeric(19,4),notnull"`
+	ApprovedAt      *time.Time  `bun:"approved_at"`
+	RejectedAt      *time.Time  `bun:"rejected_at"`
+	ReceivedAt      *time.Time  `bun:"received_at"`
+	RestockedAt     *time.Time  `bun:"restocked_at"`
+}
+
+func (m *OrderReturn) ToDomain() *entity.OrderReturn {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.OrderReturn{
+		Base: entity.Base{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			DeletedAt: m.DeletedAt,
+		},
+		OrderID:         m.OrderID,
+		OrderItemID:     m.OrderItemID,
+		Quantity:        m.Quantity,
+		ReasonCode:      m.ReasonCode,
+		Note:            m.Note,
+		Status:          m.Status,
+		RejectionReason: m.RejectionReason,
+		RefundID:        m.RefundID,
+		RefundAmount:    m.RefundAmount,
+		ApprovedAt:      m.ApprovedAt,
+		RejectedAt:      m.RejectedAt,
+		ReceivedAt:      m.ReceivedAt,
+		RestockedAt:     m.RestockedAt,
+	}
+}
+
+func ToOrderReturnsDomain(arg []*OrderReturn) []*entity.OrderReturn {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*entity.OrderReturn, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, arg[i].ToDomain())
+	}
+
+	return res
+}
+
+func AsOrderReturn(arg *entity.OrderReturn) *OrderReturn {
+	if arg == nil {
+		return nil
+	}
+
+	return &OrderReturn{
+		Base: Base{
+			ID:        arg.ID,
+			CreatedAt: arg.CreatedAt,
+			UpdatedAt: arg.UpdatedAt,
+			DeletedAt: arg.DeletedAt,
+		},
+		OrderID:         arg.OrderID,
+		OrderItemID:     arg.OrderItemID,
+		Quantity:        arg.Quantity,
+		ReasonCode:      arg.ReasonCode,
+		Note:            arg.Note,
+		Status:          arg.Status,
+		RejectionReason: arg.RejectionReason,
+		RefundID:        arg.RefundID,
+		RefundAmount:    arg.RefundAmount,
+		ApprovedAt:      arg.ApprovedAt,
+		RejectedAt:      arg.RejectedAt,
+		ReceivedAt:      arg.ReceivedAt,
+		RestockedAt:     arg.RestockedAt,
+	}
+}
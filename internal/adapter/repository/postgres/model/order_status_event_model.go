@@ -0,0 +1,51 @@
+package model
+
+import (
+	"order-service/internal/domain/entity"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// OrderStatusEvent rows are written by a trigger on orders, never by the
+// service itself.
+type OrderStatusEvent struct {
+	bun.BaseModel `bun:"table:order_status_events,alias:ose"`
+	ID            uint64    `bun:"id,pk,autoincrement"`
+	OrderID       uint32    `bun:"order_id,notnull"`
+	UserID        uint32    `bun:"user_id,notnull"`
+	Status        string    `bun:"status,notnull"`
+	CreatedAt     time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+func (m *OrderStatusEvent) ToDomain() *entity.OrderStatusEvent {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.OrderStatusEvent{
+		ID:        m.ID,
+		OrderID:   m.OrderID,
+		UserID:    m.UserID,
+		Status:    m.Status,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func ToOrderStatusEventsDomain(arg []*OrderStatusEvent) []*entity.OrderStatusEvent {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*entity.OrderStatusEvent, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, arg[i].ToDomain())
+	}
+
+	return res
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"encoding/json"
+	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Payment struct {
+	bun.BaseModel `bun:"table:payments,alias:payment"`
+	Base
+	OrderID           uint32      `bun:"order_id,notnull"`
+	Provider          string      `bun:"provider,notnull"`
+	Reference         string      `bun:"reference,notnull"`
+	ProviderReference string      `bun:"provider_reference,notnull"`
+	Status            string      `bun:"status,notnull"`
+	Amount            money.Money `bun:"amount,type:numeric(19,4),notnull"`
+	Currency          string      `bun:"currency,notnull"`
+	CheckoutURL       string      `bun:"checkout_url,notnull"`
+	FailureReason     string      `bun:"failure_reason,notnull"`
+	PaidAt            *time.Time  `bun:"paid_at"`
+	FailedAt          *time.Time  `bun:"failed_at"`
+}
+
+type PaymentEvent struct {
+	bun.BaseModel `bun:"table:payment_events,alias:payment_event"`
+	Base
+	Provider  string          `bun:"provider,notnull"`
+	EventID   string          `bun:"event_id,notnull"`
+	Type      string          `bun:"type,notnull"`
+	PaymentID *uint32         `bun:"payment_id"`
+	Payload   json.RawMessage `bun:"payload,type:jsonb,notnull"`
+}
+
+func (m *Payment) ToDomain() *entity.Payment {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.Payment{
+		Base: entity.Base{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			DeletedAt: m.DeletedAt,
+		},
+		OrderID:           m.OrderID,
+		Provider:          m.Provider,
+		Reference:         m.Reference,
+		ProviderReference: m.ProviderReference,
+		Status:            m.Status,
+		Amount:            m.Amount,
+		Currency:          m.Currency,
+		CheckoutURL:       m.CheckoutURL,
+		FailureReason:     m.FailureReason,
+		PaidAt:            m.PaidAt,
+		FailedAt:          m.FailedAt,
+	}
+}
+
+func ToPaymentsDomain(arg []*Payment) []*entity.Payment {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*entity.Payment, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, arg[i].ToDomain())
+	}
+
+	return res
+}
+
+func AsPayment(arg *entity.Payment) *Payment {
+	if arg == nil {
+		return nil
+	}
+
+	return &Payment{
+		Base: Base{
+			ID:        arg.ID,
+			CreatedAt: arg.CreatedAt,
+			UpdatedAt: arg.UpdatedAt,
+			DeletedAt: arg.DeletedAt,
+		},
+		OrderID:           arg.OrderID,
+		Provider:          arg.Provider,
+		Reference:         arg.Reference,
+		ProviderReference: arg.ProviderReference,
+		Status:            arg.Status,
+		Amount:            arg.Amount,
+		Currency:          arg.Currency,
+		CheckoutURL:       arg.CheckoutURL,
+		FailureReason:     arg.FailureReason,
+		PaidAt:            arg.PaidAt,
+		FailedAt:          arg.FailedAt,
+	}
+}
+
+func AsPaymentEvent(arg *entity.PaymentEvent) *PaymentEvent {
+	if arg == nil {
+		return nil
+	}
+
+	return &PaymentEvent{
+		Base: Base{
+			ID:        arg.ID,
+			CreatedAt: arg.CreatedAt,
+			UpdatedAt: arg.UpdatedAt,
+			DeletedAt: arg.DeletedAt,
+		},
+		Provider:  arg.Provider,
+		EventID:   arg.EventID,
+		Type:      arg.Type,
+		PaymentID: arg.PaymentID,
+		Payload:   arg.Payload,
+	}
+}
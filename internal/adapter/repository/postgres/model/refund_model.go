@@ -0,0 +1,185 @@
+package model
+
+import (
+	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Refund struct {
+	bun.BaseModel `bun:"table:refunds,alias:refund"`
+	Base
+	OrderID           uint32      `bun:"order_id,notnull"`
+	PaymentID         uint32      `bun:"payment_id,notnull"`
+	Reference         string      `bun:"reference,notnull"`
+	ProviderReference string      `bun:"provider_reference,notnull"`
+	Status            string      `bun:"status,notnull"`
+	Amount            money.Money `bun:"amount,type:numeric(19,4),notnull"`
+	Currency          string      `bun:"currency,notnull"`
+	Reason            string      `bun:"reason,notnull"`
+	Attempts          int         `bun:"attempts,notnull"`
+	LastError         string      `bun:"last_error,notnull"`
+	RefundedAt        *time.Time  `bun:"refunded_at"`
+	FailedAt          *time.Time  `bun:"failed_at"`
+
+	Items []*RefundItem `bun:"rel:has-many,join:id=refund_id"`
+}
+
+type RefundItem struct {
+	bun.BaseModel `bun:"table:refund_items,alias:refund_item"`
+	Base
+	RefundID    uint32      `bun:"refund_id,notnull"`
+	OrderItemID uint32      `bun:"order_item_id,notnull"`
+	Quantity    int         `bun:"quantity,notnull"`
+	Amount      money.Money `bun:"amount,type:numeric(19,4),notnull"`
+}
+
+func (m *Refund) ToDomain() *entity.Refund {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.Refund{
+		Base: entity.Base{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			DeletedAt: m.DeletedAt,
+		},
+		OrderID:           m.OrderID,
+		PaymentID:         m.PaymentID,
+		Reference:         m.Reference,
+		ProviderReference: m.ProviderReference,
+		Status:            m.Status,
+		Amount:            m.Amount,
+		Currency:          m.Currency,
+		Reason:            m.Reason,
+		Attempts:          m.Attempts,
+		LastError:         m.LastError,
+		RefundedAt:        m.RefundedAt,
+		FailedAt:          m.FailedAt,
+		Items:             ToRefundItemsDomain(m.Items),
+	}
+}
+
+func ToRefundsDomain(arg []*Refund) []*entity.Refund {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*entity.Refund, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, arg[i].ToDomain())
+	}
+
+	return res
+}
+
+func AsRefund(arg *entity.Refund) *Refund {
+	if arg == nil {
+		return nil
+	}
+
+	return &Refund{
+		Base: Base{
+			ID:        arg.ID,
+			CreatedAt: arg.CreatedAt,
+			UpdatedAt: arg.UpdatedAt,
+			DeletedAt: arg.DeletedAt,
+		},
+		OrderID:           arg.OrderID,
+		PaymentID:         arg.PaymentID,
+		Reference:         arg.Reference,
+		ProviderReference: arg.ProviderReference,
+		Status:            arg.Status,
+		Amount:            arg.Amount,
+		Currency:          arg.Currency,
+		Reason:            arg.Reason,
+		Attempts:          arg.Attempts,
+		LastError:         arg.LastError,
+		RefundedAt:        arg.RefundedAt,
+		FailedAt:          arg.FailedAt,
+		Items:             AsRefundItems(arg.Items),
+	}
+}
+
+func (m *RefundItem) ToDomain() *entity.RefundItem {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.RefundItem{
+		Base: entity.Base{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			DeletedAt: m.DeletedAt,
+		},
+		RefundID:    m.RefundID,
+		OrderItemID: m.OrderItemID,
+		Quantity:    m.Quantity,
+		Amount:      m.Amount,
+	}
+}
+
+func ToRefundItemsDomain(arg []*RefundItem) []*entity.RefundItem {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*entity.RefundItem, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, arg[i].ToDomain())
+	}
+
+	return res
+}
+
+func AsRefundItem(arg *entity.RefundItem) *RefundItem {
+	if arg == nil {
+		return nil
+	}
+
+	return &RefundItem{
+		Base: Base{
+			ID:        arg.ID,
+			CreatedAt: arg.CreatedAt,
+			UpdatedAt: arg.UpdatedAt,
+			DeletedAt: arg.DeletedAt,
+		},
+		RefundID:    arg.RefundID,
+		OrderItemID: arg.OrderItemID,
+		Quantity:    arg.Quantity,
+		Amount:      arg.Amount,
+	}
+}
+
+func AsRefundItems(arg []*entity.RefundItem) []*RefundItem {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*RefundItem, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, AsRefundItem(arg[i]))
+	}
+
+	return res
+}
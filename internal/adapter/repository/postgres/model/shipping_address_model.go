@@ -0,0 +1,63 @@
+package model
+
+import (
+	"order-service/internal/domain/entity"
+
+	"github.com/uptrace/bun"
+)
+
+type ShippingAddress struct {
+	bun.BaseModel `bun:"table:order_shipping_addresses,alias:shipping_address"`
+	Base
+	OrderID       uint32 `bun:"order_id,notnull"`
+	RecipientName string `bun:"recipient_name,notnull"`
+	Phone         string `bun:"phone,notnull"`
+	Street        string `bun:"street,notnull"`
+	PostalCode    string `bun:"postal_code,notnull"`
+	DistrictID    uint32 `bun:"district_id,notnull"`
+
+	District *District `bun:"rel:belongs-to,join:district_id=id"`
+}
+
+func (m *ShippingAddress) ToDomain() *entity.ShippingAddress {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.ShippingAddress{
+		Base: entity.Base{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			DeletedAt: m.DeletedAt,
+		},
+		OrderID:       m.OrderID,
+		RecipientName: m.RecipientName,
+		Phone:         m.Phone,
+		Street:        m.Street,
+		PostalCode:    m.PostalCode,
+		DistrictID:    m.DistrictID,
+		District:      m.District.ToDomain(),
+	}
+}
+
+func AsShippingAddress(arg *entity.ShippingAddress) *ShippingAddress {
+	if arg == nil {
+		return nil
+	}
+
+	return &ShippingAddress{
+		Base: Base{
+			ID:        arg.ID,
+			CreatedAt: arg.CreatedAt,
+			UpdatedAt: arg.UpdatedAt,
+			DeletedAt: arg.DeletedAt,
+		},
+		OrderID:       arg.OrderID,
+		RecipientName: arg.RecipientName,
+		Phone:         arg.Phone,
+		Street:        arg.Street,
+		PostalCode:    arg.PostalCode,
+		DistrictID:    arg.DistrictID,
+	}
+}
@@ -0,0 +1,169 @@
+package model
+
+import (
+	"encoding/json"
+	"order-service/internal/domain/entity"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type WebhookSubscription struct {
+	bun.BaseModel `bun:"table:webhook_subscriptions,alias:webhook_subscription"`
+	Base
+	URL                 string     `bun:"url,notnull"`
+	Secret              string     `bun:"secret,notnull"`
+	EventTypes          []string   `bun:"event_types,array,notnull"`
+	IsActive            bool       `bun:"is_active,notnull"`
+	ConsecutiveFailures int        `bun:"consecutive_failures,notnull"`
+	DisabledAt          *time.Time `bun:"disabled_at"`
+	DisabledReason      string     `bun:"disabled_reason,notnull"`
+}
+
+type WebhookDelivery struct {
+	bun.BaseModel `bun:"table:webhook_deliveries,alias:webhook_delivery"`
+	Base
+	SubscriptionID uint32          `bun:"subscription_id,notnull"`
+	EventID        string          `bun:"event_id,notnull"`
+	EventType      string          `bun:"event_type,notnull"`
+	Payload        json.RawMessage `bun:"payload,type:jsonb,notnull"`
+	Status         string          `bun:"status,notnull"`
+	Attempts       int             `bun:"attempts,notnull"`
+	ResponseStatus int             `bun:"response_status,notnull"`
+	ResponseBody   string          `bun:"response_body,notnull"`
+	LastError      string          `bun:"last_error,notnull"`
+	DeliveredAt    *time.Time      `bun:"delivered_at"`
+	FailedAt       *time.Time      `bun:"failed_at"`
+}
+
+func (m *WebhookSubscription) ToDomain() *entity.WebhookSubscription {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.WebhookSubscription{
+		Base: entity.Base{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			DeletedAt: m.DeletedAt,
+		},
+		URL:                 m.URL,
+		Secret:              m.Secret,
+		EventTypes:          m.EventTypes,
+		IsActive:            m.IsActive,
+		ConsecutiveFailures: m.ConsecutiveFailures,
+		DisabledAt:          m.DisabledAt,
+		DisabledReason:      m.DisabledReason,
+	}
+}
+
+func ToWebhookSubscriptionsDomain(arg []*WebhookSubscription) []*entity.WebhookSubscription {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*entity.WebhookSubscription, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, arg[i].ToDomain())
+	}
+
+	return res
+}
+
+func AsWebhookSubscription(arg *entity.WebhookSubscription) *WebhookSubscription {
+	if arg == nil {
+		return nil
+	}
+
+	return &WebhookSubscription{
+		Base: Base{
+			ID:        arg.ID,
+			CreatedAt: arg.CreatedAt,
+			UpdatedAt: arg.UpdatedAt,
+			DeletedAt: arg.DeletedAt,
+		},
+		URL:                 arg.URL,
+		Secret:              arg.Secret,
+		EventTypes:          arg.EventTypes,
+		IsActive:            arg.IsActive,
+		ConsecutiveFailures: arg.ConsecutiveFailures,
+		DisabledAt:          arg.DisabledAt,
+		DisabledReason:      arg.DisabledReason,
+	}
+}
+
+func (m *WebhookDelivery) ToDomain() *entity.WebhookDelivery {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.WebhookDelivery{
+		Base: entity.Base{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			DeletedAt: m.DeletedAt,
+		},
+		SubscriptionID: m.SubscriptionID,
+		EventID:        m.EventID,
+		EventType:      m.EventType,
+		Payload:        m.Payload,
+		Status:         m.Status,
+		Attempts:       m.Attempts,
+		ResponseStatus: m.ResponseStatus,
+		ResponseBody:   m.ResponseBody,
+		LastError:      m.LastError,
+		DeliveredAt:    m.DeliveredAt,
+		FailedAt:       m.FailedAt,
+	}
+}
+
+func ToWebhookDeliveriesDomain(arg []*WebhookDelivery) []*entity.WebhookDelivery {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*entity.WebhookDelivery, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, arg[i].ToDomain())
+	}
+
+	return res
+}
+
+func AsWebhookDelivery(arg *entity.WebhookDelivery) *WebhookDelivery {
+	if arg == nil {
+		return nil
+	}
+
+	return &WebhookDelivery{
+		Base: Base{
+			ID:        arg.ID,
+			CreatedAt: arg.CreatedAt,
+			UpdatedAt: arg.UpdatedAt,
+			DeletedAt: arg.DeletedAt,
+		},
+		SubscriptionID: arg.SubscriptionID,
+		EventID:        arg.EventID,
+		EventType:      arg.EventType,
+		Payload:        arg.Payload,
+		Status:         arg.Status,
+		Attempts:       arg.Attempts,
+		ResponseStatus: arg.ResponseStatus,
+		ResponseBody:   arg.ResponseBody,
+		LastError:      arg.LastError,
+		DeliveredAt:    arg.DeliveredAt,
+		FailedAt:       arg.FailedAt,
+	}
+}
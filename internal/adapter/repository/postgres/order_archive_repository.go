@@ -0,0 +1,166 @@
+package postgresrepository
+
+import (
+	"context"
+	"fmt"
+	"order-service/constant"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/logger"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+var _ OrderArchiveRepository = (*orderArchiveRepository)(nil)
+
+type OrderArchiveRepository interface {
+	Archive(ctx context.Context, closedBefore time.Time, limit int) ([]uint32, error)
+	Restore(ctx context.Context, ids []uint32) ([]uint32, error)
+}
+
+type orderArchiveRepository struct {
+	db     bun.IDB
+	logger logger.Logger
+}
+
+func NewOrderArchiveRepository(db bun.IDB, logger logger.Logger) *orderArchiveRepository {
+	return &orderArchiveRepository{db: db, logger: logger}
+}
+
+func (r *orderArchiveRepository) GetTableName() string {
+	return "orders_archive"
+}
+
+// archivedOrderTable is a table whose rows belong to an order and move with
+// it. where selects the rows of the orders in ?, by way of the live tables
+// only, so it holds on both the way in and the way out.
+type archivedOrderTable struct {
+	name  string
+	where string
+}
+
+// archivedOrderTables lists the tables of an order with every table before
+// the ones it references, the order rows are archived in. They are restored
+// in reverse.
+var archivedOrderTables = []archivedOrderTable{
+	{name: "refund_items", where: "refund_id IN (SELECT id FROM refunds WHERE order_id IN (?))"},
+	{name: "order_returns", where: "order_id IN (?)"},
+	{name: "refunds", where: "order_id IN (?)"},
+	{name: "payment_events", where: "payment_id IN (SELECT id FROM payments WHERE order_id IN (?))"},
+	{name: "payments", where: "order_id IN (?)"},
+	{name: "order_adjustments", where: "order_id IN (?)"},
+	{name: "order_shipping_addresses", where: "order_id IN (?)"},
+	{name: "order_items", where: "order_id IN (?)"},
+	{name: "orders", where: "id IN (?)"},
+}
+
+// Archive moves up to limit closed orders last updated before closedBefore,
+// with every row that belongs to them, to the archive tables. Orders with a
+// refund or return still in progress are left alone. It returns the IDs of
+// the orders archived.
+func (r *orderArchiveRepository) Archive(ctx context.Context, closedBefore time.Time, limit int) ([]uint32, error) {
+	var ids []uint32
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().
+			Table("orders").
+			Column("id").
+			Where("status IN (?)", bun.In(constant.ClosedOrderStatuses)).
+			Where("updated_at < ?", closedBefore).
+			Where("NOT EXISTS (SELECT 1 FROM refunds WHERE refunds.order_id = orders.id AND refunds.status <> ?)", constant.RefundStatusSucceeded).
+			Where("NOT EXISTS (SELECT 1 FROM order_returns WHERE order_returns.order_id = orders.id AND order_returns.status IN (?))",
+				bun.In([]constant.ReturnStatus{constant.ReturnStatusRequested, constant.ReturnStatusApproved})).
+			OrderExpr("id").
+			Limit(limit).
+			For("UPDATE SKIP LOCKED").
+			Scan(ctx, &ids)
+		if err != nil {
+			return exception.NewDBError(err, "orders", "find archivable orders")
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		for _, table := range archivedOrderTables {
+			if err := moveOrderRows(ctx, tx, table.name, table.name+"_archive", table.where, bun.In(ids)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Restore moves the given archived orders, with every row that belongs to
+// them, back to the live tables. It returns the IDs of the orders restored;
+// IDs that are not archived are skipped. Restored orders are announced on
+// the order status stream as if they were created.
+func (r *orderArchiveRepository) Restore(ctx context.Context, ids []uint32) ([]uint32, error) {
+	var restored []uint32
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().
+			Table(r.GetTableName()).
+			Column("id").
+			Where("id IN (?)", bun.In(ids)).
+			OrderExpr("id").
+			For("UPDATE").
+			Scan(ctx, &restored)
+		if err != nil {
+			return exception.NewDBError(err, r.GetTableName(), "find archived orders")
+		}
+
+		if len(restored) == 0 {
+			return nil
+		}
+
+		for i := len(archivedOrderTables) - 1; i >= 0; i-- {
+			table := archivedOrderTables[i]
+
+			// The rows are selected from the archive, but the subqueries of
+			// where name the live tables their parents were restored to.
+			if err := moveOrderRows(ctx, tx, table.name+"_archive", table.name, table.where, bun.In(restored)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
+
+// moveOrderRows moves the rows of from that belong to orders to to, which
+// has the same columns. orders is the list of order IDs, as bun.In, or a
+// query selecting them.
+func moveOrderRows(ctx context.Context, tx bun.Tx, from, to, where string, orders any) error {
+	_, err := tx.NewRaw(
+		fmt.Sprintf("WITH moved AS (DELETE FROM ? WHERE %s RETURNING *) INSERT INTO ? SELECT * FROM moved", where),
+		bun.Ident(from), orders, bun.Ident(to),
+	).Exec(ctx)
+	if err != nil {
+		return exception.NewDBError(err, to, "move order rows from "+from)
+	}
+
+	return nil
+}
+
+// deleteOrderRows deletes the rows of table that belong to orders, given
+// like for moveOrderRows.
+func deleteOrderRows(ctx context.Context, tx bun.Tx, table, where string, orders any) error {
+	_, err := tx.NewRaw(fmt.Sprintf("DELETE FROM ? WHERE %s", where), bun.Ident(table), orders).Exec(ctx)
+	if err != nil {
+		return exception.NewDBError(err, table, "delete order rows")
+	}
+
+	return nil
+}
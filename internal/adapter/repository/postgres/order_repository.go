@@ -8,6 +8,7 @@ import (
 	"order-service/internal/domain/entity"
 	"order-service/internal/shared/exception"
 	"order-service/pkg/logger"
+	"time"
 
 	"github.com/uptrace/bun"
 )
@@ -16,44 +17,83 @@ var _ OrderRepository = (*orderRepository)(nil)
 
 type OrderRepository interface {
 	FindByID(ctx context.Context, id uint32) (*entity.Order, error)
+	FindByKey(ctx context.Context, id uint32, createdAt time.Time) (*entity.Order, error)
 	Find(ctx context.Context, filter *FilterOrderPayload) ([]*entity.Order, int, error)
 	Create(ctx context.Context, order *entity.Order) (*entity.Order, error)
 	Delete(ctx context.Context, id uint32) error
+	Restore(ctx context.Context, id uint32) (bool, error)
 	Update(ctx context.Context, order *entity.Order) (*entity.Order, error)
 	UpdateStatus(ctx context.Context, id uint32, status string) error
+	TransitionStatus(ctx context.Context, id uint32, createdAt time.Time, from, to string) (bool, error)
+	SetDeliveredAt(ctx context.Context, id uint32, at time.Time) error
+	SetCancelled(ctx context.Context, id uint32, reason string, at time.Time) error
+	LockByID(ctx context.Context, id uint32) (time.Time, error)
+	UpdateTotals(ctx context.Context, order *entity.Order) error
+	UpdateItem(ctx context.Context, item *entity.OrderItem) error
+	SwapItemReservation(ctx context.Context, id uint32, from, to *uint32) (bool, error)
+	CreateAdjustment(ctx context.Context, adjustment *entity.OrderAdjustment) (*entity.OrderAdjustment, error)
+	UpdateItems(ctx context.Context, order *entity.Order, removed []*entity.OrderItem) error
 }
 
+// orderRepository writes to db. Find and FindByID read from read, which
+// outside a transaction may be a replica.
 type orderRepository struct {
 	db     bun.IDB
+	read   Reader
 	logger logger.Logger
 }
 
-func NewOrderRepository(db bun.IDB, logger logger.Logger) *orderRepository {
-	return &orderRepository{db: db, logger: logger}
+func NewOrderRepository(db bun.IDB, read Reader, logger logger.Logger) *orderRepository {
+	return &orderRepository{db: db, read: read, logger: logger}
 }
 
 func (r *orderRepository) GetTableName() string {
 	return "orders"
 }
 
+// FilterOrderPayload selects orders. Deleted orders are left out unless
+// IncludeDeleted is set. CreatedFrom is inclusive and CreatedBefore
+// exclusive; as orders are partitioned by month of creation, bounding a
+// query by them spares scanning the other months.
 type FilterOrderPayload struct {
-	IDs     []uint32
-	UserID  uint32
-	Page    int
-	PerPage int
+	IDs            []uint32
+	UserID         uint32
+	Status         string
+	CreatedFrom    time.Time
+	CreatedBefore  time.Time
+	IncludeDeleted bool
+	Page           int
+	PerPage        int
 }
 
 func (r *orderRepository) Find(ctx context.Context, filter *FilterOrderPayload) ([]*entity.Order, int, error) {
 	var orders []*model.Order
 
-	query := r.db.NewSelect().Model(&orders).Relation("Items")
+	query := r.read(ctx).NewSelect().Model(&orders).Relation("Items").Relation("Adjustments").Relation("Payments", orderPaymentsByID)
+	query = withShippingAddress(withRefunds(query)).Relation("Returns", orderReturnsByID)
 
 	if len(filter.IDs) > 0 {
-		query = query.Where("id IN (?)", bun.In(filter.IDs))
+		query = query.Where("?TableAlias.id IN (?)", bun.In(filter.IDs))
 	}
 
 	if filter.UserID > 0 {
-		query = query.Where("user_id = ?", filter.UserID)
+		query = query.Where("?TableAlias.user_id = ?", filter.UserID)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("?TableAlias.status = ?", filter.Status)
+	}
+
+	if !filter.CreatedFrom.IsZero() {
+		query = query.Where("?TableAlias.created_at >= ?", filter.CreatedFrom)
+	}
+
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where("?TableAlias.created_at < ?", filter.CreatedBefore)
+	}
+
+	if filter.IncludeDeleted {
+		query = query.WhereAllWithDeleted()
 	}
 
 	totalCount, err := query.Clone().Count(ctx)
@@ -74,7 +114,7 @@ func (r *orderRepository) Find(ctx context.Context, filter *FilterOrderPayload)
 		query = query.Offset(offset)
 	}
 
-	query = query.Order("id DESC")
+	query = query.OrderExpr("?TableAlias.id DESC")
 	if err := query.Scan(ctx); err != nil {
 		return nil, 0, exception.NewDBError(err, r.GetTableName(), "find order")
 	}
@@ -83,8 +123,35 @@ func (r *orderRepository) Find(ctx context.Context, filter *FilterOrderPayload)
 }
 
 func (r *orderRepository) FindByID(ctx context.Context, id uint32) (*entity.Order, error) {
+	return r.findOne(ctx, id, time.Time{})
+}
+
+// FindByKey is FindByID for an order whose created_at, the key orders and
+// their items are partitioned by, is known, so only its partition is read.
+func (r *orderRepository) FindByKey(ctx context.Context, id uint32, createdAt time.Time) (*entity.Order, error) {
+	return r.findOne(ctx, id, createdAt)
+}
+
+func (r *orderRepository) findOne(ctx context.Context, id uint32, createdAt time.Time) (*entity.Order, error) {
 	var order model.Order
-	err := r.db.NewSelect().Model(&order).Where("id = ?", id).Relation("Items").Scan(ctx)
+	query := r.read(ctx).NewSelect().
+		Model(&order).
+		Where("?TableAlias.id = ?", id).
+		Relation("Items", func(q *bun.SelectQuery) *bun.SelectQuery {
+			if createdAt.IsZero() {
+				return q
+			}
+
+			return q.Where("?TableAlias.order_created_at = ?", createdAt)
+		}).
+		Relation("Adjustments").
+		Relation("Payments", orderPaymentsByID)
+
+	if !createdAt.IsZero() {
+		query = query.Where("?TableAlias.created_at = ?", createdAt)
+	}
+
+	err := withShippingAddress(withRefunds(query)).Relation("Returns", orderReturnsByID).Scan(ctx)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -102,12 +169,90 @@ func (r *orderRepository) Create(ctx context.Context, order *entity.Order) (*ent
 
 	dbOrder := model.AsOrder(order)
 
-	_, err := r.db.NewInsert().Model(dbOrder).Exec(ctx)
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(dbOrder).Exec(ctx); err != nil {
+			return exception.NewDBError(err, r.GetTableName(), "create order")
+		}
+
+		for _, item := range dbOrder.Items {
+			item.OrderID = dbOrder.ID
+			item.OrderCreatedAt = dbOrder.CreatedAt
+		}
+
+		if len(dbOrder.Items) > 0 {
+			if _, err := tx.NewInsert().Model(&dbOrder.Items).Exec(ctx); err != nil {
+				return exception.NewDBError(err, "order_items", "create order items")
+			}
+		}
+
+		// Adjustments reference their line by pointer until the items have
+		// been inserted and received IDs.
+		itemIDs := make(map[*entity.OrderItem]uint32, len(order.Items))
+		for i, item := range order.Items {
+			itemIDs[item] = dbOrder.Items[i].ID
+		}
+
+		dbOrder.Adjustments = model.AsOrderAdjustments(order.Adjustments)
+		for i, adjustment := range dbOrder.Adjustments {
+			adjustment.OrderID = dbOrder.ID
+
+			if itemID, ok := itemIDs[order.Adjustments[i].OrderItem]; ok && adjustment.OrderItemID == nil {
+				adjustment.OrderItemID = &itemID
+			}
+		}
+
+		if len(dbOrder.Adjustments) > 0 {
+			if _, err := tx.NewInsert().Model(&dbOrder.Adjustments).Exec(ctx); err != nil {
+				return exception.NewDBError(err, "order_adjustments", "create order adjustments")
+			}
+		}
+
+		if dbOrder.ShippingAddress != nil {
+			dbOrder.ShippingAddress.OrderID = dbOrder.ID
+
+			if _, err := tx.NewInsert().Model(dbOrder.ShippingAddress).Exec(ctx); err != nil {
+				return exception.NewDBError(err, "order_shipping_addresses", "create order shipping address")
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, exception.NewDBError(err, r.GetTableName(), "create order")
+		return nil, err
 	}
 
-	return dbOrder.ToDomain(), nil
+	created := dbOrder.ToDomain()
+	if created.ShippingAddress != nil && order.ShippingAddress != nil {
+		created.ShippingAddress.District = order.ShippingAddress.District
+	}
+
+	return created, nil
+}
+
+func orderPaymentsByID(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.Order("payment.id ASC")
+}
+
+func orderReturnsByID(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.Order("order_return.id ASC")
+}
+
+func withRefunds(query *bun.SelectQuery) *bun.SelectQuery {
+	return query.
+		Relation("Refunds", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("refund.id ASC")
+		}).
+		Relation("Refunds.Items")
+}
+
+// withShippingAddress loads the shipping address with its district, city and
+// province names.
+func withShippingAddress(query *bun.SelectQuery) *bun.SelectQuery {
+	return query.
+		Relation("ShippingAddress").
+		Relation("ShippingAddress.District").
+		Relation("ShippingAddress.District.City").
+		Relation("ShippingAddress.District.City.Province")
 }
 
 func (r *orderRepository) Update(ctx context.Context, order *entity.Order) (*entity.Order, error) {
@@ -144,6 +289,255 @@ func (r *orderRepository) UpdateStatus(ctx context.Context, id uint32, status st
 	return nil
 }
 
+// TransitionStatus moves the order created at createdAt to status to only if
+// it is currently in status from. It reports whether the order was updated.
+func (r *orderRepository) TransitionStatus(ctx context.Context, id uint32, createdAt time.Time, from, to string) (bool, error) {
+	if id == 0 {
+		return false, exception.ErrIDNull
+	}
+
+	res, err := r.db.NewUpdate().
+		Model((*model.Order)(nil)).
+		Set("status = ?", to).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Where("created_at = ?", createdAt).
+		Where("status = ?", from).
+		Exec(ctx)
+	if err != nil {
+		return false, exception.NewDBError(err, r.GetTableName(), "transition order status")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, exception.NewDBError(err, r.GetTableName(), "transition order status")
+	}
+
+	return affected == 1, nil
+}
+
+func (r *orderRepository) SetDeliveredAt(ctx context.Context, id uint32, at time.Time) error {
+	if id == 0 {
+		return exception.ErrIDNull
+	}
+
+	_, err := r.db.NewUpdate().
+		Model((*model.Order)(nil)).
+		Set("delivered_at = ?", at).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return exception.NewDBError(err, r.GetTableName(), "set order delivered at")
+	}
+
+	return nil
+}
+
+// SetCancelled records why and when the order was cancelled.
+func (r *orderRepository) SetCancelled(ctx context.Context, id uint32, reason string, at time.Time) error {
+	if id == 0 {
+		return exception.ErrIDNull
+	}
+
+	_, err := r.db.NewUpdate().
+		Model((*model.Order)(nil)).
+		Set("cancellation_reason = ?", reason).
+		Set("cancelled_at = ?", at).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return exception.NewDBError(err, r.GetTableName(), "set order cancelled")
+	}
+
+	return nil
+}
+
+// LockByID takes a row lock on the order until the surrounding transaction
+// ends. Changes that are checked against the order's items take it first so
+// they are applied one at a time. It returns the order's created_at, which
+// later lookups of the order pass to read only its partition.
+func (r *orderRepository) LockByID(ctx context.Context, id uint32) (time.Time, error) {
+	var createdAt time.Time
+
+	err := r.db.NewSelect().
+		Model((*model.Order)(nil)).
+		Column("created_at").
+		Where("id = ?", id).
+		For("UPDATE").
+		Scan(ctx, &createdAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, exception.New(exception.TypeNotFound, exception.CodeNotFound, "order not found")
+		}
+
+		return time.Time{}, exception.NewDBError(err, r.GetTableName(), "lock order")
+	}
+
+	return createdAt, nil
+}
+
+// UpdateTotals writes the order's derived amounts.
+func (r *orderRepository) UpdateTotals(ctx context.Context, order *entity.Order) error {
+	if order == nil || order.ID == 0 {
+		return exception.ErrDataNull
+	}
+
+	order.UpdatedAt = time.Now()
+
+	_, err := r.db.NewUpdate().
+		Model(model.AsOrder(order)).
+		Column("subtotal", "discount_total", "shipping_total", "tax_total", "total_price", "base_total_price", "updated_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return exception.NewDBError(err, r.GetTableName(), "update order totals")
+	}
+
+	return nil
+}
+
+// UpdateItem writes an order item's quantities, prices and tax. Its reservation
+// is only written by SwapItemReservation.
+func (r *orderRepository) UpdateItem(ctx context.Context, item *entity.OrderItem) error {
+	if item == nil || item.ID == 0 {
+		return exception.ErrDataNull
+	}
+
+	item.UpdatedAt = time.Now()
+
+	_, err := r.db.NewUpdate().
+		Model(model.AsOrderItem(item)).
+		Column("quantity", "price", "base_price", "subtotal", "cancelled_quantity",
+			"tax_class", "tax_rate", "taxable_amount", "tax_amount", "updated_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return exception.NewDBError(err, "order_items", "update order item")
+	}
+
+	return nil
+}
+
+// SwapItemReservation replaces the reservation of an order item with to,
+// provided it is still from. It reports whether it did, so a reservation
+// replaced concurrently is not overwritten.
+func (r *orderRepository) SwapItemReservation(ctx context.Context, id uint32, from, to *uint32) (bool, error) {
+	if id == 0 {
+		return false, exception.ErrIDNull
+	}
+
+	res, err := r.db.NewUpdate().
+		Model((*model.OrderItem)(nil)).
+		Set("reservation_id = ?", to).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Where("reservation_id IS NOT DISTINCT FROM ?", from).
+		Exec(ctx)
+	if err != nil {
+		return false, exception.NewDBError(err, "order_items", "swap order item reservation")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, exception.NewDBError(err, "order_items", "swap order item reservation")
+	}
+
+	return affected == 1, nil
+}
+
+func (r *orderRepository) CreateAdjustment(ctx context.Context, adjustment *entity.OrderAdjustment) (*entity.OrderAdjustment, error) {
+	if adjustment == nil {
+		return nil, exception.ErrDataNull
+	}
+
+	dbAdjustment := model.AsOrderAdjustment(adjustment)
+
+	if _, err := r.db.NewInsert().Model(dbAdjustment).Exec(ctx); err != nil {
+		return nil, exception.NewDBError(err, "order_adjustments", "create order adjustment")
+	}
+
+	return dbAdjustment.ToDomain(), nil
+}
+
+// UpdateItems persists an edit of the order's items as a diff: removed items
+// are deleted, items without an ID are inserted and the others updated. The
+// order's adjustments are replaced and its totals written. New items receive
+// their IDs.
+func (r *orderRepository) UpdateItems(ctx context.Context, order *entity.Order, removed []*entity.OrderItem) error {
+	if order == nil || order.ID == 0 {
+		return exception.ErrDataNull
+	}
+
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txRepo := &orderRepository{db: tx, read: func(context.Context) bun.IDB { return tx }, logger: r.logger}
+
+		if len(removed) > 0 {
+			ids := make([]uint32, len(removed))
+			for i, item := range removed {
+				ids[i] = item.ID
+			}
+
+			_, err := tx.NewDelete().
+				Model((*model.OrderItem)(nil)).
+				Where("id IN (?)", bun.In(ids)).
+				Where("order_id = ?", order.ID).
+				Exec(ctx)
+			if err != nil {
+				return exception.NewDBError(err, "order_items", "delete order items")
+			}
+		}
+
+		for _, item := range order.Items {
+			item.OrderID = order.ID
+
+			if item.ID != 0 {
+				if err := txRepo.UpdateItem(ctx, item); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			dbItem := model.AsOrderItem(item)
+			dbItem.OrderCreatedAt = order.CreatedAt
+
+			if _, err := tx.NewInsert().Model(dbItem).Exec(ctx); err != nil {
+				return exception.NewDBError(err, "order_items", "create order item")
+			}
+
+			item.ID = dbItem.ID
+			item.CreatedAt = dbItem.CreatedAt
+			item.UpdatedAt = dbItem.UpdatedAt
+		}
+
+		_, err := tx.NewDelete().
+			Model((*model.OrderAdjustment)(nil)).
+			Where("order_id = ?", order.ID).
+			Exec(ctx)
+		if err != nil {
+			return exception.NewDBError(err, "order_adjustments", "delete order adjustments")
+		}
+
+		for _, adjustment := range order.Adjustments {
+			adjustment.OrderID = order.ID
+			if adjustment.OrderItem != nil {
+				adjustment.OrderItemID = &adjustment.OrderItem.ID
+			}
+		}
+
+		if len(order.Adjustments) > 0 {
+			dbAdjustments := model.AsOrderAdjustments(order.Adjustments)
+			if _, err := tx.NewInsert().Model(&dbAdjustments).Exec(ctx); err != nil {
+				return exception.NewDBError(err, "order_adjustments", "create order adjustments")
+			}
+		}
+
+		return txRepo.UpdateTotals(ctx, order)
+	})
+}
+
 func (r *orderRepository) Delete(ctx context.Context, id uint32) error {
 	if id == 0 {
 		return exception.ErrIDNull
@@ -158,3 +552,29 @@ func (r *orderRepository) Delete(ctx context.Context, id uint32) error {
 
 	return nil
 }
+
+// Restore undeletes a deleted order. It reports false when the order does
+// not exist or is not deleted.
+func (r *orderRepository) Restore(ctx context.Context, id uint32) (bool, error) {
+	if id == 0 {
+		return false, exception.ErrIDNull
+	}
+
+	res, err := r.db.NewUpdate().
+		Model((*model.Order)(nil)).
+		Set("deleted_at = NULL").
+		Set("updated_at = ?", time.Now()).
+		Where("?TableAlias.id = ?", id).
+		WhereDeleted().
+		Exec(ctx)
+	if err != nil {
+		return false, exception.NewDBError(err, r.GetTableName(), "restore order")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, exception.NewDBError(err, r.GetTableName(), "restore order")
+	}
+
+	return affected == 1, nil
+}
@@ -0,0 +1,92 @@
+package postgresrepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"order-service/internal/adapter/repository/postgres/model"
+	"order-service/internal/domain/entity"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/logger"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+var _ OrderReturnRepository = (*orderReturnRepository)(nil)
+
+type OrderReturnRepository interface {
+	FindByIDForUpdate(ctx context.Context, id uint32) (*entity.OrderReturn, error)
+	FindByOrderItemID(ctx context.Context, orderItemID uint32) ([]*entity.OrderReturn, error)
+	Create(ctx context.Context, orderReturn *entity.OrderReturn) (*entity.OrderReturn, error)
+	Update(ctx context.Context, orderReturn *entity.OrderReturn) (*entity.OrderReturn, error)
+}
+
+type orderReturnRepository struct {
+	db     bun.IDB
+	logger logger.Logger
+}
+
+func NewOrderReturnRepository(db bun.IDB, logger logger.Logger) *orderReturnRepository {
+	return &orderReturnRepository{db: db, logger: logger}
+}
+
+func (r *orderReturnRepository) GetTableName() string {
+	return "order_returns"
+}
+
+func (r *orderReturnRepository) FindByIDForUpdate(ctx context.Context, id uint32) (*entity.OrderReturn, error) {
+	var orderReturn model.OrderReturn
+
+	err := r.db.NewSelect().Model(&orderReturn).Where("id = ?", id).For("UPDATE").Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, exception.NewDBError(err, r.GetTableName(), "find return by id for update")
+	}
+
+	return orderReturn.ToDomain(), nil
+}
+
+func (r *orderReturnRepository) FindByOrderItemID(ctx context.Context, orderItemID uint32) ([]*entity.OrderReturn, error) {
+	var returns []*model.OrderReturn
+
+	err := r.db.NewSelect().Model(&returns).Where("order_item_id = ?", orderItemID).Order("id ASC").Scan(ctx)
+	if err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "find return by order item")
+	}
+
+	return model.ToOrderReturnsDomain(returns), nil
+}
+
+func (r *orderReturnRepository) Create(ctx context.Context, orderReturn *entity.OrderReturn) (*entity.OrderReturn, error) {
+	if orderReturn == nil {
+		return nil, exception.ErrDataNull
+	}
+
+	dbOrderReturn := model.AsOrderReturn(orderReturn)
+
+	if _, err := r.db.NewInsert().Model(dbOrderReturn).Exec(ctx); err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "create return")
+	}
+
+	return dbOrderReturn.ToDomain(), nil
+}
+
+func (r *orderReturnRepository) Update(ctx context.Context, orderReturn *entity.OrderReturn) (*entity.OrderReturn, error) {
+	if orderReturn == nil || orderReturn.ID == 0 {
+		return nil, exception.ErrDataNull
+	}
+
+	dbOrderReturn := model.AsOrderReturn(orderReturn)
+	dbOrderReturn.UpdatedAt = time.Now()
+
+	_, err := r.db.NewUpdate().Model(dbOrderReturn).ExcludeColumn("created_at").WherePK().Exec(ctx)
+	if err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "update return")
+	}
+
+	return dbOrderReturn.ToDomain(), nil
+}
@@ -0,0 +1,74 @@
+package postgresrepository
+
+import (
+	"context"
+	"order-service/internal/adapter/repository/postgres/model"
+	"order-service/internal/domain/entity"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/logger"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// OrderStatusEventChannel is the channel the orders trigger notifies of every
+// status event, with the event as JSON.
+const OrderStatusEventChannel = "order_status_events"
+
+var _ OrderStatusEventRepository = (*orderStatusEventRepository)(nil)
+
+type OrderStatusEventRepository interface {
+	FindByUserAfter(ctx context.Context, userID uint32, afterID uint64, since time.Time) ([]*entity.OrderStatusEvent, error)
+	Purge(ctx context.Context, before time.Time) (int, error)
+}
+
+type orderStatusEventRepository struct {
+	db     bun.IDB
+	logger logger.Logger
+}
+
+func NewOrderStatusEventRepository(db bun.IDB, logger logger.Logger) *orderStatusEventRepository {
+	return &orderStatusEventRepository{db: db, logger: logger}
+}
+
+func (r *orderStatusEventRepository) GetTableName() string {
+	return "order_status_events"
+}
+
+// FindByUserAfter returns the user's events with an ID above afterID that
+// were recorded at or after since, oldest first.
+func (r *orderStatusEventRepository) FindByUserAfter(ctx context.Context, userID uint32, afterID uint64, since time.Time) ([]*entity.OrderStatusEvent, error) {
+	var events []*model.OrderStatusEvent
+
+	err := r.db.NewSelect().
+		Model(&events).
+		Where("?TableAlias.user_id = ?", userID).
+		Where("?TableAlias.id > ?", afterID).
+		Where("?TableAlias.created_at >= ?", since).
+		OrderExpr("?TableAlias.id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "find order status event by user")
+	}
+
+	return model.ToOrderStatusEventsDomain(events), nil
+}
+
+// Purge deletes events recorded before before. It returns the number of
+// events deleted.
+func (r *orderStatusEventRepository) Purge(ctx context.Context, before time.Time) (int, error) {
+	res, err := r.db.NewDelete().
+		Model((*model.OrderStatusEvent)(nil)).
+		Where("?TableAlias.created_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, exception.NewDBError(err, r.GetTableName(), "purge order status event")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, exception.NewDBError(err, r.GetTableName(), "purge order status event")
+	}
+
+	return int(affected), nil
+}
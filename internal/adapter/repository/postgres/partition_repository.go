@@ -0,0 +1,207 @@
+package postgresrepository
+
+import (
+	"context"
+	"order-service/constant"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/logger"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+var _ PartitionRepository = (*partitionRepository)(nil)
+
+// partitionMonthLayout is the suffix of a monthly partition's name after
+// "<table>_p", as create_monthly_partition names them.
+const partitionMonthLayout = "2006_01"
+
+type PartitionRepository interface {
+	FindMonthly(ctx context.Context, parent string) ([]*MonthlyPartition, error)
+	CreateMonthly(ctx context.Context, parent string, month time.Time) (bool, error)
+	Expire(ctx context.Context, month time.Time, drop bool) (bool, error)
+}
+
+type partitionRepository struct {
+	db     bun.IDB
+	logger logger.Logger
+}
+
+func NewPartitionRepository(db bun.IDB, logger logger.Logger) *partitionRepository {
+	return &partitionRepository{db: db, logger: logger}
+}
+
+// MonthlyPartition is the partition of a table holding the rows created in
+// one calendar month, in UTC.
+type MonthlyPartition struct {
+	Name  string
+	Month time.Time
+}
+
+// FindMonthly returns the monthly partitions attached to parent, oldest
+// first. The default partition is left out.
+func (r *partitionRepository) FindMonthly(ctx context.Context, parent string) ([]*MonthlyPartition, error) {
+	var names []string
+
+	err := r.db.NewSelect().
+		ColumnExpr("c.relname").
+		TableExpr("pg_inherits AS i").
+		Join("JOIN pg_class AS c ON c.oid = i.inhrelid").
+		Where("i.inhparent = to_regclass(?)", parent).
+		OrderExpr("c.relname").
+		Scan(ctx, &names)
+	if err != nil {
+		return nil, exception.NewDBError(err, parent, "find partitions")
+	}
+
+	partitions := make([]*MonthlyPartition, 0, len(names))
+
+	for _, name := range names {
+		month, err := time.Parse(partitionMonthLayout, strings.TrimPrefix(name, parent+"_p"))
+		if err != nil {
+			continue
+		}
+
+		partitions = append(partitions, &MonthlyPartition{Name: name, Month: month})
+	}
+
+	return partitions, nil
+}
+
+// CreateMonthly creates the partition of parent for the month of month, in
+// UTC. It reports false when the partition already exists.
+func (r *partitionRepository) CreateMonthly(ctx context.Context, parent string, month time.Time) (bool, error) {
+	var created bool
+
+	err := r.db.NewRaw("SELECT create_monthly_partition(?, ?::DATE)", parent, month.UTC().Format(time.DateOnly)).Scan(ctx, &created)
+	if err != nil {
+		return false, exception.NewDBError(err, parent, "create partition")
+	}
+
+	return created, nil
+}
+
+// partitionedOrderTables are the tables partitioned by month of their
+// order's created_at, so a month's partitions of each hold the same orders.
+// Items come first, as they belong to the orders.
+var partitionedOrderTables = []string{"order_items", "orders"}
+
+// Expire expires the partitions of the orders tables for the month of month,
+// in UTC, unless that month still has open orders, and reports whether it
+// did. No foreign key keeps the rows of the other tables from outliving
+// their orders, so in the same transaction those rows are moved to the
+// archive tables when the partitions are detached, and deleted when they
+// are dropped. Detached partitions are kept as tables of their own.
+func (r *partitionRepository) Expire(ctx context.Context, month time.Time, drop bool) (bool, error) {
+	month = month.UTC()
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	var expired bool
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Locking the month's orders keeps them from being reopened, by a
+		// refund or return, until the partitions are gone.
+		orders := tx.NewSelect().
+			Table("orders").
+			Column("id").
+			Where("created_at >= ?", from).
+			Where("created_at < ?", to)
+
+		if _, err := orders.Clone().For("UPDATE").Exec(ctx); err != nil {
+			return exception.NewDBError(err, "orders", "lock orders of partition")
+		}
+
+		open, err := hasOpenOrders(ctx, tx, from, to)
+		if err != nil {
+			return err
+		}
+		if open {
+			return nil
+		}
+
+		for _, table := range archivedOrderTables {
+			if slices.Contains(partitionedOrderTables, table.name) {
+				continue
+			}
+
+			if drop {
+				err = deleteOrderRows(ctx, tx, table.name, table.where, orders)
+			} else {
+				err = moveOrderRows(ctx, tx, table.name, table.name+"_archive", table.where, orders)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, parent := range partitionedOrderTables {
+			name := parent + "_p" + from.Format(partitionMonthLayout)
+
+			attached, err := isPartitionOf(ctx, tx, parent, name)
+			if err != nil {
+				return err
+			}
+			if !attached {
+				continue
+			}
+
+			if drop {
+				_, err = tx.NewRaw("DROP TABLE ?", bun.Ident(name)).Exec(ctx)
+			} else {
+				_, err = tx.NewRaw("ALTER TABLE ? DETACH PARTITION ?", bun.Ident(parent), bun.Ident(name)).Exec(ctx)
+			}
+			if err != nil {
+				return exception.NewDBError(err, parent, "expire partition "+name)
+			}
+		}
+
+		expired = true
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return expired, nil
+}
+
+// isPartitionOf reports whether name is a partition attached to parent.
+func isPartitionOf(ctx context.Context, db bun.IDB, parent, name string) (bool, error) {
+	exists, err := db.NewSelect().
+		TableExpr("pg_inherits").
+		Where("inhparent = to_regclass(?)", parent).
+		Where("inhrelid = to_regclass(?)", name).
+		Exists(ctx)
+	if err != nil {
+		return false, exception.NewDBError(err, parent, "find partition")
+	}
+
+	return exists, nil
+}
+
+// hasOpenOrders reports whether orders created in [from, to) include any the
+// archive would leave alone: orders not closed yet, or with a refund or
+// return still in progress.
+func hasOpenOrders(ctx context.Context, db bun.IDB, from, to time.Time) (bool, error) {
+	exists, err := db.NewSelect().
+		Table("orders").
+		Where("created_at >= ?", from).
+		Where("created_at < ?", to).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("status NOT IN (?)", bun.In(constant.ClosedOrderStatuses)).
+				WhereOr("EXISTS (SELECT 1 FROM refunds WHERE refunds.order_id = orders.id AND refunds.status <> ?)", constant.RefundStatusSucceeded).
+				WhereOr("EXISTS (SELECT 1 FROM order_returns WHERE order_returns.order_id = orders.id AND order_returns.status IN (?))",
+					bun.In([]constant.ReturnStatus{constant.ReturnStatusRequested, constant.ReturnStatusApproved}))
+		}).
+		Exists(ctx)
+	if err != nil {
+		return false, exception.NewDBError(err, "orders", "find open orders")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,187 @@
+package postgresrepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"order-service/internal/adapter/repository/postgres/model"
+	"order-service/internal/domain/entity"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/bundb"
+	"order-service/pkg/logger"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+var _ PaymentRepository = (*paymentRepository)(nil)
+
+type PaymentRepository interface {
+	FindByID(ctx context.Context, id uint32) (*entity.Payment, error)
+	FindByIDForUpdate(ctx context.Context, id uint32) (*entity.Payment, error)
+	FindByReferenceForUpdate(ctx context.Context, provider, reference string) (*entity.Payment, error)
+	FindByOrderID(ctx context.Context, orderID uint32) ([]*entity.Payment, error)
+	Create(ctx context.Context, payment *entity.Payment) (*entity.Payment, error)
+	Update(ctx context.Context, payment *entity.Payment) (*entity.Payment, error)
+	UpdateProviderDetails(ctx context.Context, id uint32, providerReference, checkoutURL string) error
+	CreateEvent(ctx context.Context, event *entity.PaymentEvent) (bool, error)
+}
+
+type paymentRepository struct {
+	db     bun.IDB
+	logger logger.Logger
+}
+
+func NewPaymentRepository(db bun.IDB, logger logger.Logger) *paymentRepository {
+	return &paymentRepository{db: db, logger: logger}
+}
+
+func (r *paymentRepository) GetTableName() string {
+	return "payments"
+}
+
+func (r *paymentRepository) FindByID(ctx context.Context, id uint32) (*entity.Payment, error) {
+	var payment model.Payment
+
+	err := r.db.NewSelect().Model(&payment).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, exception.NewDBError(err, r.GetTableName(), "FindByID")
+	}
+
+	return payment.ToDomain(), nil
+}
+
+// FindByIDForUpdate locks the payment until the surrounding transaction ends.
+// Refunds take this lock so that concurrent ones cannot together exceed the
+// captured amount.
+func (r *paymentRepository) FindByIDForUpdate(ctx context.Context, id uint32) (*entity.Payment, error) {
+	var payment model.Payment
+
+	err := r.db.NewSelect().Model(&payment).Where("id = ?", id).For("UPDATE").Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, exception.NewDBError(err, r.GetTableName(), "find payment by id for update")
+	}
+
+	return payment.ToDomain(), nil
+}
+
+// FindByReferenceForUpdate locks the payment until the surrounding
+// transaction ends, so concurrent callbacks for it are applied one at a time.
+func (r *paymentRepository) FindByReferenceForUpdate(ctx context.Context, provider, reference string) (*entity.Payment, error) {
+	var payment model.Payment
+
+	err := r.db.NewSelect().
+		Model(&payment).
+		Where("provider = ?", provider).
+		Where("reference = ?", reference).
+		For("UPDATE").
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, exception.NewDBError(err, r.GetTableName(), "find payment for update")
+	}
+
+	return payment.ToDomain(), nil
+}
+
+func (r *paymentRepository) FindByOrderID(ctx context.Context, orderID uint32) ([]*entity.Payment, error) {
+	var payments []*model.Payment
+
+	err := r.db.NewSelect().Model(&payments).Where("order_id = ?", orderID).Order("id ASC").Scan(ctx)
+	if err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "find payment by order")
+	}
+
+	return model.ToPaymentsDomain(payments), nil
+}
+
+// Create records a payment attempt. An order has at most one pending attempt;
+// creating a second one is a conflict.
+func (r *paymentRepository) Create(ctx context.Context, payment *entity.Payment) (*entity.Payment, error) {
+	if payment == nil {
+		return nil, exception.ErrDataNull
+	}
+
+	dbPayment := model.AsPayment(payment)
+
+	if _, err := r.db.NewInsert().Model(dbPayment).Exec(ctx); err != nil {
+		if bundb.IsUniqueViolation(err, "uq_payments_pending_order_id") {
+			return nil, exception.New(exception.TypeConflict, exception.CodeConflict, "a payment for this order is already in progress")
+		}
+
+		return nil, exception.NewDBError(err, r.GetTableName(), "create payment")
+	}
+
+	return dbPayment.ToDomain(), nil
+}
+
+func (r *paymentRepository) Update(ctx context.Context, payment *entity.Payment) (*entity.Payment, error) {
+	if payment == nil || payment.ID == 0 {
+		return nil, exception.ErrDataNull
+	}
+
+	dbPayment := model.AsPayment(payment)
+	dbPayment.UpdatedAt = time.Now()
+
+	_, err := r.db.NewUpdate().Model(dbPayment).ExcludeColumn("created_at").WherePK().Exec(ctx)
+	if err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "update payment")
+	}
+
+	return dbPayment.ToDomain(), nil
+}
+
+// UpdateProviderDetails stores what the provider returned for a new attempt
+// without touching its status, which a callback may already have changed.
+func (r *paymentRepository) UpdateProviderDetails(ctx context.Context, id uint32, providerReference, checkoutURL string) error {
+	if id == 0 {
+		return exception.ErrIDNull
+	}
+
+	_, err := r.db.NewUpdate().
+		Model((*model.Payment)(nil)).
+		Set("provider_reference = ?", providerReference).
+		Set("checkout_url = ?", checkoutURL).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return exception.NewDBError(err, r.GetTableName(), "update payment provider details")
+	}
+
+	return nil
+}
+
+// CreateEvent records a provider callback. It reports false when the event
+// has already been recorded, i.e. the callback is a redelivery.
+func (r *paymentRepository) CreateEvent(ctx context.Context, event *entity.PaymentEvent) (bool, error) {
+	if event == nil {
+		return false, exception.ErrDataNull
+	}
+
+	res, err := r.db.NewInsert().
+		Model(model.AsPaymentEvent(event)).
+		On("CONFLICT (provider, event_id) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return false, exception.NewDBError(err, "payment_events", "create payment event")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, exception.NewDBError(err, "payment_events", "create payment event")
+	}
+
+	return affected == 1, nil
+}
@@ -3,33 +3,86 @@ package postgresrepository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"order-service/config"
 	"order-service/internal/adapter/repository/postgres/model"
+	"order-service/internal/shared/exception"
 	"order-service/pkg/bundb"
+	"order-service/pkg/bundb/querystats"
+	"order-service/pkg/bundb/replica"
 	"order-service/pkg/logger"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/uptrace/bun"
 )
 
 var _ PostgresRepository = (*postgresRepository)(nil)
 
+const maxAtomicAttempts = 3
+
 type RepositoryAtomicCallback func(r PostgresRepository) error
 
 type PostgresRepository interface {
 	DB() *bun.DB
 	Atomic(ctx context.Context, config *config.Config, fn RepositoryAtomicCallback) error
+	WithAdvisoryLock(ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error)
+	Listen(ctx context.Context, channel string, fn func(payload string)) error
+	PoolStats() []bundb.PoolStats
+	QueryStats() *querystats.Recorder
 	Close() error
 	Order() OrderRepository
+	Coupon() CouponRepository
+	Location() LocationRepository
+	Payment() PaymentRepository
+	Refund() RefundRepository
+	OrderReturn() OrderReturnRepository
+	Job() JobRepository
+	Webhook() WebhookRepository
+	OrderStatusEvent() OrderStatusEventRepository
+	Audit() AuditRepository
+	OrderArchive() OrderArchiveRepository
+	Partition() PartitionRepository
+	RateLimit() RateLimitRepository
 }
 
 type properties struct {
-	db     bun.IDB
-	logger logger.Logger
+	db       bun.IDB
+	replicas *replica.Set
+	logger   logger.Logger
+}
+
+// Reader returns the database a read that may be served by a replica goes
+// to.
+type Reader func(ctx context.Context) bun.IDB
+
+// reader routes reads to the replicas, or, inside a transaction, to the
+// transaction itself.
+func (p properties) reader() Reader {
+	if p.replicas == nil {
+		db := p.db
+		return func(context.Context) bun.IDB { return db }
+	}
+
+	return p.replicas.Reader
 }
 
 type postgresRepository struct {
 	properties
-	orderRepository OrderRepository
+	conn                       bundb.BunDB
+	orderRepository            OrderRepository
+	couponRepository           CouponRepository
+	locationRepository         LocationRepository
+	paymentRepository          PaymentRepository
+	refundRepository           RefundRepository
+	orderReturnRepository      OrderReturnRepository
+	jobRepository              JobRepository
+	webhookRepository          WebhookRepository
+	orderStatusEventRepository OrderStatusEventRepository
+	auditRepository            AuditRepository
+	orderArchiveRepository     OrderArchiveRepository
+	partitionRepository        PartitionRepository
+	rateLimitRepository        RateLimitRepository
 }
 
 func NewPostgresRepository(config *config.Config, logger logger.Logger) (*postgresRepository, error) {
@@ -40,12 +93,18 @@ func NewPostgresRepository(config *config.Config, logger logger.Logger) (*postgr
 
 	db.DB().RegisterModel(
 		(*model.Order)(nil),
+		(*model.Coupon)(nil),
+		(*model.CouponProduct)(nil),
 	)
 
-	return create(properties{
-		db:     db.DB(),
-		logger: logger,
-	}), nil
+	repo := create(properties{
+		db:       db.DB(),
+		replicas: db.Replicas(),
+		logger:   logger,
+	})
+	repo.conn = db
+
+	return repo, nil
 }
 
 func (r *postgresRepository) DB() *bun.DB {
@@ -58,35 +117,191 @@ func (r *postgresRepository) DB() *bun.DB {
 	return dbInstance
 }
 
+// PoolStats returns the connection pool statistics of the primary and the
+// replicas.
+func (r *postgresRepository) PoolStats() []bundb.PoolStats {
+	if r.conn == nil {
+		return nil
+	}
+
+	return r.conn.PoolStats()
+}
+
+// QueryStats returns the durations of the queries run by the service,
+// aggregated by fingerprint.
+func (r *postgresRepository) QueryStats() *querystats.Recorder {
+	if r.conn == nil {
+		return nil
+	}
+
+	return r.conn.QueryStats()
+}
+
 func (r *postgresRepository) Close() error {
+	if r.conn != nil {
+		return r.conn.Close()
+	}
+
 	return r.DB().Close()
 }
 
+// Atomic runs fn in a serializable transaction. Serialization failures and
+// deadlocks are expected under concurrency at this isolation level, so the
+// transaction is retried a few times; fn must therefore be safe to re-run.
 func (r *postgresRepository) Atomic(ctx context.Context, config *config.Config, fn RepositoryAtomicCallback) error {
-	err := r.db.RunInTx(
-		ctx,
-		&sql.TxOptions{Isolation: sql.LevelSerializable},
-		func(ctx context.Context, tx bun.Tx) error {
-			return fn(create(properties{
-				db:     tx,
-				logger: r.logger,
-			}))
-		},
-	)
+	var err error
+
+	for attempt := 1; attempt <= maxAtomicAttempts; attempt++ {
+		err = r.db.RunInTx(
+			ctx,
+			&sql.TxOptions{Isolation: sql.LevelSerializable},
+			func(ctx context.Context, tx bun.Tx) error {
+				return fn(create(properties{
+					db:     tx,
+					logger: r.logger,
+				}))
+			},
+		)
+		if err == nil || !bundb.IsRetryableTxError(err) {
+			return err
+		}
+
+		logger.FromContext(ctx, r.logger).Warn().Err(err).Msgf("Retrying transaction after conflict (attempt %d/%d)", attempt, maxAtomicAttempts)
+	}
+
+	return err
+}
+
+// WithAdvisoryLock runs fn while holding the transaction-level advisory lock
+// key, so at most one replica runs it at a time. If another session holds the
+// lock, fn is not run and false is returned. The lock is released when fn
+// returns, also if the connection is lost.
+func (r *postgresRepository) WithAdvisoryLock(ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error) {
+	acquired := false
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := tx.NewRaw("SELECT pg_try_advisory_xact_lock(?)", key).Scan(ctx, &acquired); err != nil {
+			return exception.NewDBError(err, "pg_advisory_lock", "acquire advisory lock")
+		}
+
+		if !acquired {
+			return nil
+		}
+
+		return fn(ctx)
+	})
+
+	return acquired, err
+}
+
+// Listen subscribes to channel on a connection of its own and calls fn with
+// the payload of each notification, in order. It blocks until ctx is done or
+// the connection fails, and returns the error that stopped it.
+func (r *postgresRepository) Listen(ctx context.Context, channel string, fn func(payload string)) error {
+	conn, err := r.DB().Conn(ctx)
 	if err != nil {
-		return err
+		return exception.NewDBError(err, channel, "acquire listen connection")
 	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		pgxConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("listen requires the pgx driver, got %T", driverConn)
+		}
+
+		c := pgxConn.Conn()
+
+		if _, err := c.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			return exception.NewDBError(err, channel, "listen")
+		}
 
-	return nil
+		// The connection goes back to the pool afterwards and must not keep
+		// receiving notifications there.
+		defer func() {
+			if !c.IsClosed() {
+				_, _ = c.Exec(context.WithoutCancel(ctx), "UNLISTEN *")
+			}
+		}()
+
+		for {
+			notification, err := c.WaitForNotification(ctx)
+			if err != nil {
+				return err
+			}
+
+			fn(notification.Payload)
+		}
+	})
 }
 
 func create(props properties) *postgresRepository {
 	return &postgresRepository{
-		properties:      props,
-		orderRepository: NewOrderRepository(props.db, props.logger),
+		properties:                 props,
+		orderRepository:            NewOrderRepository(props.db, props.reader(), props.logger),
+		couponRepository:           NewCouponRepository(props.db, props.logger),
+		locationRepository:         NewLocationRepository(props.db, props.logger),
+		paymentRepository:          NewPaymentRepository(props.db, props.logger),
+		refundRepository:           NewRefundRepository(props.db, props.logger),
+		orderReturnRepository:      NewOrderReturnRepository(props.db, props.logger),
+		jobRepository:              NewJobRepository(props.db, props.logger),
+		webhookRepository:          NewWebhookRepository(props.db, props.logger),
+		orderStatusEventRepository: NewOrderStatusEventRepository(props.db, props.logger),
+		auditRepository:            NewAuditRepository(props.db, props.logger),
+		orderArchiveRepository:     NewOrderArchiveRepository(props.db, props.logger),
+		partitionRepository:        NewPartitionRepository(props.db, props.logger),
+		rateLimitRepository:        NewRateLimitRepository(props.db, props.logger),
 	}
 }
 
 func (r *postgresRepository) Order() OrderRepository {
 	return r.orderRepository
 }
+
+func (r *postgresRepository) Coupon() CouponRepository {
+	return r.couponRepository
+}
+
+func (r *postgresRepository) Location() LocationRepository {
+	return r.locationRepository
+}
+
+func (r *postgresRepository) Payment() PaymentRepository {
+	return r.paymentRepository
+}
+
+func (r *postgresRepository) Refund() RefundRepository {
+	return r.refundRepository
+}
+
+func (r *postgresRepository) OrderReturn() OrderReturnRepository {
+	return r.orderReturnRepository
+}
+
+func (r *postgresRepository) Job() JobRepository {
+	return r.jobRepository
+}
+
+func (r *postgresRepository) Webhook() WebhookRepository {
+	return r.webhookRepository
+}
+
+func (r *postgresRepository) OrderStatusEvent() OrderStatusEventRepository {
+	return r.orderStatusEventRepository
+}
+
+func (r *postgresRepository) Audit() AuditRepository {
+	return r.auditRepository
+}
+
+func (r *postgresRepository) OrderArchive() OrderArchiveRepository {
+	return r.orderArchiveRepository
+}
+
+func (r *postgresRepository) Partition() PartitionRepository {
+	return r.partitionRepository
+}
+
+func (r *postgresRepository) RateLimit() RateLimitRepository {
+	return r.rateLimitRepository
+}
@@ -0,0 +1,84 @@
+package postgresrepository
+
+import (
+	"context"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/logger"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+var _ RateLimitRepository = (*rateLimitRepository)(nil)
+
+type RateLimitRepository interface {
+	Take(ctx context.Context, key string, capacity, ratePerSecond float64) (*RateLimitBucket, error)
+	Purge(ctx context.Context, idleBefore time.Time) (int, error)
+}
+
+type rateLimitRepository struct {
+	db     bun.IDB
+	logger logger.Logger
+}
+
+func NewRateLimitRepository(db bun.IDB, logger logger.Logger) *rateLimitRepository {
+	return &rateLimitRepository{db: db, logger: logger}
+}
+
+func (r *rateLimitRepository) GetTableName() string {
+	return "rate_limit_buckets"
+}
+
+// RateLimitBucket is the state of a token bucket after a token was taken
+// from it, or not when Allowed is false.
+type RateLimitBucket struct {
+	Tokens  float64 `bun:"tokens"`
+	Allowed bool    `bun:"allowed"`
+}
+
+// Take refills the bucket of key at ratePerSecond up to capacity for the time
+// since it was last used and takes a token from it if one is left, in one
+// statement so concurrent requests from any replica are counted exactly. A
+// new bucket starts full.
+func (r *rateLimitRepository) Take(ctx context.Context, key string, capacity, ratePerSecond float64) (*RateLimitBucket, error) {
+	bucket := new(RateLimitBucket)
+
+	err := r.db.NewRaw(`
+		INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+		VALUES (?0, GREATEST(?1::DOUBLE PRECISION - 1, 0), ?1::DOUBLE PRECISION >= 1, CURRENT_TIMESTAMP)
+		ON CONFLICT (key) DO UPDATE SET
+			tokens = CASE
+				WHEN LEAST(?1, b.tokens + EXTRACT(EPOCH FROM CURRENT_TIMESTAMP - b.updated_at) * ?2) >= 1
+				THEN LEAST(?1, b.tokens + EXTRACT(EPOCH FROM CURRENT_TIMESTAMP - b.updated_at) * ?2) - 1
+				ELSE LEAST(?1, b.tokens + EXTRACT(EPOCH FROM CURRENT_TIMESTAMP - b.updated_at) * ?2)
+			END,
+			allowed = LEAST(?1, b.tokens + EXTRACT(EPOCH FROM CURRENT_TIMESTAMP - b.updated_at) * ?2) >= 1,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING tokens, allowed`,
+		key, capacity, ratePerSecond,
+	).Scan(ctx, bucket)
+	if err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "take rate limit token")
+	}
+
+	return bucket, nil
+}
+
+// Purge deletes the buckets unused since idleBefore, which have refilled and
+// are no different from a new one. It returns the number of buckets deleted.
+func (r *rateLimitRepository) Purge(ctx context.Context, idleBefore time.Time) (int, error) {
+	res, err := r.db.NewDelete().
+		TableExpr(r.GetTableName()).
+		Where("updated_at < ?", idleBefore).
+		Exec(ctx)
+	if err != nil {
+		return 0, exception.NewDBError(err, r.GetTableName(), "purge rate limit buckets")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, exception.NewDBError(err, r.GetTableName(), "purge rate limit buckets")
+	}
+
+	return int(affected), nil
+}
@@ -0,0 +1,122 @@
+package postgresrepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"order-service/internal/adapter/repository/postgres/model"
+	"order-service/internal/domain/entity"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/logger"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+var _ RefundRepository = (*refundRepository)(nil)
+
+type RefundRepository interface {
+	FindByID(ctx context.Context, id uint32) (*entity.Refund, error)
+	FindByPaymentID(ctx context.Context, paymentID uint32) ([]*entity.Refund, error)
+	Create(ctx context.Context, refund *entity.Refund) (*entity.Refund, error)
+	Update(ctx context.Context, refund *entity.Refund) (*entity.Refund, error)
+}
+
+type refundRepository struct {
+	db     bun.IDB
+	logger logger.Logger
+}
+
+func NewRefundRepository(db bun.IDB, logger logger.Logger) *refundRepository {
+	return &refundRepository{db: db, logger: logger}
+}
+
+func (r *refundRepository) GetTableName() string {
+	return "refunds"
+}
+
+func (r *refundRepository) FindByID(ctx context.Context, id uint32) (*entity.Refund, error) {
+	var refund model.Refund
+
+	err := r.db.NewSelect().Model(&refund).Relation("Items").Where("?TableAlias.id = ?", id).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, exception.NewDBError(err, r.GetTableName(), "FindByID")
+	}
+
+	return refund.ToDomain(), nil
+}
+
+func (r *refundRepository) FindByPaymentID(ctx context.Context, paymentID uint32) ([]*entity.Refund, error) {
+	var refunds []*model.Refund
+
+	err := r.db.NewSelect().
+		Model(&refunds).
+		Relation("Items").
+		Where("?TableAlias.payment_id = ?", paymentID).
+		OrderExpr("?TableAlias.id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "find refund by payment")
+	}
+
+	return model.ToRefundsDomain(refunds), nil
+}
+
+func (r *refundRepository) Create(ctx context.Context, refund *entity.Refund) (*entity.Refund, error) {
+	if refund == nil {
+		return nil, exception.ErrDataNull
+	}
+
+	dbRefund := model.AsRefund(refund)
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(dbRefund).Exec(ctx); err != nil {
+			return exception.NewDBError(err, r.GetTableName(), "create refund")
+		}
+
+		if len(dbRefund.Items) == 0 {
+			return nil
+		}
+
+		for _, item := range dbRefund.Items {
+			item.RefundID = dbRefund.ID
+		}
+
+		if _, err := tx.NewInsert().Model(&dbRefund.Items).Exec(ctx); err != nil {
+			return exception.NewDBError(err, "refund_items", "create refund items")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbRefund.ToDomain(), nil
+}
+
+// Update saves the refund's state. Its amount and items are fixed once
+// created and are not written.
+func (r *refundRepository) Update(ctx context.Context, refund *entity.Refund) (*entity.Refund, error) {
+	if refund == nil || refund.ID == 0 {
+		return nil, exception.ErrDataNull
+	}
+
+	dbRefund := model.AsRefund(refund)
+	dbRefund.UpdatedAt = time.Now()
+
+	_, err := r.db.NewUpdate().
+		Model(dbRefund).
+		Column("provider_reference", "status", "attempts", "last_error", "refunded_at", "failed_at", "updated_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "update refund")
+	}
+
+	return dbRefund.ToDomain(), nil
+}
@@ -0,0 +1,280 @@
+package postgresrepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"order-service/internal/adapter/repository/postgres/model"
+	"order-service/internal/domain/entity"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/logger"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+var _ WebhookRepository = (*webhookRepository)(nil)
+
+type WebhookRepository interface {
+	FindSubscriptionByID(ctx context.Context, id uint32) (*entity.WebhookSubscription, error)
+	FindSubscriptionByIDForUpdate(ctx context.Context, id uint32) (*entity.WebhookSubscription, error)
+	FindSubscriptions(ctx context.Context, filter *FilterWebhookSubscriptionPayload) ([]*entity.WebhookSubscription, int, error)
+	FindActiveSubscriptionsByEventType(ctx context.Context, eventType string) ([]*entity.WebhookSubscription, error)
+	CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uint32) error
+	FindDeliveryByID(ctx context.Context, id uint32) (*entity.WebhookDelivery, error)
+	FindDeliveries(ctx context.Context, filter *FilterWebhookDeliveryPayload) ([]*entity.WebhookDelivery, int, error)
+	CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error)
+}
+
+type webhookRepository struct {
+	db     bun.IDB
+	logger logger.Logger
+}
+
+func NewWebhookRepository(db bun.IDB, logger logger.Logger) *webhookRepository {
+	return &webhookRepository{db: db, logger: logger}
+}
+
+func (r *webhookRepository) GetTableName() string {
+	return "webhook_subscriptions"
+}
+
+type FilterWebhookSubscriptionPayload struct {
+	IsActive *bool
+	Page     int
+	PerPage  int
+}
+
+type FilterWebhookDeliveryPayload struct {
+	SubscriptionID uint32
+	Status         string
+	Page           int
+	PerPage        int
+}
+
+func (r *webhookRepository) FindSubscriptionByID(ctx context.Context, id uint32) (*entity.WebhookSubscription, error) {
+	var subscription model.WebhookSubscription
+
+	err := r.db.NewSelect().Model(&subscription).Where("?TableAlias.id = ?", id).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, exception.NewDBError(err, r.GetTableName(), "find webhook subscription by id")
+	}
+
+	return subscription.ToDomain(), nil
+}
+
+// FindSubscriptionByIDForUpdate locks the subscription until the surrounding
+// transaction ends, so concurrent deliveries update its failure count one at
+// a time.
+func (r *webhookRepository) FindSubscriptionByIDForUpdate(ctx context.Context, id uint32) (*entity.WebhookSubscription, error) {
+	var subscription model.WebhookSubscription
+
+	err := r.db.NewSelect().Model(&subscription).Where("?TableAlias.id = ?", id).For("UPDATE").Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, exception.NewDBError(err, r.GetTableName(), "find webhook subscription by id for update")
+	}
+
+	return subscription.ToDomain(), nil
+}
+
+func (r *webhookRepository) FindSubscriptions(ctx context.Context, filter *FilterWebhookSubscriptionPayload) ([]*entity.WebhookSubscription, int, error) {
+	var subscriptions []*model.WebhookSubscription
+
+	query := r.db.NewSelect().Model(&subscriptions)
+
+	if filter.IsActive != nil {
+		query = query.Where("?TableAlias.is_active = ?", *filter.IsActive)
+	}
+
+	totalCount, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, 0, exception.NewDBError(err, r.GetTableName(), "count webhook subscription")
+	}
+
+	if totalCount == 0 {
+		return []*entity.WebhookSubscription{}, 0, nil
+	}
+
+	if filter.PerPage > 0 {
+		query = query.Limit(filter.PerPage)
+	}
+
+	if filter.Page > 0 && filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query = query.Offset(offset)
+	}
+
+	query = query.OrderExpr("?TableAlias.id DESC")
+	if err := query.Scan(ctx); err != nil {
+		return nil, 0, exception.NewDBError(err, r.GetTableName(), "find webhook subscription")
+	}
+
+	return model.ToWebhookSubscriptionsDomain(subscriptions), totalCount, nil
+}
+
+func (r *webhookRepository) FindActiveSubscriptionsByEventType(ctx context.Context, eventType string) ([]*entity.WebhookSubscription, error) {
+	var subscriptions []*model.WebhookSubscription
+
+	err := r.db.NewSelect().
+		Model(&subscriptions).
+		Where("?TableAlias.is_active").
+		Where("?TableAlias.event_types @> ?", pgdialect.Array([]string{eventType})).
+		OrderExpr("?TableAlias.id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "find active webhook subscription by event type")
+	}
+
+	return model.ToWebhookSubscriptionsDomain(subscriptions), nil
+}
+
+func (r *webhookRepository) CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	if subscription == nil {
+		return nil, exception.ErrDataNull
+	}
+
+	dbSubscription := model.AsWebhookSubscription(subscription)
+
+	if _, err := r.db.NewInsert().Model(dbSubscription).Exec(ctx); err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "create webhook subscription")
+	}
+
+	return dbSubscription.ToDomain(), nil
+}
+
+func (r *webhookRepository) UpdateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	if subscription == nil || subscription.ID == 0 {
+		return nil, exception.ErrDataNull
+	}
+
+	dbSubscription := model.AsWebhookSubscription(subscription)
+	dbSubscription.UpdatedAt = time.Now()
+
+	_, err := r.db.NewUpdate().
+		Model(dbSubscription).
+		ExcludeColumn("created_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return nil, exception.NewDBError(err, r.GetTableName(), "update webhook subscription")
+	}
+
+	return dbSubscription.ToDomain(), nil
+}
+
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, id uint32) error {
+	if id == 0 {
+		return exception.ErrIDNull
+	}
+
+	dbSubscription := &model.WebhookSubscription{Base: model.Base{ID: id}}
+
+	_, err := r.db.NewDelete().Model(dbSubscription).WherePK().Exec(ctx)
+	if err != nil {
+		return exception.NewDBError(err, r.GetTableName(), "delete webhook subscription")
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) FindDeliveryByID(ctx context.Context, id uint32) (*entity.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+
+	err := r.db.NewSelect().Model(&delivery).Where("?TableAlias.id = ?", id).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, exception.NewDBError(err, "webhook_deliveries", "find webhook delivery by id")
+	}
+
+	return delivery.ToDomain(), nil
+}
+
+func (r *webhookRepository) FindDeliveries(ctx context.Context, filter *FilterWebhookDeliveryPayload) ([]*entity.WebhookDelivery, int, error) {
+	var deliveries []*model.WebhookDelivery
+
+	query := r.db.NewSelect().Model(&deliveries)
+
+	if filter.SubscriptionID > 0 {
+		query = query.Where("?TableAlias.subscription_id = ?", filter.SubscriptionID)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("?TableAlias.status = ?", filter.Status)
+	}
+
+	totalCount, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, 0, exception.NewDBError(err, "webhook_deliveries", "count webhook delivery")
+	}
+
+	if totalCount == 0 {
+		return []*entity.WebhookDelivery{}, 0, nil
+	}
+
+	if filter.PerPage > 0 {
+		query = query.Limit(filter.PerPage)
+	}
+
+	if filter.Page > 0 && filter.PerPage > 0 {
+		offset := (filter.Page - 1) * filter.PerPage
+		query = query.Offset(offset)
+	}
+
+	query = query.OrderExpr("?TableAlias.id DESC")
+	if err := query.Scan(ctx); err != nil {
+		return nil, 0, exception.NewDBError(err, "webhook_deliveries", "find webhook delivery")
+	}
+
+	return model.ToWebhookDeliveriesDomain(deliveries), totalCount, nil
+}
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	if delivery == nil {
+		return nil, exception.ErrDataNull
+	}
+
+	dbDelivery := model.AsWebhookDelivery(delivery)
+
+	if _, err := r.db.NewInsert().Model(dbDelivery).Exec(ctx); err != nil {
+		return nil, exception.NewDBError(err, "webhook_deliveries", "create webhook delivery")
+	}
+
+	return dbDelivery.ToDomain(), nil
+}
+
+// UpdateDelivery saves the outcome of a delivery attempt. The event it
+// carries is fixed once created and is not written.
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	if delivery == nil || delivery.ID == 0 {
+		return nil, exception.ErrDataNull
+	}
+
+	dbDelivery := model.AsWebhookDelivery(delivery)
+	dbDelivery.UpdatedAt = time.Now()
+
+	_, err := r.db.NewUpdate().
+		Model(dbDelivery).
+		Column("status", "attempts", "response_status", "response_body", "last_error", "delivered_at", "failed_at", "updated_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return nil, exception.NewDBError(err, "webhook_deliveries", "update webhook delivery")
+	}
+
+	return dbDelivery.ToDomain(), nil
+}
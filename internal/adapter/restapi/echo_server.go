@@ -7,7 +7,10 @@ import (
 	"order-service/config"
 	"order-service/internal/adapter/repository"
 	"order-service/internal/adapter/restapi/handler"
+	"order-service/internal/adapter/restapi/openapi"
 	"order-service/internal/domain/service"
+	"order-service/internal/orderstream"
+	"order-service/internal/ratelimit"
 	"order-service/pkg/logger"
 	"time"
 
@@ -24,26 +27,57 @@ type Server interface {
 }
 
 type echoServer struct {
-	config  *config.Config
-	logger  logger.Logger
-	echo    *echo.Echo
-	handler handler.Handler
+	config     *config.Config
+	logger     logger.Logger
+	echo       *echo.Echo
+	handler    handler.Handler
+	limiter    *ratelimit.Limiter
+	spec       []byte
+	validator  *openapi.Validator
+	stream     *orderstream.Hub
+	stopStream context.CancelFunc
+	streamDone chan struct{}
 }
 
 func NewEchoServer(config *config.Config, logger logger.Logger, service service.Service, repository repository.Repository) (*echoServer, error) {
 	e := echo.New()
 	e.HideBanner = true
+	e.Server.ReadTimeout = config.HTTP.ReadTimeout
+	e.Server.WriteTimeout = config.HTTP.WriteTimeout
+	e.Server.IdleTimeout = config.HTTP.IdleTimeout
 
-	handler, err := handler.NewHandler(config, logger, service, repository.Postgres().DB())
+	stream := orderstream.NewHub(config.Stream, repository, logger.NewInstance().Field("component", "order_stream").Logger())
+
+	handler, err := handler.NewHandler(config, logger, service, repository.Postgres().DB(), stream)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := ratelimit.NewStore(config, repository, logger.NewInstance().Field("component", "rate_limit").Logger())
 	if err != nil {
 		return nil, err
 	}
 
+	spec, err := openapi.Load(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	specJSON, err := spec.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode openapi document")
+	}
+
 	server := &echoServer{
-		config:  config,
-		logger:  logger.NewInstance().Field("component", "http_server").Logger(),
-		echo:    e,
-		handler: handler,
+		config:     config,
+		logger:     logger.NewInstance().Field("component", "http_server").Logger(),
+		echo:       e,
+		handler:    handler,
+		limiter:    ratelimit.NewLimiter(store),
+		spec:       specJSON,
+		validator:  openapi.NewValidator(spec),
+		stream:     stream,
+		stopStream: func() {},
 	}
 
 	server.setupMiddlewares()
@@ -58,6 +92,15 @@ func (s *echoServer) Echo() *echo.Echo {
 
 func (s *echoServer) Start() error {
 	address := fmt.Sprintf("%s:%d", s.config.HTTP.Host, s.config.HTTP.Port)
+
+	streamCtx, stopStream := context.WithCancel(context.Background())
+	s.stopStream = stopStream
+	s.streamDone = make(chan struct{})
+
+	go func() {
+		defer close(s.streamDone)
+		s.stream.Run(streamCtx)
+	}()
 	startErrChan := make(chan error, 1)
 
 	go func() {
@@ -88,9 +131,22 @@ func (s *echoServer) Shutdown(ctx context.Context) error {
 	shutdownCtx, cancel := context.WithTimeout(ctx, shutdownTimeout)
 	defer cancel()
 
+	// Open order streams never finish on their own and would hold the
+	// shutdown until its timeout, so they are ended first.
+	s.stream.Close()
+	s.stopStream()
+
 	if err := s.echo.Shutdown(shutdownCtx); err != nil {
 		return errors.Wrap(err, "server shutdown failed")
 	}
 
+	if s.streamDone != nil {
+		select {
+		case <-s.streamDone:
+		case <-shutdownCtx.Done():
+			return errors.Wrap(shutdownCtx.Err(), "order stream listener shutdown failed")
+		}
+	}
+
 	return nil
 }
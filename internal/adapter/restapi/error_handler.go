@@ -18,16 +18,15 @@ func (s *echoServer) httpErrorHandler(err error, c echo.Context) {
 		s.logger.Warn().Msg("Request ID not found in context, using empty string")
 	}
 
-	if apmErr := apm.CaptureError(c.Request().Context(), err); apmErr != nil {
+	// Errors raised by the middlewares that run before the APM transaction
+	// starts, such as rate limit rejections, have no tracer to report to.
+	if apmErr := apm.CaptureError(c.Request().Context(), err); apmErr != nil && apmErr.ErrorData != nil {
 		apmErr.Handled = true
 		apmErr.Context.SetHTTPRequest(c.Request())
 		apmErr.Send()
 	}
 
-	log, ok := c.Get(constant.CtxKeySubLogger).(logger.Logger)
-	if !ok || log == nil {
-		log = s.logger.NewInstance().Field("request_id", requestID).Logger()
-	}
+	log := logger.FromContext(c.Request().Context(), s.logger.NewInstance().Field("request_id", requestID).Logger())
 
 	if err == nil || c.Response().Committed {
 		if err != nil {
@@ -37,6 +36,17 @@ func (s *echoServer) httpErrorHandler(err error, c echo.Context) {
 		return
 	}
 
+	// The body limit and the binder report violations as framework errors,
+	// wrapped in a 400 when the limit is hit while binding.
+	switch {
+	case errors.Is(err, echo.ErrStatusRequestEntityTooLarge), errors.As(err, new(*http.MaxBytesError)):
+		err = exception.Newf(exception.TypePayloadTooLarge, exception.CodePayloadTooLarge,
+			"request body exceeds %d bytes", s.config.HTTP.MaxBodySize)
+	case errors.Is(err, echo.ErrUnsupportedMediaType):
+		err = exception.Newf(exception.TypeUnsupportedMediaType, exception.CodeUnsupportedMediaType,
+			"unsupported content type %q", c.Request().Header.Get(echo.HeaderContentType))
+	}
+
 	var (
 		statusCode  int
 		responseMsg string
@@ -91,7 +101,9 @@ func buildErrorPayload(ex *exception.Exception, initialStatusCode int, forceGene
 	defaultMessage := "An internal server error occurred."
 	defaultDetail := map[string]any{"type": string(exception.TypeInternalError), "request_id": requestID}
 
-	if ex != nil {
+	if ex == nil {
+		statusCode = initialStatusCode
+	} else {
 		switch ex.Type {
 		case exception.TypeBadRequest:
 			statusCode = http.StatusBadRequest
@@ -107,6 +119,8 @@ func buildErrorPayload(ex *exception.Exception, initialStatusCode int, forceGene
 			statusCode = http.StatusConflict
 		case exception.TypeUnsupportedMediaType:
 			statusCode = http.StatusUnsupportedMediaType
+		case exception.TypePayloadTooLarge:
+			statusCode = http.StatusRequestEntityTooLarge
 		case exception.TypeRateLimitExceeded:
 			statusCode = http.StatusTooManyRequests
 		case exception.TypeMethodNotAllowed:
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/adapter/restapi/response"
+	"order-service/internal/adapter/restapi/serializer"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuditHandler interface {
+	List(c echo.Context) error
+}
+
+type auditHandler struct {
+	properties
+}
+
+func NewAuditHandler(props properties) AuditHandler {
+	return &auditHandler{properties: props}
+}
+
+// List returns audit entries newest first, filtered by entity, actor, action
+// and an RFC 3339 time range.
+func (h *auditHandler) List(c echo.Context) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
+
+	filter := &postgresrepository.FilterAuditLogPayload{
+		EntityType: c.QueryParam("entity_type"),
+		ActorType:  c.QueryParam("actor_type"),
+		ActorID:    c.QueryParam("actor_id"),
+		Action:     c.QueryParam("action"),
+		Page:       page,
+		PerPage:    perPage,
+	}
+
+	if v := c.QueryParam("entity_id"); v != "" {
+		entityID, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return err
+		}
+
+		filter.EntityID = uint32(entityID)
+	}
+
+	if v := c.QueryParam("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return err
+		}
+
+		filter.From = from
+	}
+
+	if v := c.QueryParam("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return err
+		}
+
+		filter.To = to
+	}
+
+	entries, total, err := h.service.Audit().Find(c.Request().Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	totalPage := 0
+	if perPage > 0 {
+		totalPage = (total + perPage - 1) / perPage
+	}
+
+	return response.Paginate(c, "Audit log retrieved successfully", serializer.SerializeAuditLogs(entries), response.Pagination{
+		Page:       page,
+		PerPage:    perPage,
+		TotalCount: total,
+		TotalPage:  totalPage,
+	})
+}
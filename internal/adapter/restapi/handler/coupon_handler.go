@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"net/http"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/adapter/restapi/response"
+	"order-service/internal/adapter/restapi/serializer"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/promotion"
+	"order-service/pkg/money"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CouponHandler interface {
+	Create(c echo.Context) error
+	Get(c echo.Context) error
+	List(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+}
+
+type couponHandler struct {
+	properties
+}
+
+func NewCouponHandler(props properties) CouponHandler {
+	return &couponHandler{properties: props}
+}
+
+// CouponRequest is shared by create and update; amounts are in the base
+// currency.
+type CouponRequest struct {
+	Code               string        `json:"code" validate:"required,max=64,code_chars_allowed"`
+	Name               string        `json:"name" validate:"required,max=255"`
+	Description        string        `json:"description" validate:"max=1000"`
+	Type               string        `json:"type" validate:"required,oneof=PERCENTAGE FIXED FREE_ITEM"`
+	PercentOff         money.Percent `json:"percent_off"`
+	AmountOff          money.Money   `json:"amount_off"`
+	MaxDiscount        money.Money   `json:"max_discount"`
+	MinSpend           money.Money   `json:"min_spend"`
+	FreeProductID      string        `json:"free_product_id" validate:"max=64"`
+	FreeQuantity       int           `json:"free_quantity" validate:"min=0"`
+	StartsAt           *time.Time    `json:"starts_at"`
+	EndsAt             *time.Time    `json:"ends_at"`
+	UsageLimit         int           `json:"usage_limit" validate:"min=0"`
+	UsageLimitPerUser  int           `json:"usage_limit_per_user" validate:"min=0"`
+	IsActive           *bool         `json:"is_active"`
+	EligibleProductIDs []string      `json:"eligible_product_ids" validate:"omitempty,dive,required,max=64"`
+}
+
+func (r *CouponRequest) toEntity() *entity.Coupon {
+	isActive := true
+	if r.IsActive != nil {
+		isActive = *r.IsActive
+	}
+
+	return &entity.Coupon{
+		Code:               r.Code,
+		Name:               r.Name,
+		Description:        r.Description,
+		Type:               r.Type,
+		PercentOff:         r.PercentOff,
+		AmountOff:          r.AmountOff,
+		MaxDiscount:        r.MaxDiscount,
+		MinSpend:           r.MinSpend,
+		FreeProductID:      r.FreeProductID,
+		FreeQuantity:       r.FreeQuantity,
+		StartsAt:           r.StartsAt,
+		EndsAt:             r.EndsAt,
+		UsageLimit:         r.UsageLimit,
+		UsageLimitPerUser:  r.UsageLimitPerUser,
+		IsActive:           isActive,
+		EligibleProductIDs: r.EligibleProductIDs,
+	}
+}
+
+func (h *couponHandler) bind(c echo.Context) (*CouponRequest, error) {
+	var req CouponRequest
+	if err := c.Bind(&req); err != nil {
+		return nil, err
+	}
+
+	req.Code = promotion.NormalizeCode(req.Code)
+
+	if err := h.validator.Struct(req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (h *couponHandler) Create(c echo.Context) error {
+	req, err := h.bind(c)
+	if err != nil {
+		return err
+	}
+
+	coupon, err := h.service.Coupon().Create(c.Request().Context(), req.toEntity())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, serializer.SerializeCoupon(coupon))
+}
+
+func (h *couponHandler) Get(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	coupon, err := h.service.Coupon().FindByID(c.Request().Context(), uint32(id))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, "Coupon retrieved successfully", serializer.SerializeCoupon(coupon))
+}
+
+func (h *couponHandler) List(c echo.Context) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
+
+	filter := &postgresrepository.FilterCouponPayload{
+		Search:  c.QueryParam("search"),
+		Page:    page,
+		PerPage: perPage,
+	}
+
+	if v := c.QueryParam("is_active"); v != "" {
+		isActive, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+
+		filter.IsActive = &isActive
+	}
+
+	coupons, total, err := h.service.Coupon().Find(c.Request().Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	totalPage := 0
+	if perPage > 0 {
+		totalPage = (total + perPage - 1) / perPage
+	}
+
+	return response.Paginate(c, "Coupons retrieved successfully", serializer.SerializeCoupons(coupons), response.Pagination{
+		Page:       page,
+		PerPage:    perPage,
+		TotalCount: total,
+		TotalPage:  totalPage,
+	})
+}
+
+func (h *couponHandler) Update(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	req, err := h.bind(c)
+	if err != nil {
+		return err
+	}
+
+	coupon := req.toEntity()
+	coupon.ID = uint32(id)
+
+	updated, err := h.service.Coupon().Update(c.Request().Context(), coupon)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, "Coupon updated successfully", serializer.SerializeCoupon(updated))
+}
+
+func (h *couponHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.Coupon().Delete(c.Request().Context(), uint32(id)); err != nil {
+		return err
+	}
+
+	return response.Success(c, "Coupon deleted successfully", nil)
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"order-service/internal/adapter/restapi/response"
+	"order-service/internal/adapter/restapi/serializer"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/bundb/querystats"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+const defaultQueryStatsLimit = 50
+
+type DatabaseHandler interface {
+	Stats(c echo.Context) error
+	Queries(c echo.Context) error
+	ResetQueries(c echo.Context) error
+}
+
+type databaseHandler struct {
+	properties
+}
+
+func NewDatabaseHandler(props properties) DatabaseHandler {
+	return &databaseHandler{properties: props}
+}
+
+// Stats returns the live connection pool statistics of the primary and the
+// read replicas.
+func (h *databaseHandler) Stats(c echo.Context) error {
+	return response.Success(c, "Database pool stats retrieved successfully", serializer.SerializePoolStats(h.service.Database().PoolStats()))
+}
+
+// Queries returns the stats of the query fingerprints, largest first by the
+// sort key (total, count, p50, p99 or max; total by default).
+func (h *databaseHandler) Queries(c echo.Context) error {
+	key := querystats.SortByTotal
+	if v := c.QueryParam("sort"); v != "" {
+		key = querystats.SortKey(v)
+		if !key.Valid() {
+			return exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "invalid sort %q", v)
+		}
+	}
+
+	limit := defaultQueryStatsLimit
+	if v := c.QueryParam("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "invalid limit %q", v)
+		}
+
+		limit = n
+	}
+
+	return response.Success(c, "Query stats retrieved successfully", serializer.SerializeQueryStats(h.service.Database().QueryStats(key, limit)))
+}
+
+// ResetQueries discards the collected query stats.
+func (h *databaseHandler) ResetQueries(c echo.Context) error {
+	h.service.Database().ResetQueryStats()
+
+	return response.Success(c, "Query stats reset successfully", nil)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"order-service/config"
 	"order-service/internal/domain/service"
+	"order-service/internal/orderstream"
 	"order-service/internal/shared"
 	"order-service/pkg/logger"
 
@@ -14,6 +15,13 @@ import (
 
 type Handler interface {
 	Order() OrderHandler
+	Coupon() CouponHandler
+	Payment() PaymentHandler
+	Refund() RefundHandler
+	Return() ReturnHandler
+	Webhook() WebhookHandler
+	Audit() AuditHandler
+	Database() DatabaseHandler
 }
 
 type properties struct {
@@ -22,14 +30,22 @@ type properties struct {
 	service   service.Service
 	validator *validator.Validate
 	db        *bun.DB
+	stream    *orderstream.Hub
 }
 
 type handler struct {
 	properties
-	orderHandler OrderHandler
+	orderHandler    OrderHandler
+	couponHandler   CouponHandler
+	paymentHandler  PaymentHandler
+	refundHandler   RefundHandler
+	returnHandler   ReturnHandler
+	webhookHandler  WebhookHandler
+	auditHandler    AuditHandler
+	databaseHandler DatabaseHandler
 }
 
-func NewHandler(config *config.Config, logger logger.Logger, service service.Service, db *bun.DB) (*handler, error) {
+func NewHandler(config *config.Config, logger logger.Logger, service service.Service, db *bun.DB, stream *orderstream.Hub) (*handler, error) {
 	if config == nil {
 		return nil, errors.New("config cannot be nil")
 	}
@@ -45,11 +61,19 @@ func NewHandler(config *config.Config, logger logger.Logger, service service.Ser
 		logger:    logger,
 		validator: validate,
 		db:        db,
+		stream:    stream,
 	}
 
 	h := &handler{
-		properties:   props,
-		orderHandler: NewOrderHandler(props),
+		properties:      props,
+		orderHandler:    NewOrderHandler(props),
+		couponHandler:   NewCouponHandler(props),
+		paymentHandler:  NewPaymentHandler(props),
+		refundHandler:   NewRefundHandler(props),
+		returnHandler:   NewReturnHandler(props),
+		webhookHandler:  NewWebhookHandler(props),
+		auditHandler:    NewAuditHandler(props),
+		databaseHandler: NewDatabaseHandler(props),
 	}
 
 	return h, nil
@@ -58,3 +82,31 @@ func NewHandler(config *config.Config, logger logger.Logger, service service.Ser
 func (h *handler) Order() OrderHandler {
 	return h.orderHandler
 }
+
+func (h *handler) Coupon() CouponHandler {
+	return h.couponHandler
+}
+
+func (h *handler) Payment() PaymentHandler {
+	return h.paymentHandler
+}
+
+func (h *handler) Refund() RefundHandler {
+	return h.refundHandler
+}
+
+func (h *handler) Return() ReturnHandler {
+	return h.returnHandler
+}
+
+func (h *handler) Webhook() WebhookHandler {
+	return h.webhookHandler
+}
+
+func (h *handler) Audit() AuditHandler {
+	return h.auditHandler
+}
+
+func (h *handler) Database() DatabaseHandler {
+	return h.databaseHandler
+}
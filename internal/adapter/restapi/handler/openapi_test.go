@@ -0,0 +1,123 @@
+package handler_test
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"order-service/internal/adapter/restapi/handler"
+	"order-service/internal/adapter/restapi/openapi"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requestBodies maps the operations of the OpenAPI document to the structs
+// their handlers bind the request body to.
+var requestBodies = map[string]any{
+	"createOrder":               handler.CreateOrderRequest{},
+	"updateOrder":               handler.UpdateOrderRequest{},
+	"cancelOrderItem":           handler.CancelOrderItemRequest{},
+	"createReturn":              handler.CreateReturnRequest{},
+	"rejectReturn":              handler.RejectReturnRequest{},
+	"createRefund":              handler.RefundRequest{},
+	"createCoupon":              handler.CouponRequest{},
+	"updateCoupon":              handler.CouponRequest{},
+	"createWebhookSubscription": handler.CreateWebhookSubscriptionRequest{},
+	"updateWebhookSubscription": handler.UpdateWebhookSubscriptionRequest{},
+}
+
+// rawBodies are the operations whose handlers read the body as it is.
+var rawBodies = map[string]bool{
+	"handlePaymentWebhook": true,
+}
+
+// TestOpenAPI_RequestBodies checks that the request body schemas of the
+// document have the fields of the request structs, and require the fields
+// the structs validate as required.
+func TestOpenAPI_RequestBodies(t *testing.T) {
+	spec, err := openapi.Load(context.Background())
+	require.NoError(t, err)
+
+	for path, item := range spec.Paths.Map() {
+		for method, op := range item.Operations() {
+			if op.RequestBody == nil || rawBodies[op.OperationID] {
+				continue
+			}
+
+			req, ok := requestBodies[op.OperationID]
+			if !assert.True(t, ok, "%s %s (%s) has a request body but no request struct", method, path, op.OperationID) {
+				continue
+			}
+
+			media := op.RequestBody.Value.Content.Get(echo.MIMEApplicationJSON)
+			if !assert.NotNil(t, media, "%s has no JSON request body", op.OperationID) {
+				continue
+			}
+
+			assertSchemaMatches(t, op.OperationID, media.Schema.Value, reflect.TypeOf(req))
+		}
+	}
+}
+
+func assertSchemaMatches(t *testing.T, at string, schema *openapi3.Schema, typ reflect.Type) {
+	t.Helper()
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if isJSONLeaf(typ) {
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Slice:
+		if assert.NotNil(t, schema.Items, "%s is an array in the request struct", at) {
+			assertSchemaMatches(t, at+"[]", schema.Items.Value, typ.Elem())
+		}
+	case reflect.Struct:
+		var fields, required []string
+
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+
+			fields = append(fields, name)
+
+			rule, _, _ := strings.Cut(field.Tag.Get("validate"), ",")
+			if rule == "required" {
+				required = append(required, name)
+			}
+
+			if prop, ok := schema.Properties[name]; assert.True(t, ok, "%s.%s is missing from the schema", at, name) {
+				assertSchemaMatches(t, at+"."+name, prop.Value, field.Type)
+			}
+		}
+
+		properties := make([]string, 0, len(schema.Properties))
+		for name := range schema.Properties {
+			properties = append(properties, name)
+		}
+
+		assert.ElementsMatch(t, fields, properties, "%s has other fields than its schema", at)
+		assert.ElementsMatch(t, required, schema.Required, "%s requires other fields than its schema", at)
+	}
+}
+
+// isJSONLeaf reports whether values of typ encode themselves, as times and
+// amounts do.
+func isJSONLeaf(typ reflect.Type) bool {
+	ptr := reflect.PointerTo(typ)
+
+	return ptr.Implements(reflect.TypeFor[json.Unmarshaler]()) || ptr.Implements(reflect.TypeFor[encoding.TextUnmarshaler]())
+}
@@ -1,11 +1,17 @@
 package handler
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
 	"order-service/internal/adapter/restapi/response"
 	"order-service/internal/adapter/restapi/serializer"
 	"order-service/internal/domain/entity"
+	"order-service/internal/shared/exception"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
@@ -14,7 +20,14 @@ type OrderHandler interface {
 	Create(c echo.Context) error
 	Get(c echo.Context) error
 	List(c echo.Context) error
+	Update(c echo.Context) error
 	Cancel(c echo.Context) error
+	CancelItem(c echo.Context) error
+	Deliver(c echo.Context) error
+	Stream(c echo.Context) error
+	AdminList(c echo.Context) error
+	Delete(c echo.Context) error
+	Restore(c echo.Context) error
 }
 
 type orderHandler struct {
@@ -26,7 +39,19 @@ func NewOrderHandler(props properties) OrderHandler {
 }
 
 type CreateOrderRequest struct {
-	Items []CreateOrderItemRequest `json:"items" validate:"required,min=1"`
+	Currency        string                   `json:"currency" validate:"omitempty,len=3,uppercase"`
+	TaxRegion       string                   `json:"tax_region" validate:"omitempty,max=16"`
+	ShippingAddress *ShippingAddressRequest  `json:"shipping_address"`
+	Items           []CreateOrderItemRequest `json:"items" validate:"required,min=1"`
+	CouponCodes     []string                 `json:"coupon_codes" validate:"omitempty,max=5,dive,required,max=64"`
+}
+
+type ShippingAddressRequest struct {
+	RecipientName string `json:"recipient_name" validate:"required,max=255"`
+	Phone         string `json:"phone" validate:"required,e164|numeric,min=6,max=20"`
+	Street        string `json:"street" validate:"required,max=500"`
+	PostalCode    string `json:"postal_code" validate:"required,numeric,len=5"`
+	DistrictID    uint32 `json:"district_id" validate:"required"`
 }
 
 type CreateOrderItemRequest struct {
@@ -34,6 +59,23 @@ type CreateOrderItemRequest struct {
 	Quantity  int    `json:"quantity" validate:"required,min=1"`
 }
 
+// UpdateOrderRequest edits an order's items. An entry with an order_item_id
+// sets that item's quantity, removing it at 0; an entry with a product_id adds
+// a new item.
+type UpdateOrderRequest struct {
+	Items []UpdateOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type UpdateOrderItemRequest struct {
+	OrderItemID uint32 `json:"order_item_id" validate:"required_without=ProductID,excluded_with=ProductID"`
+	ProductID   string `json:"product_id" validate:"required_without=OrderItemID"`
+	Quantity    int    `json:"quantity" validate:"min=0"`
+}
+
+type CancelOrderItemRequest struct {
+	Quantity int `json:"quantity" validate:"required,min=1"`
+}
+
 func (h *orderHandler) Create(c echo.Context) error {
 	var req CreateOrderRequest
 	if err := c.Bind(&req); err != nil {
@@ -52,8 +94,23 @@ func (h *orderHandler) Create(c echo.Context) error {
 	}
 
 	order := &entity.Order{
-		UserID: 1, // TODO: get from auth
-		Items:  items,
+		UserID:      1, // TODO: get from auth
+		Currency:    req.Currency,
+		TaxRegion:   strings.ToUpper(req.TaxRegion),
+		Items:       items,
+		CouponCodes: req.CouponCodes,
+	}
+
+	// Orders without an address, such as those collected in store, are not
+	// charged for shipping.
+	if address := req.ShippingAddress; address != nil {
+		order.ShippingAddress = &entity.ShippingAddress{
+			RecipientName: address.RecipientName,
+			Phone:         address.Phone,
+			Street:        address.Street,
+			PostalCode:    address.PostalCode,
+			DistrictID:    address.DistrictID,
+		}
 	}
 
 	createdOrder, err := h.service.Order().Create(c.Request().Context(), order)
@@ -82,7 +139,11 @@ func (h *orderHandler) List(c echo.Context) error {
 	page, _ := strconv.Atoi(c.QueryParam("page"))
 	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
 
-	orders, total, err := h.service.Order().Find(c.Request().Context(), 1, page, perPage) // TODO: get user id from auth
+	orders, total, err := h.service.Order().Find(c.Request().Context(), &postgresrepository.FilterOrderPayload{
+		UserID:  1, // TODO: get user id from auth
+		Page:    page,
+		PerPage: perPage,
+	})
 	if err != nil {
 		return err
 	}
@@ -95,6 +156,37 @@ func (h *orderHandler) List(c echo.Context) error {
 	})
 }
 
+func (h *orderHandler) Update(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	var req UpdateOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := h.validator.Struct(req); err != nil {
+		return err
+	}
+
+	changes := make([]*entity.OrderItem, len(req.Items))
+	for i, item := range req.Items {
+		changes[i] = &entity.OrderItem{
+			Base:      entity.Base{ID: item.OrderItemID},
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	order, err := h.service.Order().UpdateItems(c.Request().Context(), uint32(id), changes)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, "Order updated successfully", serializer.SerializeOrder(order))
+}
+
 func (h *orderHandler) Cancel(c echo.Context) error {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -108,3 +200,244 @@ func (h *orderHandler) Cancel(c echo.Context) error {
 
 	return response.Success(c, "Order cancelled successfully", nil)
 }
+
+func (h *orderHandler) CancelItem(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	itemID, err := strconv.ParseUint(c.Param("itemId"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	var req CancelOrderItemRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := h.validator.Struct(req); err != nil {
+		return err
+	}
+
+	order, err := h.service.Order().CancelItem(c.Request().Context(), uint32(id), uint32(itemID), req.Quantity)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, "Order item cancelled successfully", serializer.SerializeOrder(order))
+}
+
+func (h *orderHandler) Deliver(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Order().Deliver(c.Request().Context(), uint32(id))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, "Order marked as delivered successfully", nil)
+}
+
+// AdminList lists every user's orders, optionally including deleted ones.
+// The created_from (inclusive) and created_to (exclusive) RFC 3339 bounds
+// limit the monthly partitions scanned.
+func (h *orderHandler) AdminList(c echo.Context) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
+
+	filter := &postgresrepository.FilterOrderPayload{
+		Status:  strings.ToUpper(c.QueryParam("status")),
+		Page:    page,
+		PerPage: perPage,
+	}
+
+	if v := c.QueryParam("user_id"); v != "" {
+		userID, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return err
+		}
+
+		filter.UserID = uint32(userID)
+	}
+
+	if v := c.QueryParam("created_from"); v != "" {
+		createdFrom, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return err
+		}
+
+		filter.CreatedFrom = createdFrom
+	}
+
+	if v := c.QueryParam("created_to"); v != "" {
+		createdBefore, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return err
+		}
+
+		filter.CreatedBefore = createdBefore
+	}
+
+	if v := c.QueryParam("include_deleted"); v != "" {
+		includeDeleted, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+
+		filter.IncludeDeleted = includeDeleted
+	}
+
+	orders, total, err := h.service.Order().Find(c.Request().Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	totalPage := 0
+	if perPage > 0 {
+		totalPage = (total + perPage - 1) / perPage
+	}
+
+	return response.Paginate(c, "Orders retrieved successfully", serializer.SerializeOrders(orders), response.Pagination{
+		Page:       page,
+		PerPage:    perPage,
+		TotalCount: total,
+		TotalPage:  totalPage,
+	})
+}
+
+func (h *orderHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.Order().Delete(c.Request().Context(), uint32(id)); err != nil {
+		return err
+	}
+
+	return response.Success(c, "Order deleted successfully", nil)
+}
+
+func (h *orderHandler) Restore(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	order, err := h.service.Order().Restore(c.Request().Context(), uint32(id))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, "Order restored successfully", serializer.SerializeOrder(order))
+}
+
+// Stream pushes the status changes of the user's orders as server-sent
+// events. A client that reconnects with Last-Event-ID is first sent the
+// events it missed, as far back as the retention window.
+func (h *orderHandler) Stream(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := uint32(1) // TODO: get user id from auth
+
+	var lastID uint64
+
+	resume := c.Request().Header.Get("Last-Event-ID")
+	if resume != "" {
+		id, err := strconv.ParseUint(resume, 10, 64)
+		if err != nil {
+			return exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "invalid Last-Event-ID")
+		}
+
+		lastID = id
+	}
+
+	// Subscribing before the replay leaves no gap between the two; events
+	// seen in both are skipped by ID.
+	sub, err := h.stream.Subscribe(userID)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	var missed []*entity.OrderStatusEvent
+	if resume != "" {
+		if missed, err = h.stream.Replay(ctx, userID, lastID); err != nil {
+			return err
+		}
+	}
+
+	// A stream outlives the server's read and write timeouts, which would
+	// otherwise cut it off.
+	rc := http.NewResponseController(c.Response())
+	if err := rc.SetReadDeadline(time.Time{}); err != nil {
+		return err
+	}
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		return err
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	for _, event := range missed {
+		if err := writeOrderStatusEvent(res, event); err != nil {
+			return nil
+		}
+
+		lastID = event.ID
+	}
+
+	heartbeat := time.NewTicker(h.config.Stream.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				return nil
+			}
+
+			if event.ID <= lastID {
+				continue
+			}
+
+			if err := writeOrderStatusEvent(res, event); err != nil {
+				return nil
+			}
+
+			lastID = event.ID
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+
+			res.Flush()
+		}
+	}
+}
+
+func writeOrderStatusEvent(res *echo.Response, event *entity.OrderStatusEvent) error {
+	data, err := json.Marshal(serializer.SerializeOrderStatusEvent(event))
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(res, "id: %d\nevent: order.status\ndata: %s\n\n", event.ID, data); err != nil {
+		return err
+	}
+
+	res.Flush()
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"order-service/internal/adapter/restapi/response"
+	"order-service/internal/adapter/restapi/serializer"
+	"order-service/internal/domain/audit"
+	"order-service/internal/shared/exception"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxWebhookBodySize bounds how much of a callback body is read before its
+// signature has been checked.
+const maxWebhookBodySize = 1 << 20
+
+type PaymentHandler interface {
+	Start(c echo.Context) error
+	Webhook(c echo.Context) error
+}
+
+type paymentHandler struct {
+	properties
+}
+
+func NewPaymentHandler(props properties) PaymentHandler {
+	return &paymentHandler{properties: props}
+}
+
+func (h *paymentHandler) Start(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	payment, err := h.service.Payment().Start(c.Request().Context(), uint32(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, serializer.SerializePayment(payment))
+}
+
+// Webhook reads the raw body because the signature is computed over the exact
+// bytes the provider sent.
+func (h *paymentHandler) Webhook(c echo.Context) error {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookBodySize+1))
+	if err != nil {
+		return err
+	}
+
+	if len(body) > maxWebhookBodySize {
+		return exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "webhook body is too large")
+	}
+
+	ctx := audit.WithActor(c.Request().Context(), audit.Actor{Type: audit.ActorPaymentProvider, ID: h.config.Payment.Provider})
+
+	if err := h.service.Payment().HandleWebhook(ctx, c.Request().Header, body); err != nil {
+		return err
+	}
+
+	return response.Success(c, "Webhook processed successfully", nil)
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"order-service/internal/adapter/restapi/response"
+	"order-service/internal/adapter/restapi/serializer"
+	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RefundHandler interface {
+	Create(c echo.Context) error
+	Retry(c echo.Context) error
+}
+
+type refundHandler struct {
+	properties
+}
+
+func NewRefundHandler(props properties) RefundHandler {
+	return &refundHandler{properties: props}
+}
+
+// RefundRequest refunds either Items or Amount. Leaving both empty refunds
+// everything not yet refunded.
+type RefundRequest struct {
+	Amount money.Money          `json:"amount"`
+	Items  []*RefundItemRequest `json:"items" validate:"omitempty,dive,required"`
+	Reason string               `json:"reason" validate:"max=255"`
+}
+
+type RefundItemRequest struct {
+	OrderItemID uint32 `json:"order_item_id" validate:"required"`
+	Quantity    int    `json:"quantity" validate:"required,min=1"`
+}
+
+func (h *refundHandler) Create(c echo.Context) error {
+	orderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	var req RefundRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := h.validator.Struct(req); err != nil {
+		return err
+	}
+
+	refund := &entity.Refund{
+		Amount: req.Amount,
+		Reason: req.Reason,
+		Items:  make([]*entity.RefundItem, len(req.Items)),
+	}
+	for i, item := range req.Items {
+		refund.Items[i] = &entity.RefundItem{
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	created, err := h.service.Refund().Create(c.Request().Context(), uint32(orderID), refund)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, serializer.SerializeRefund(created))
+}
+
+func (h *refundHandler) Retry(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	refund, err := h.service.Refund().Retry(c.Request().Context(), uint32(id))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, "Refund retried successfully", serializer.SerializeRefund(refund))
+}
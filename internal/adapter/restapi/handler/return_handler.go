@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"net/http"
+	"order-service/internal/adapter/restapi/response"
+	"order-service/internal/adapter/restapi/serializer"
+	"order-service/internal/domain/entity"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReturnHandler interface {
+	Create(c echo.Context) error
+	Approve(c echo.Context) error
+	Reject(c echo.Context) error
+	Receive(c echo.Context) error
+}
+
+type returnHandler struct {
+	properties
+}
+
+func NewReturnHandler(props properties) ReturnHandler {
+	return &returnHandler{properties: props}
+}
+
+type CreateReturnRequest struct {
+	OrderItemID uint32 `json:"order_item_id" validate:"required"`
+	Quantity    int    `json:"quantity" validate:"required,min=1"`
+	ReasonCode  string `json:"reason_code" validate:"required,oneof=DAMAGED DEFECTIVE WRONG_ITEM NOT_AS_DESCRIBED NO_LONGER_NEEDED OTHER"`
+	Note        string `json:"note" validate:"max=1000"`
+}
+
+type RejectReturnRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+func (h *returnHandler) Create(c echo.Context) error {
+	orderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	var req CreateReturnRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := h.validator.Struct(req); err != nil {
+		return err
+	}
+
+	created, err := h.service.Return().Create(c.Request().Context(), uint32(orderID), &entity.OrderReturn{
+		OrderItemID: req.OrderItemID,
+		Quantity:    req.Quantity,
+		ReasonCode:  req.ReasonCode,
+		Note:        req.Note,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, serializer.SerializeOrderReturn(created))
+}
+
+func (h *returnHandler) Approve(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	orderReturn, err := h.service.Return().Approve(c.Request().Context(), uint32(id))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, "Return approved successfully", serializer.SerializeOrderReturn(orderReturn))
+}
+
+func (h *returnHandler) Reject(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	var req RejectReturnRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := h.validator.Struct(req); err != nil {
+		return err
+	}
+
+	orderReturn, err := h.service.Return().Reject(c.Request().Context(), uint32(id), req.Reason)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, "Return rejected successfully", serializer.SerializeOrderReturn(orderReturn))
+}
+
+func (h *returnHandler) Receive(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	orderReturn, err := h.service.Return().Receive(c.Request().Context(), uint32(id))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, "Return received successfully", serializer.SerializeOrderReturn(orderReturn))
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"net/http"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/adapter/restapi/response"
+	"order-service/internal/adapter/restapi/serializer"
+	"order-service/internal/domain/entity"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler interface {
+	Create(c echo.Context) error
+	Get(c echo.Context) error
+	List(c echo.Context) error
+	Update(c echo.Context) error
+	Delete(c echo.Context) error
+	Deliveries(c echo.Context) error
+}
+
+type webhookHandler struct {
+	properties
+}
+
+func NewWebhookHandler(props properties) WebhookHandler {
+	return &webhookHandler{properties: props}
+}
+
+type CreateWebhookSubscriptionRequest struct {
+	URL        string   `json:"url" validate:"required,url,max=2048"`
+	Secret     string   `json:"secret" validate:"required,min=16,max=255"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=order.created order.updated order.confirmed order.item_cancelled order.cancelled order.delivered"`
+}
+
+// UpdateWebhookSubscriptionRequest replaces the subscription's settings. The
+// secret is kept when left empty.
+type UpdateWebhookSubscriptionRequest struct {
+	URL        string   `json:"url" validate:"required,url,max=2048"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16,max=255"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=order.created order.updated order.confirmed order.item_cancelled order.cancelled order.delivered"`
+	IsActive   *bool    `json:"is_active" validate:"required"`
+}
+
+func (h *webhookHandler) Create(c echo.Context) error {
+	var req CreateWebhookSubscriptionRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return err
+	}
+
+	subscription, err := h.service.Webhook().CreateSubscription(c.Request().Context(), &entity.WebhookSubscription{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, serializer.SerializeWebhookSubscription(subscription))
+}
+
+func (h *webhookHandler) Get(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	subscription, err := h.service.Webhook().FindSubscriptionByID(c.Request().Context(), uint32(id))
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, "Webhook subscription retrieved successfully", serializer.SerializeWebhookSubscription(subscription))
+}
+
+func (h *webhookHandler) List(c echo.Context) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
+
+	filter := &postgresrepository.FilterWebhookSubscriptionPayload{
+		Page:    page,
+		PerPage: perPage,
+	}
+
+	if v := c.QueryParam("is_active"); v != "" {
+		isActive, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+
+		filter.IsActive = &isActive
+	}
+
+	subscriptions, total, err := h.service.Webhook().FindSubscriptions(c.Request().Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	totalPage := 0
+	if perPage > 0 {
+		totalPage = (total + perPage - 1) / perPage
+	}
+
+	return response.Paginate(c, "Webhook subscriptions retrieved successfully", serializer.SerializeWebhookSubscriptions(subscriptions), response.Pagination{
+		Page:       page,
+		PerPage:    perPage,
+		TotalCount: total,
+		TotalPage:  totalPage,
+	})
+}
+
+func (h *webhookHandler) Update(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	var req UpdateWebhookSubscriptionRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return err
+	}
+
+	subscription, err := h.service.Webhook().UpdateSubscription(c.Request().Context(), &entity.WebhookSubscription{
+		Base:       entity.Base{ID: uint32(id)},
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		IsActive:   *req.IsActive,
+	})
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, "Webhook subscription updated successfully", serializer.SerializeWebhookSubscription(subscription))
+}
+
+func (h *webhookHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.Webhook().DeleteSubscription(c.Request().Context(), uint32(id)); err != nil {
+		return err
+	}
+
+	return response.Success(c, "Webhook subscription deleted successfully", nil)
+}
+
+// Deliveries lists the delivery log of a subscription, newest first.
+func (h *webhookHandler) Deliveries(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return err
+	}
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	perPage, _ := strconv.Atoi(c.QueryParam("per_page"))
+
+	deliveries, total, err := h.service.Webhook().FindDeliveries(c.Request().Context(), &postgresrepository.FilterWebhookDeliveryPayload{
+		SubscriptionID: uint32(id),
+		Status:         strings.ToUpper(c.QueryParam("status")),
+		Page:           page,
+		PerPage:        perPage,
+	})
+	if err != nil {
+		return err
+	}
+
+	totalPage := 0
+	if perPage > 0 {
+		totalPage = (total + perPage - 1) / perPage
+	}
+
+	return response.Paginate(c, "Webhook deliveries retrieved successfully", serializer.SerializeWebhookDeliveries(deliveries), response.Pagination{
+		Page:       page,
+		PerPage:    perPage,
+		TotalCount: total,
+		TotalPage:  totalPage,
+	})
+}
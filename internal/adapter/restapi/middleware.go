@@ -1,9 +1,19 @@
 package rest
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math"
 	"net/http"
 	"order-service/constant"
+	"order-service/internal/domain/audit"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/bundb/replica"
 	"order-service/pkg/logger"
+	"strconv"
+	"strings"
 	"time"
 
 	echo "github.com/labstack/echo/v4"
@@ -15,11 +25,26 @@ func (s *echoServer) setupMiddlewares() {
 	s.echo.Use(middleware.Recover())
 	s.echo.Use(middleware.RequestID())
 	s.echo.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"*"},
-		AllowMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowOrigins: s.config.HTTP.AllowedOrigins,
+		AllowMethods: s.config.HTTP.AllowedMethods,
+		AllowHeaders: s.config.HTTP.AllowedHeaders,
+		ExposeHeaders: []string{
+			headerRateLimitLimit, headerRateLimitRemaining, headerRateLimitReset, headerRateLimitPolicy, echo.HeaderRetryAfter,
+		},
 	}))
+	s.echo.Use(middleware.SecureWithConfig(middleware.SecureConfig{
+		ContentTypeNosniff: "nosniff",
+		XFrameOptions:      s.config.HTTP.FrameOptions,
+		HSTSMaxAge:         int(s.config.HTTP.HSTSMaxAge.Seconds()),
+	}))
+	s.echo.Use(s.bodyLimitMiddleware())
 	s.echo.Use(s.requestLoggerMiddleware())
+	s.echo.Use(s.rateLimitMiddleware())
+	if s.config.HTTP.ValidateRequests {
+		s.echo.Use(s.requestValidationMiddleware())
+	}
+	s.echo.Use(s.auditContextMiddleware())
+	s.echo.Use(s.readYourWritesMiddleware())
 	s.echo.Use(apmecho.Middleware())
 	s.echo.HTTPErrorHandler = s.httpErrorHandler
 }
@@ -32,8 +57,10 @@ func (s *echoServer) requestLoggerMiddleware() echo.MiddlewareFunc {
 			reqID := c.Response().Header().Get(echo.HeaderXRequestID)
 			c.Set(constant.CtxKeyRequestID, reqID)
 
+			// The request logger is carried by the request context so that
+			// the services and repositories log with the request ID too.
 			reqLogger := s.logger.NewInstance().Field("request_id", reqID).Logger()
-			c.Set(constant.CtxKeySubLogger, reqLogger)
+			c.SetRequest(c.Request().WithContext(logger.WithContext(c.Request().Context(), reqLogger)))
 
 			req := c.Request()
 			err := next(c)
@@ -74,3 +101,211 @@ func (s *echoServer) requestLoggerMiddleware() echo.MiddlewareFunc {
 		}
 	}
 }
+
+// bodyLimitMiddleware refuses request bodies larger than the configured size,
+// by their declared length before they are read or else once more has been
+// read. Unlike echo's BodyLimit, the body keeps failing past the limit, so
+// decoders cannot read on regardless.
+func (s *echoServer) bodyLimitMiddleware() echo.MiddlewareFunc {
+	limit := s.config.HTTP.MaxBodySize
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.ContentLength > limit {
+				return echo.ErrStatusRequestEntityTooLarge
+			}
+
+			req.Body = http.MaxBytesReader(c.Response().Writer, req.Body, limit)
+
+			return next(c)
+		}
+	}
+}
+
+// auditContextMiddleware attributes the changes a request makes to its
+// caller, for the audit log. Admin routes replace the actor once the caller
+// is authenticated.
+func (s *echoServer) auditContextMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			ctx := audit.WithRequest(req.Context(), audit.Request{
+				Actor:     audit.Actor{Type: audit.ActorUser, ID: "1"}, // TODO: get user id from auth
+				RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+				IP:        c.RealIP(),
+				UserAgent: req.UserAgent(),
+			})
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// headerReadYourWrites lets a client that has just written in an earlier
+// request read from the primary rather than from a lagging replica.
+const headerReadYourWrites = "X-Read-Your-Writes"
+
+// readYourWritesMiddleware pins the reads of a request to the primary once it
+// has written, or from the start when the client asks with the
+// X-Read-Your-Writes header.
+func (s *echoServer) readYourWritesMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			ctx := replica.WithReadYourWrites(req.Context())
+			if pinned, _ := strconv.ParseBool(req.Header.Get(headerReadYourWrites)); pinned {
+				replica.MarkWritten(ctx)
+			}
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// adminAuthMiddleware guards admin routes with a static bearer key. Requests
+// are rejected when no key is configured.
+func (s *echoServer) adminAuthMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			if header == "" {
+				return exception.ErrAuthHeaderMissing
+			}
+
+			scheme, token, ok := strings.Cut(header, " ")
+			if !ok || token == "" {
+				return exception.ErrAuthHeaderInvalid
+			}
+
+			if !strings.EqualFold(scheme, "Bearer") {
+				return exception.ErrAuthUnsupported
+			}
+
+			key := s.config.HTTP.AdminAPIKey
+			if key == "" || subtle.ConstantTimeCompare([]byte(token), []byte(key)) != 1 {
+				return exception.New(exception.TypeForbidden, exception.CodeForbidden, "invalid admin api key")
+			}
+
+			c.SetRequest(c.Request().WithContext(audit.WithActor(c.Request().Context(), audit.Actor{Type: audit.ActorAdmin})))
+
+			return next(c)
+		}
+	}
+}
+
+// The RateLimit headers tell clients their limit on the route they called,
+// as drafted by the IETF httpapi working group.
+const (
+	headerRateLimitLimit     = "RateLimit-Limit"
+	headerRateLimitRemaining = "RateLimit-Remaining"
+	headerRateLimitReset     = "RateLimit-Reset"
+	headerRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// rateLimitMiddleware limits the requests of each client to the rule of the
+// route they call. Requests are let through when the store fails, so that an
+// unavailable store does not take the API down with it.
+func (s *echoServer) rateLimitMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			cfg := s.config.RateLimit
+			if !cfg.Enabled {
+				return next(c)
+			}
+
+			route := c.Request().Method + " " + c.Path()
+
+			rule, ok := cfg.Routes[route]
+			if !ok {
+				rule = cfg.Default
+			}
+
+			if rule.Requests == 0 {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+
+			res, err := s.limiter.Take(ctx, route+"|"+s.rateLimitClient(c), rule)
+			if err != nil {
+				logger.FromContext(ctx, s.logger).Warn().Err(err).Field("route", route).Msg("Rate limit store failed, request let through")
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(headerRateLimitLimit, strconv.Itoa(res.Limit))
+			header.Set(headerRateLimitRemaining, strconv.Itoa(res.Remaining))
+			header.Set(headerRateLimitReset, strconv.Itoa(ceilSeconds(res.Reset)))
+			header.Set(headerRateLimitPolicy, fmt.Sprintf("%d;w=%d", rule.Requests, ceilSeconds(rule.Period)))
+
+			if !res.Allowed {
+				header.Set(echo.HeaderRetryAfter, strconv.Itoa(max(ceilSeconds(res.RetryAfter), 1)))
+				return exception.Newf(exception.TypeRateLimitExceeded, exception.CodeRateLimitExceeded,
+					"rate limit of %d requests per %s exceeded", rule.Requests, rule.Period)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// headerAPIKey carries a client's API key, as an alternative to a bearer
+// token.
+const headerAPIKey = "X-API-Key"
+
+// rateLimitClient identifies the caller a request is counted against: the
+// admin when the admin key is presented, the API key or bearer token of any
+// other caller that presents one, or else the client IP. Clients sharing an
+// IP, e.g. behind a NAT, so get a bucket per key. Keys are counted by their
+// hash, so they are never written to the rate limit store.
+// TODO: key by user id once users are authenticated.
+func (s *echoServer) rateLimitClient(c echo.Context) string {
+	scheme, token, ok := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		token = ""
+	}
+
+	key := s.config.HTTP.AdminAPIKey
+	if token != "" && key != "" && subtle.ConstantTimeCompare([]byte(token), []byte(key)) == 1 {
+		return "key:admin"
+	}
+
+	if apiKey := c.Request().Header.Get(headerAPIKey); apiKey != "" {
+		token = apiKey
+	}
+
+	if token != "" {
+		sum := sha256.Sum256([]byte(token))
+		return "key:" + hex.EncodeToString(sum[:16])
+	}
+
+	return "ip:" + c.RealIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// requestValidationMiddleware rejects requests whose parameters or body do
+// not match their operation in the OpenAPI document.
+func (s *echoServer) requestValidationMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			params := make(map[string]string, len(c.ParamNames()))
+			for i, name := range c.ParamNames() {
+				params[name] = c.ParamValues()[i]
+			}
+
+			if err := s.validator.Validate(c.Request().Context(), c.Request(), c.Path(), params); err != nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package rest
+
+import (
+	"net/http"
+	"order-service/internal/adapter/restapi/openapi"
+
+	echo "github.com/labstack/echo/v4"
+)
+
+// openAPIDocument serves the OpenAPI document of the API.
+func (s *echoServer) openAPIDocument(c echo.Context) error {
+	return c.JSONBlob(http.StatusOK, s.spec)
+}
+
+// apiDocs serves a page rendering the OpenAPI document.
+func (s *echoServer) apiDocs(c echo.Context) error {
+	return c.HTMLBlob(http.StatusOK, openapi.DocsPage)
+}
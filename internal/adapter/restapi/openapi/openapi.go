@@ -0,0 +1,38 @@
+// Package openapi holds the OpenAPI document of the REST API, the page
+// rendering it, and the validation of requests against it.
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+var (
+	//go:embed openapi.yaml
+	document []byte
+
+	// DocsPage renders the document served at /openapi.json.
+	//
+	//go:embed docs.html
+	DocsPage []byte
+)
+
+// Load parses the embedded document and checks that it is valid OpenAPI 3.
+func Load(ctx context.Context) (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	loader.Context = ctx
+
+	spec, err := loader.LoadFromData(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse openapi document: %w", err)
+	}
+
+	if err := spec.Validate(ctx); err != nil {
+		return nil, fmt.Errorf("invalid openapi document: %w", err)
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,117 @@
+package openapi_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"order-service/internal/adapter/restapi/openapi"
+	"order-service/internal/shared/exception"
+
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validOrder = `{
+	"shipping_address": {
+		"recipient_name": "Budi",
+		"phone": "081234567890",
+		"street": "Jl. Sudirman 1",
+		"postal_code": "10110",
+		"district_id": 1
+	},
+	"items": [{"product_id": "101", "quantity": 2}]
+}`
+
+func newValidator(t *testing.T) *openapi.Validator {
+	spec, err := openapi.Load(context.Background())
+	require.NoError(t, err)
+
+	return openapi.NewValidator(spec)
+}
+
+func newRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	return req
+}
+
+func fieldErrors(t *testing.T, err error) exception.FieldErrors {
+	ex, ok := exception.GetException(err)
+	require.True(t, ok, "expected an exception, got %v", err)
+	assert.Equal(t, exception.TypeValidationError, ex.Type)
+
+	return ex.Errors
+}
+
+func TestPath(t *testing.T) {
+	assert.Equal(t, "/api/v1/orders", openapi.Path("/api/v1/orders"))
+	assert.Equal(t, "/api/v1/orders/{id}/items/{itemId}/cancel", openapi.Path("/api/v1/orders/:id/items/:itemId/cancel"))
+}
+
+func TestValidator_ValidRequestKeepsBody(t *testing.T) {
+	v := newValidator(t)
+	req := newRequest(http.MethodPost, "/api/v1/orders", validOrder)
+
+	require.NoError(t, v.Validate(context.Background(), req, "/api/v1/orders", nil))
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, validOrder, string(body))
+}
+
+func TestValidator_InvalidBody(t *testing.T) {
+	v := newValidator(t)
+	req := newRequest(http.MethodPost, "/api/v1/orders", `{
+		"shipping_address": {"recipient_name": "Budi", "phone": "081234567890", "street": "Jl. Sudirman 1", "postal_code": "10110", "district_id": 1},
+		"items": [{"product_id": "101", "quantity": 0}],
+		"currency": "idr"
+	}`)
+
+	errs := fieldErrors(t, v.Validate(context.Background(), req, "/api/v1/orders", nil))
+	assert.Contains(t, errs, "items.0.quantity")
+	assert.Contains(t, errs, "currency")
+}
+
+func TestValidator_MissingBody(t *testing.T) {
+	v := newValidator(t)
+	req := newRequest(http.MethodPost, "/api/v1/orders", "")
+
+	errs := fieldErrors(t, v.Validate(context.Background(), req, "/api/v1/orders", nil))
+	assert.Contains(t, errs, "body")
+}
+
+func TestValidator_InvalidParameters(t *testing.T) {
+	v := newValidator(t)
+	req := newRequest(http.MethodGet, "/api/v1/admin/db/queries?sort=slowest&limit=-1", "")
+
+	errs := fieldErrors(t, v.Validate(context.Background(), req, "/api/v1/admin/db/queries", nil))
+	assert.Contains(t, errs, "sort")
+	assert.Contains(t, errs, "limit")
+
+	req = newRequest(http.MethodGet, "/api/v1/orders/abc", "")
+
+	errs = fieldErrors(t, v.Validate(context.Background(), req, "/api/v1/orders/:id", map[string]string{"id": "abc"}))
+	assert.Contains(t, errs, "id")
+}
+
+func TestValidator_UndeclaredRoute(t *testing.T) {
+	v := newValidator(t)
+	req := newRequest(http.MethodGet, "/docs", "")
+
+	assert.NoError(t, v.Validate(context.Background(), req, "/docs", nil))
+}
+
+func TestValidator_BodyLimit(t *testing.T) {
+	v := newValidator(t)
+	req := newRequest(http.MethodPost, "/api/v1/orders", validOrder)
+	req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 4)
+
+	var maxBytesErr *http.MaxBytesError
+	assert.ErrorAs(t, v.Validate(context.Background(), req, "/api/v1/orders", nil), &maxBytesErr)
+}
@@ -0,0 +1,132 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"order-service/internal/shared/exception"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// Validator checks requests against the parameters and request bodies the
+// document declares for their operation.
+type Validator struct {
+	spec    *openapi3.T
+	options *openapi3filter.Options
+}
+
+func NewValidator(spec *openapi3.T) *Validator {
+	return &Validator{
+		spec: spec,
+		options: &openapi3filter.Options{
+			MultiError: true,
+			// Credentials are checked by the routes' middlewares.
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			// Setting defaults would re-encode the body, which must reach
+			// the handlers as sent, as signatures are computed over it.
+			SkipSettingDefaults: true,
+		},
+	}
+}
+
+// Validate checks req, routed to the Echo path pattern route with the path
+// params, against its operation. Requests to routes the document does not
+// declare pass. The violations are returned as the field errors of a
+// validation exception, keyed by parameter name or by the dotted path of
+// the body field.
+func (v *Validator) Validate(ctx context.Context, req *http.Request, route string, params map[string]string) error {
+	path := Path(route)
+
+	pathItem := v.spec.Paths.Value(path)
+	if pathItem == nil {
+		return nil
+	}
+
+	operation := pathItem.GetOperation(req.Method)
+	if operation == nil {
+		return nil
+	}
+
+	err := openapi3filter.ValidateRequest(ctx, &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: params,
+		Route: &routers.Route{
+			Spec:      v.spec,
+			Path:      path,
+			PathItem:  pathItem,
+			Method:    req.Method,
+			Operation: operation,
+		},
+		Options: v.options,
+	})
+	if err == nil {
+		return nil
+	}
+
+	// A body over the body limit is reported as such by the error handler
+	// rather than as a violation.
+	if errors.As(err, new(*http.MaxBytesError)) {
+		return err
+	}
+
+	fieldErrors := make(exception.FieldErrors)
+	collectFieldErrors(fieldErrors, "", err)
+
+	return exception.NewWithErrors(exception.TypeValidationError, exception.CodeValidationFailed, "request does not match the api specification", fieldErrors)
+}
+
+// Path converts an Echo path pattern, such as /orders/:id, to an OpenAPI
+// path template, such as /orders/{id}.
+func Path(route string) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func collectFieldErrors(fieldErrors exception.FieldErrors, field string, err error) {
+	switch err := err.(type) {
+	case openapi3.MultiError:
+		for _, err := range err {
+			collectFieldErrors(fieldErrors, field, err)
+		}
+	case *openapi3filter.RequestError:
+		if err.Parameter != nil {
+			field = err.Parameter.Name
+		}
+
+		if err.Err == nil {
+			addFieldError(fieldErrors, field, err.Reason)
+		} else {
+			collectFieldErrors(fieldErrors, field, err.Err)
+		}
+	case *openapi3.SchemaError:
+		if pointer := err.JSONPointer(); len(pointer) > 0 {
+			if field != "" {
+				pointer = append([]string{field}, pointer...)
+			}
+
+			field = strings.Join(pointer, ".")
+		}
+
+		addFieldError(fieldErrors, field, err.Reason)
+	default:
+		addFieldError(fieldErrors, field, err.Error())
+	}
+}
+
+func addFieldError(fieldErrors exception.FieldErrors, field, message string) {
+	if field == "" {
+		field = "body"
+	}
+
+	fieldErrors[field] = append(fieldErrors[field], message)
+}
@@ -1,14 +1,66 @@
 package rest
 
 func (s *echoServer) setupRouter() {
+	s.echo.GET("/openapi.json", s.openAPIDocument)
+	s.echo.GET("/docs", s.apiDocs)
+
 	apiV1 := s.echo.Group("/api/v1")
 	{
 		orderGroup := apiV1.Group("/orders")
 		{
 			orderGroup.POST("", s.handler.Order().Create)
 			orderGroup.GET("", s.handler.Order().List)
+			orderGroup.GET("/stream", s.handler.Order().Stream)
 			orderGroup.GET("/:id", s.handler.Order().Get)
+			orderGroup.PATCH("/:id", s.handler.Order().Update)
 			orderGroup.POST("/:id/cancel", s.handler.Order().Cancel)
+			orderGroup.POST("/:id/items/:itemId/cancel", s.handler.Order().CancelItem)
+			orderGroup.POST("/:id/payments", s.handler.Payment().Start)
+			orderGroup.POST("/:id/returns", s.handler.Return().Create)
+		}
+
+		// Authenticated by the provider's signature rather than an API key.
+		apiV1.POST("/payments/webhook", s.handler.Payment().Webhook)
+
+		adminGroup := apiV1.Group("/admin", s.adminAuthMiddleware())
+		{
+			couponGroup := adminGroup.Group("/coupons")
+			{
+				couponGroup.POST("", s.handler.Coupon().Create)
+				couponGroup.GET("", s.handler.Coupon().List)
+				couponGroup.GET("/:id", s.handler.Coupon().Get)
+				couponGroup.PUT("/:id", s.handler.Coupon().Update)
+				couponGroup.DELETE("/:id", s.handler.Coupon().Delete)
+			}
+
+			adminGroup.GET("/orders", s.handler.Order().AdminList)
+			adminGroup.DELETE("/orders/:id", s.handler.Order().Delete)
+			adminGroup.POST("/orders/:id/restore", s.handler.Order().Restore)
+			adminGroup.POST("/orders/:id/deliver", s.handler.Order().Deliver)
+			adminGroup.POST("/orders/:id/refunds", s.handler.Refund().Create)
+			adminGroup.POST("/refunds/:id/retry", s.handler.Refund().Retry)
+
+			returnGroup := adminGroup.Group("/returns")
+			{
+				returnGroup.POST("/:id/approve", s.handler.Return().Approve)
+				returnGroup.POST("/:id/reject", s.handler.Return().Reject)
+				returnGroup.POST("/:id/receive", s.handler.Return().Receive)
+			}
+
+			webhookGroup := adminGroup.Group("/webhooks")
+			{
+				webhookGroup.POST("", s.handler.Webhook().Create)
+				webhookGroup.GET("", s.handler.Webhook().List)
+				webhookGroup.GET("/:id", s.handler.Webhook().Get)
+				webhookGroup.PUT("/:id", s.handler.Webhook().Update)
+				webhookGroup.DELETE("/:id", s.handler.Webhook().Delete)
+				webhookGroup.GET("/:id/deliveries", s.handler.Webhook().Deliveries)
+			}
+
+			adminGroup.GET("/audit-logs", s.handler.Audit().List)
+			adminGroup.GET("/db/stats", s.handler.Database().Stats)
+			adminGroup.GET("/db/queries", s.handler.Database().Queries)
+			adminGroup.DELETE("/db/queries", s.handler.Database().ResetQueries)
 		}
 	}
 }
@@ -0,0 +1,267 @@
+package rest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"order-service/config"
+	rest "order-service/internal/adapter/restapi"
+	"order-service/internal/adapter/restapi/openapi"
+	"order-service/mocks"
+	"order-service/pkg/logger"
+
+	echo "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, configure ...func(*config.Config)) *echo.Echo {
+	mRepo := mocks.NewMockRepository(t)
+	mPostgres := mocks.NewMockPostgresRepository(t)
+
+	mRepo.EXPECT().Postgres().Return(mPostgres).Maybe()
+	mPostgres.EXPECT().DB().Return(nil).Maybe()
+
+	cfg := &config.Config{
+		HTTP:      &config.HTTPConfig{MaxBodySize: 1 << 20},
+		Stream:    &config.StreamConfig{},
+		RateLimit: &config.RateLimitConfig{},
+	}
+	for _, fn := range configure {
+		fn(cfg)
+	}
+
+	server, err := rest.NewEchoServer(cfg, logger.NewZerologLogger(false), nil, mRepo)
+	require.NoError(t, err)
+
+	return server.Echo()
+}
+
+// TestRouter_MatchesOpenAPI checks that the OpenAPI document describes every
+// route of the API and no other.
+func TestRouter_MatchesOpenAPI(t *testing.T) {
+	e := newTestServer(t)
+
+	spec, err := openapi.Load(context.Background())
+	require.NoError(t, err)
+
+	var documented []string
+	for path, item := range spec.Paths.Map() {
+		for method := range item.Operations() {
+			documented = append(documented, method+" "+path)
+		}
+	}
+
+	var routed []string
+	for _, route := range e.Routes() {
+		// Groups with middlewares register catch-all routes of their own.
+		if route.Method == echo.RouteNotFound || route.Path == "/openapi.json" || route.Path == "/docs" {
+			continue
+		}
+
+		routed = append(routed, route.Method+" "+openapi.Path(route.Path))
+	}
+
+	assert.ElementsMatch(t, routed, documented)
+}
+
+func TestRouter_ServesOpenAPI(t *testing.T) {
+	e := newTestServer(t)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+	assert.Contains(t, rec.Body.String(), `"openapi":"3.0.3"`)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "openapi.json")
+}
+
+func TestRouter_ValidatesRequests(t *testing.T) {
+	e := newTestServer(t, func(cfg *config.Config) {
+		cfg.HTTP.ValidateRequests = true
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(`{"items": []}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"VALIDATION_FAILED"`)
+	assert.Contains(t, rec.Body.String(), `"items"`)
+}
+
+// TestRouter_RateLimits calls an admin route without a key, which is refused
+// past the rate limiter without reaching a handler.
+func TestRouter_RateLimits(t *testing.T) {
+	e := newTestServer(t, func(cfg *config.Config) {
+		cfg.RateLimit = &config.RateLimitConfig{
+			Enabled: true,
+			Default: config.RateLimitRule{Requests: 1, Period: time.Minute},
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/coupons", nil))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1;w=60", rec.Header().Get("RateLimit-Policy"))
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/coupons", nil))
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get(echo.HeaderRetryAfter))
+	assert.Contains(t, rec.Body.String(), `"code":"RATE_LIMIT_EXCEEDED"`)
+}
+
+// TestRouter_RateLimitsPerKey checks that clients sharing an IP get a bucket
+// per API key, and that a key's bucket is not spent by the others.
+func TestRouter_RateLimitsPerKey(t *testing.T) {
+	e := newTestServer(t, func(cfg *config.Config) {
+		cfg.RateLimit = &config.RateLimitConfig{
+			Enabled: true,
+			Default: config.RateLimitRule{Requests: 1, Period: time.Minute},
+		}
+	})
+
+	call := func(header, value string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/coupons", nil)
+		req.RemoteAddr = "203.0.113.7:4321"
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, call("X-API-Key", "key-a"))
+	assert.Equal(t, http.StatusForbidden, call("X-API-Key", "key-b"))
+	assert.Equal(t, http.StatusForbidden, call(echo.HeaderAuthorization, "Bearer key-c"))
+	assert.Equal(t, http.StatusForbidden, call("", ""))
+
+	assert.Equal(t, http.StatusTooManyRequests, call("X-API-Key", "key-a"))
+	assert.Equal(t, http.StatusTooManyRequests, call("", ""))
+}
+
+func TestRouter_RateLimitsOff(t *testing.T) {
+	e := newTestServer(t)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/coupons", nil))
+
+	assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+}
+
+func TestRouter_RejectsLargeBodies(t *testing.T) {
+	e := newTestServer(t, func(cfg *config.Config) {
+		cfg.HTTP.MaxBodySize = 16
+	})
+
+	body := `{"items": [{"product_id": "101", "quantity": 1}]}`
+
+	tests := []struct {
+		name string
+		body io.Reader
+	}{
+		// The declared length is refused before the body is read.
+		{name: "declared length", body: strings.NewReader(body)},
+		// A body of unknown length is cut off while it is bound.
+		{name: "unknown length", body: iotest.OneByteReader(strings.NewReader(body))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", tt.body)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"code":"PAYLOAD_TOO_LARGE"`)
+		})
+	}
+}
+
+func TestRouter_RejectsUnsupportedMediaType(t *testing.T) {
+	e := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader("items=1"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMETextPlain)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"UNSUPPORTED_MEDIA_TYPE"`)
+}
+
+func TestRouter_SecurityHeaders(t *testing.T) {
+	e := newTestServer(t, func(cfg *config.Config) {
+		cfg.HTTP.FrameOptions = "DENY"
+		cfg.HTTP.HSTSMaxAge = 24 * time.Hour
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/coupons", nil)
+	req.Header.Set(echo.HeaderXForwardedProto, "https")
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, "nosniff", rec.Header().Get(echo.HeaderXContentTypeOptions))
+	assert.Equal(t, "DENY", rec.Header().Get(echo.HeaderXFrameOptions))
+	assert.Equal(t, "max-age=86400; includeSubdomains", rec.Header().Get(echo.HeaderStrictTransportSecurity))
+
+	// HSTS is only sent over HTTPS.
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/coupons", nil))
+
+	assert.Empty(t, rec.Header().Get(echo.HeaderStrictTransportSecurity))
+}
+
+func TestRouter_CORS(t *testing.T) {
+	e := newTestServer(t, func(cfg *config.Config) {
+		cfg.HTTP.AllowedOrigins = []string{"https://shop.example.com"}
+		cfg.HTTP.AllowedMethods = []string{http.MethodGet, http.MethodPost}
+		cfg.HTTP.AllowedHeaders = []string{echo.HeaderContentType}
+	})
+
+	preflight := func(origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/orders", nil)
+		req.Header.Set(echo.HeaderOrigin, origin)
+		req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodPost)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	rec := preflight("https://shop.example.com")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://shop.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "GET,POST", rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+	assert.Equal(t, echo.HeaderContentType, rec.Header().Get(echo.HeaderAccessControlAllowHeaders))
+
+	rec = preflight("https://evil.example.com")
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+}
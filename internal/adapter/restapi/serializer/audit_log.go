@@ -0,0 +1,59 @@
+package serializer
+
+import (
+	"encoding/json"
+	"order-service/internal/domain/entity"
+	"time"
+)
+
+type AuditLogResponse struct {
+	ID         uint64          `json:"id"`
+	ActorType  string          `json:"actor_type"`
+	ActorID    string          `json:"actor_id"`
+	RequestID  string          `json:"request_id"`
+	IP         string          `json:"ip"`
+	UserAgent  string          `json:"user_agent"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uint32          `json:"entity_id"`
+	Changes    json.RawMessage `json:"changes"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func SerializeAuditLog(arg *entity.AuditLog) *AuditLogResponse {
+	if arg == nil {
+		return nil
+	}
+
+	return &AuditLogResponse{
+		ID:         arg.ID,
+		ActorType:  arg.ActorType,
+		ActorID:    arg.ActorID,
+		RequestID:  arg.RequestID,
+		IP:         arg.IP,
+		UserAgent:  arg.UserAgent,
+		Action:     arg.Action,
+		EntityType: arg.EntityType,
+		EntityID:   arg.EntityID,
+		Changes:    arg.Changes,
+		CreatedAt:  arg.CreatedAt,
+	}
+}
+
+func SerializeAuditLogs(arg []*entity.AuditLog) []*AuditLogResponse {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*AuditLogResponse, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, SerializeAuditLog(arg[i]))
+	}
+
+	return res
+}
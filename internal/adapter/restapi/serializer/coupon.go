@@ -0,0 +1,77 @@
+package serializer
+
+import (
+	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
+	"time"
+)
+
+type CouponResponse struct {
+	ID                 uint32        `json:"id"`
+	Code               string        `json:"code"`
+	Name               string        `json:"name"`
+	Description        string        `json:"description"`
+	Type               string        `json:"type"`
+	PercentOff         money.Percent `json:"percent_off"`
+	AmountOff          money.Money   `json:"amount_off"`
+	MaxDiscount        money.Money   `json:"max_discount"`
+	MinSpend           money.Money   `json:"min_spend"`
+	FreeProductID      string        `json:"free_product_id"`
+	FreeQuantity       int           `json:"free_quantity"`
+	StartsAt           *time.Time    `json:"starts_at"`
+	EndsAt             *time.Time    `json:"ends_at"`
+	UsageLimit         int           `json:"usage_limit"`
+	UsageLimitPerUser  int           `json:"usage_limit_per_user"`
+	UsedCount          int           `json:"used_count"`
+	IsActive           bool          `json:"is_active"`
+	EligibleProductIDs []string      `json:"eligible_product_ids"`
+	CreatedAt          time.Time     `json:"created_at"`
+	UpdatedAt          time.Time     `json:"updated_at"`
+}
+
+func SerializeCoupon(arg *entity.Coupon) *CouponResponse {
+	if arg == nil {
+		return nil
+	}
+
+	return &CouponResponse{
+		ID:                 arg.ID,
+		Code:               arg.Code,
+		Name:               arg.Name,
+		Description:        arg.Description,
+		Type:               arg.Type,
+		PercentOff:         arg.PercentOff,
+		AmountOff:          arg.AmountOff,
+		MaxDiscount:        arg.MaxDiscount,
+		MinSpend:           arg.MinSpend,
+		FreeProductID:      arg.FreeProductID,
+		FreeQuantity:       arg.FreeQuantity,
+		StartsAt:           arg.StartsAt,
+		EndsAt:             arg.EndsAt,
+		UsageLimit:         arg.UsageLimit,
+		UsageLimitPerUser:  arg.UsageLimitPerUser,
+		UsedCount:          arg.UsedCount,
+		IsActive:           arg.IsActive,
+		EligibleProductIDs: arg.EligibleProductIDs,
+		CreatedAt:          arg.CreatedAt,
+		UpdatedAt:          arg.UpdatedAt,
+	}
+}
+
+func SerializeCoupons(arg []*entity.Coupon) []*CouponResponse {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*CouponResponse, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, SerializeCoupon(arg[i]))
+	}
+
+	return res
+}
@@ -2,17 +2,48 @@ package serializer
 
 import (
 	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
 	"time"
 )
 
 type OrderResponse struct {
-	ID         uint32               `json:"id"`
-	UserID     uint32               `json:"user_id"`
-	Status     string               `json:"status"`
-	TotalPrice float64              `json:"total_price"`
-	Items      []*OrderItemResponse `json:"items"`
-	CreatedAt  time.Time            `json:"created_at"`
-	UpdatedAt  time.Time            `json:"updated_at"`
+	ID              uint32                     `json:"id"`
+	UserID          uint32                     `json:"user_id"`
+	Status          string                     `json:"status"`
+	Currency        string                     `json:"currency"`
+	Subtotal        money.Money                `json:"subtotal"`
+	DiscountTotal   money.Money                `json:"discount_total"`
+	ShippingTotal   money.Money                `json:"shipping_total"`
+	TaxTotal        money.Money                `json:"tax_total"`
+	TaxRegion       string                     `json:"tax_region"`
+	TaxInclusive    bool                       `json:"tax_inclusive"`
+	GrandTotal      money.Money                `json:"grand_total"`
+	RefundedTotal   money.Money                `json:"refunded_total"`
+	TotalPrice      money.Money                `json:"total_price"`
+	BaseCurrency    string                     `json:"base_currency"`
+	BaseTotalPrice  money.Money                `json:"base_total_price"`
+	FXRate          *FXRateResponse            `json:"fx_rate"`
+	DeliveredAt     *time.Time                 `json:"delivered_at"`
+	ShippingAddress *ShippingAddressResponse   `json:"shipping_address"`
+	Items           []*OrderItemResponse       `json:"items"`
+	Adjustments     []*OrderAdjustmentResponse `json:"adjustments"`
+	Payments        []*PaymentResponse         `json:"payments"`
+	Refunds         []*RefundResponse          `json:"refunds"`
+	Returns         []*OrderReturnResponse     `json:"returns"`
+	CreatedAt       time.Time                  `json:"created_at"`
+	UpdatedAt       time.Time                  `json:"updated_at"`
+
+	CancellationReason string     `json:"cancellation_reason,omitempty"`
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty"`
+	DeletedAt          *time.Time `json:"deleted_at,omitempty"`
+}
+
+type FXRateResponse struct {
+	From   string     `json:"from"`
+	To     string     `json:"to"`
+	Rate   money.Rate `json:"rate"`
+	Source string     `json:"source"`
+	AsOf   time.Time  `json:"as_of"`
 }
 
 func SerializeOrder(arg *entity.Order) *OrderResponse {
@@ -21,13 +52,41 @@ func SerializeOrder(arg *entity.Order) *OrderResponse {
 	}
 
 	return &OrderResponse{
-		ID:         arg.ID,
-		UserID:     arg.UserID,
-		Status:     arg.Status,
-		TotalPrice: arg.TotalPrice,
-		Items:      SerializeOrderItems(arg.Items),
-		CreatedAt:  arg.CreatedAt,
-		UpdatedAt:  arg.UpdatedAt,
+		ID:             arg.ID,
+		UserID:         arg.UserID,
+		Status:         arg.Status,
+		Currency:       arg.Currency,
+		Subtotal:       arg.Subtotal,
+		DiscountTotal:  arg.DiscountTotal,
+		ShippingTotal:  arg.ShippingTotal,
+		TaxTotal:       arg.TaxTotal,
+		TaxRegion:      arg.TaxRegion,
+		TaxInclusive:   arg.TaxInclusive,
+		GrandTotal:     arg.TotalPrice,
+		RefundedTotal:  refundedTotal(arg.Refunds),
+		TotalPrice:     arg.TotalPrice,
+		BaseCurrency:   arg.BaseCurrency,
+		BaseTotalPrice: arg.BaseTotalPrice,
+		FXRate: &FXRateResponse{
+			From:   arg.BaseCurrency,
+			To:     arg.Currency,
+			Rate:   arg.FXRate,
+			Source: arg.FXRateSource,
+			AsOf:   arg.FXRateAt,
+		},
+		DeliveredAt:     arg.DeliveredAt,
+		ShippingAddress: SerializeShippingAddress(arg.ShippingAddress),
+		Items:           SerializeOrderItems(arg.Items),
+		Adjustments:     SerializeOrderAdjustments(arg.Adjustments),
+		Payments:        SerializePayments(arg.Payments),
+		Refunds:         SerializeRefunds(arg.Refunds),
+		Returns:         SerializeOrderReturns(arg.Returns),
+		CreatedAt:       arg.CreatedAt,
+		UpdatedAt:       arg.UpdatedAt,
+
+		CancellationReason: arg.CancellationReason,
+		CancelledAt:        arg.CancelledAt,
+		DeletedAt:          arg.DeletedAt,
 	}
 }
 
@@ -48,3 +107,23 @@ func SerializeOrders(arg []*entity.Order) []*OrderResponse {
 
 	return res
 }
+
+// OrderStatusEventResponse is the data of an order status event on the
+// order stream.
+type OrderStatusEventResponse struct {
+	OrderID   uint32    `json:"order_id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func SerializeOrderStatusEvent(arg *entity.OrderStatusEvent) *OrderStatusEventResponse {
+	if arg == nil {
+		return nil
+	}
+
+	return &OrderStatusEventResponse{
+		OrderID:   arg.OrderID,
+		Status:    arg.Status,
+		CreatedAt: arg.CreatedAt,
+	}
+}
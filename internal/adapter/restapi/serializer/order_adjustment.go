@@ -0,0 +1,53 @@
+package serializer
+
+import (
+	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
+	"time"
+)
+
+type OrderAdjustmentResponse struct {
+	ID          uint32      `json:"id"`
+	OrderItemID *uint32     `json:"order_item_id"`
+	CouponID    *uint32     `json:"coupon_id"`
+	Type        string      `json:"type"`
+	Code        string      `json:"code"`
+	Description string      `json:"description"`
+	Amount      money.Money `json:"amount"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+func SerializeOrderAdjustment(arg *entity.OrderAdjustment) *OrderAdjustmentResponse {
+	if arg == nil {
+		return nil
+	}
+
+	return &OrderAdjustmentResponse{
+		ID:          arg.ID,
+		OrderItemID: arg.OrderItemID,
+		CouponID:    arg.CouponID,
+		Type:        arg.Type,
+		Code:        arg.Code,
+		Description: arg.Description,
+		Amount:      arg.Amount,
+		CreatedAt:   arg.CreatedAt,
+	}
+}
+
+func SerializeOrderAdjustments(arg []*entity.OrderAdjustment) []*OrderAdjustmentResponse {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*OrderAdjustmentResponse, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, SerializeOrderAdjustment(arg[i]))
+	}
+
+	return res
+}
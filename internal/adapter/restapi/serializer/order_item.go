@@ -2,17 +2,26 @@ package serializer
 
 import (
 	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
 	"time"
 )
 
 type OrderItemResponse struct {
-	ID        uint32    `json:"id"`
-	ProductID string    `json:"product_id"`
-	Quantity  int       `json:"quantity"`
-	Price     float64   `json:"price"`
-	Subtotal  float64   `json:"subtotal"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint32      `json:"id"`
+	ProductID string      `json:"product_id"`
+	Quantity  int         `json:"quantity"`
+	Price     money.Money `json:"price"`
+	BasePrice money.Money `json:"base_price"`
+	Subtotal  money.Money `json:"subtotal"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+
+	CancelledQuantity int `json:"cancelled_quantity"`
+
+	TaxClass      string        `json:"tax_class"`
+	TaxRate       money.Percent `json:"tax_rate"`
+	TaxableAmount money.Money   `json:"taxable_amount"`
+	TaxAmount     money.Money   `json:"tax_amount"`
 }
 
 func SerializeOrderItem(arg *entity.OrderItem) *OrderItemResponse {
@@ -25,9 +34,17 @@ func SerializeOrderItem(arg *entity.OrderItem) *OrderItemResponse {
 		ProductID: arg.ProductID,
 		Quantity:  arg.Quantity,
 		Price:     arg.Price,
+		BasePrice: arg.BasePrice,
 		Subtotal:  arg.Subtotal,
 		CreatedAt: arg.CreatedAt,
 		UpdatedAt: arg.UpdatedAt,
+
+		CancelledQuantity: arg.CancelledQuantity,
+
+		TaxClass:      arg.TaxClass,
+		TaxRate:       arg.TaxRate,
+		TaxableAmount: arg.TaxableAmount,
+		TaxAmount:     arg.TaxAmount,
 	}
 }
 
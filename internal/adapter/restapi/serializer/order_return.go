@@ -0,0 +1,67 @@
+package serializer
+
+import (
+	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
+	"time"
+)
+
+type OrderReturnResponse struct {
+	ID              uint32      `json:"id"`
+	OrderID         uint32      `json:"order_id"`
+	OrderItemID     uint32      `json:"order_item_id"`
+	Quantity        int         `json:"quantity"`
+	ReasonCode      string      `json:"reason_code"`
+	Note            string      `json:"note"`
+	Status          string      `json:"status"`
+	RejectionReason string      `json:"rejection_reason,omitempty"`
+	RefundID        *uint32     `json:"refund_id"`
+	RefundAmount    money.Money `json:"refund_amount"`
+	ApprovedAt      *time.Time  `json:"approved_at"`
+	RejectedAt      *time.Time  `json:"rejected_at"`
+	ReceivedAt      *time.Time  `json:"received_at"`
+	RestockedAt     *time.Time  `json:"restocked_at"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+func SerializeOrderReturn(arg *entity.OrderReturn) *OrderReturnResponse {
+	if arg == nil {
+		return nil
+	}
+
+	return &OrderReturnResponse{
+		ID:              arg.ID,
+		OrderID:         arg.OrderID,
+		OrderItemID:     arg.OrderItemID,
+		Quantity:        arg.Quantity,
+		ReasonCode:      arg.ReasonCode,
+		Note:            arg.Note,
+		Status:          arg.Status,
+		RejectionReason: arg.RejectionReason,
+		RefundID:        arg.RefundID,
+		RefundAmount:    arg.RefundAmount,
+		ApprovedAt:      arg.ApprovedAt,
+		RejectedAt:      arg.RejectedAt,
+		ReceivedAt:      arg.ReceivedAt,
+		RestockedAt:     arg.RestockedAt,
+		CreatedAt:       arg.CreatedAt,
+	}
+}
+
+func SerializeOrderReturns(arg []*entity.OrderReturn) []*OrderReturnResponse {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*OrderReturnResponse, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, SerializeOrderReturn(arg[i]))
+	}
+
+	return res
+}
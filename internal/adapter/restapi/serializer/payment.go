@@ -0,0 +1,61 @@
+package serializer
+
+import (
+	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
+	"time"
+)
+
+type PaymentResponse struct {
+	ID                uint32      `json:"id"`
+	Provider          string      `json:"provider"`
+	Reference         string      `json:"reference"`
+	ProviderReference string      `json:"provider_reference"`
+	Status            string      `json:"status"`
+	Amount            money.Money `json:"amount"`
+	Currency          string      `json:"currency"`
+	CheckoutURL       string      `json:"checkout_url"`
+	FailureReason     string      `json:"failure_reason,omitempty"`
+	PaidAt            *time.Time  `json:"paid_at"`
+	FailedAt          *time.Time  `json:"failed_at"`
+	CreatedAt         time.Time   `json:"created_at"`
+}
+
+func SerializePayment(arg *entity.Payment) *PaymentResponse {
+	if arg == nil {
+		return nil
+	}
+
+	return &PaymentResponse{
+		ID:                arg.ID,
+		Provider:          arg.Provider,
+		Reference:         arg.Reference,
+		ProviderReference: arg.ProviderReference,
+		Status:            arg.Status,
+		Amount:            arg.Amount,
+		Currency:          arg.Currency,
+		CheckoutURL:       arg.CheckoutURL,
+		FailureReason:     arg.FailureReason,
+		PaidAt:            arg.PaidAt,
+		FailedAt:          arg.FailedAt,
+		CreatedAt:         arg.CreatedAt,
+	}
+}
+
+func SerializePayments(arg []*entity.Payment) []*PaymentResponse {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*PaymentResponse, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, SerializePayment(arg[i]))
+	}
+
+	return res
+}
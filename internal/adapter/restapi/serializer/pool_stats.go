@@ -0,0 +1,39 @@
+package serializer
+
+import (
+	"order-service/pkg/bundb"
+)
+
+type PoolStatsResponse struct {
+	Name               string `json:"name"`
+	MaxOpenConnections int    `json:"max_open_connections"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"wait_count"`
+	WaitDurationMs     int64  `json:"wait_duration_ms"`
+	MaxIdleClosed      int64  `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64  `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64  `json:"max_lifetime_closed"`
+}
+
+func SerializePoolStats(arg []bundb.PoolStats) []*PoolStatsResponse {
+	res := make([]*PoolStatsResponse, 0, len(arg))
+
+	for _, stats := range arg {
+		res = append(res, &PoolStatsResponse{
+			Name:               stats.Name,
+			MaxOpenConnections: stats.MaxOpenConnections,
+			OpenConnections:    stats.OpenConnections,
+			InUse:              stats.InUse,
+			Idle:               stats.Idle,
+			WaitCount:          stats.WaitCount,
+			WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+			MaxIdleClosed:      stats.MaxIdleClosed,
+			MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+			MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,50 @@
+package serializer
+
+import (
+	"order-service/pkg/bundb/querystats"
+	"time"
+)
+
+type QueryStatsResponse struct {
+	Since   time.Time            `json:"since"`
+	Dropped int64                `json:"dropped"`
+	Queries []*QueryStatResponse `json:"queries"`
+}
+
+type QueryStatResponse struct {
+	Fingerprint string    `json:"fingerprint"`
+	Count       int64     `json:"count"`
+	Errors      int64     `json:"errors"`
+	TotalMs     float64   `json:"total_ms"`
+	P50Ms       float64   `json:"p50_ms"`
+	P99Ms       float64   `json:"p99_ms"`
+	MaxMs       float64   `json:"max_ms"`
+	LastSeen    time.Time `json:"last_seen"`
+}
+
+func SerializeQueryStats(arg querystats.Report) *QueryStatsResponse {
+	res := &QueryStatsResponse{
+		Since:   arg.Since,
+		Dropped: arg.Dropped,
+		Queries: make([]*QueryStatResponse, 0, len(arg.Queries)),
+	}
+
+	for _, stat := range arg.Queries {
+		res.Queries = append(res.Queries, &QueryStatResponse{
+			Fingerprint: stat.Fingerprint,
+			Count:       stat.Count,
+			Errors:      stat.Errors,
+			TotalMs:     milliseconds(stat.Total),
+			P50Ms:       milliseconds(stat.P50),
+			P99Ms:       milliseconds(stat.P99),
+			MaxMs:       milliseconds(stat.Max),
+			LastSeen:    stat.LastSeen,
+		})
+	}
+
+	return res
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,94 @@
+package serializer
+
+import (
+	"order-service/constant"
+	"order-service/internal/domain/entity"
+	"order-service/pkg/money"
+	"time"
+)
+
+type RefundResponse struct {
+	ID                uint32                `json:"id"`
+	PaymentID         uint32                `json:"payment_id"`
+	Reference         string                `json:"reference"`
+	ProviderReference string                `json:"provider_reference"`
+	Status            string                `json:"status"`
+	Amount            money.Money           `json:"amount"`
+	Currency          string                `json:"currency"`
+	Reason            string                `json:"reason"`
+	Attempts          int                   `json:"attempts"`
+	LastError         string                `json:"last_error,omitempty"`
+	Items             []*RefundItemResponse `json:"items"`
+	RefundedAt        *time.Time            `json:"refunded_at"`
+	FailedAt          *time.Time            `json:"failed_at"`
+	CreatedAt         time.Time             `json:"created_at"`
+}
+
+type RefundItemResponse struct {
+	OrderItemID uint32      `json:"order_item_id"`
+	Quantity    int         `json:"quantity"`
+	Amount      money.Money `json:"amount"`
+}
+
+func SerializeRefund(arg *entity.Refund) *RefundResponse {
+	if arg == nil {
+		return nil
+	}
+
+	items := make([]*RefundItemResponse, 0, len(arg.Items))
+	for _, item := range arg.Items {
+		items = append(items, &RefundItemResponse{
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+			Amount:      item.Amount,
+		})
+	}
+
+	return &RefundResponse{
+		ID:                arg.ID,
+		PaymentID:         arg.PaymentID,
+		Reference:         arg.Reference,
+		ProviderReference: arg.ProviderReference,
+		Status:            arg.Status,
+		Amount:            arg.Amount,
+		Currency:          arg.Currency,
+		Reason:            arg.Reason,
+		Attempts:          arg.Attempts,
+		LastError:         arg.LastError,
+		Items:             items,
+		RefundedAt:        arg.RefundedAt,
+		FailedAt:          arg.FailedAt,
+		CreatedAt:         arg.CreatedAt,
+	}
+}
+
+func SerializeRefunds(arg []*entity.Refund) []*RefundResponse {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*RefundResponse, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, SerializeRefund(arg[i]))
+	}
+
+	return res
+}
+
+// refundedTotal sums the refunds that have reached the customer.
+func refundedTotal(refunds []*entity.Refund) money.Money {
+	var total money.Money
+
+	for _, refund := range refunds {
+		if refund.Status == string(constant.RefundStatusSucceeded) {
+			total = total.Add(refund.Amount)
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,48 @@
+package serializer
+
+import (
+	"order-service/internal/domain/entity"
+)
+
+type ShippingAddressResponse struct {
+	RecipientName string `json:"recipient_name"`
+	Phone         string `json:"phone"`
+	Street        string `json:"street"`
+	PostalCode    string `json:"postal_code"`
+	DistrictID    uint32 `json:"district_id"`
+	District      string `json:"district"`
+	CityID        uint32 `json:"city_id"`
+	City          string `json:"city"`
+	ProvinceID    uint32 `json:"province_id"`
+	Province      string `json:"province"`
+}
+
+func SerializeShippingAddress(arg *entity.ShippingAddress) *ShippingAddressResponse {
+	if arg == nil {
+		return nil
+	}
+
+	res := &ShippingAddressResponse{
+		RecipientName: arg.RecipientName,
+		Phone:         arg.Phone,
+		Street:        arg.Street,
+		PostalCode:    arg.PostalCode,
+		DistrictID:    arg.DistrictID,
+	}
+
+	if d := arg.District; d != nil {
+		res.District = d.Name
+
+		if c := d.City; c != nil {
+			res.CityID = c.ID
+			res.City = c.Name
+
+			if p := c.Province; p != nil {
+				res.ProvinceID = p.ID
+				res.Province = p.Name
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,115 @@
+package serializer
+
+import (
+	"encoding/json"
+	"order-service/internal/domain/entity"
+	"time"
+)
+
+// WebhookSubscriptionResponse leaves out the signing secret, which is only
+// ever set by the caller.
+type WebhookSubscriptionResponse struct {
+	ID                  uint32     `json:"id"`
+	URL                 string     `json:"url"`
+	EventTypes          []string   `json:"event_types"`
+	IsActive            bool       `json:"is_active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at"`
+	DisabledReason      string     `json:"disabled_reason"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+func SerializeWebhookSubscription(arg *entity.WebhookSubscription) *WebhookSubscriptionResponse {
+	if arg == nil {
+		return nil
+	}
+
+	return &WebhookSubscriptionResponse{
+		ID:                  arg.ID,
+		URL:                 arg.URL,
+		EventTypes:          arg.EventTypes,
+		IsActive:            arg.IsActive,
+		ConsecutiveFailures: arg.ConsecutiveFailures,
+		DisabledAt:          arg.DisabledAt,
+		DisabledReason:      arg.DisabledReason,
+		CreatedAt:           arg.CreatedAt,
+		UpdatedAt:           arg.UpdatedAt,
+	}
+}
+
+func SerializeWebhookSubscriptions(arg []*entity.WebhookSubscription) []*WebhookSubscriptionResponse {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*WebhookSubscriptionResponse, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, SerializeWebhookSubscription(arg[i]))
+	}
+
+	return res
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uint32          `json:"id"`
+	SubscriptionID uint32          `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status"`
+	ResponseBody   string          `json:"response_body"`
+	LastError      string          `json:"last_error"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	FailedAt       *time.Time      `json:"failed_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+func SerializeWebhookDelivery(arg *entity.WebhookDelivery) *WebhookDeliveryResponse {
+	if arg == nil {
+		return nil
+	}
+
+	return &WebhookDeliveryResponse{
+		ID:             arg.ID,
+		SubscriptionID: arg.SubscriptionID,
+		EventID:        arg.EventID,
+		EventType:      arg.EventType,
+		Payload:        arg.Payload,
+		Status:         arg.Status,
+		Attempts:       arg.Attempts,
+		ResponseStatus: arg.ResponseStatus,
+		ResponseBody:   arg.ResponseBody,
+		LastError:      arg.LastError,
+		DeliveredAt:    arg.DeliveredAt,
+		FailedAt:       arg.FailedAt,
+		CreatedAt:      arg.CreatedAt,
+		UpdatedAt:      arg.UpdatedAt,
+	}
+}
+
+func SerializeWebhookDeliveries(arg []*entity.WebhookDelivery) []*WebhookDeliveryResponse {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	res := make([]*WebhookDeliveryResponse, 0, len(arg))
+
+	for i := range arg {
+		if arg[i] == nil {
+			continue
+		}
+
+		res = append(res, SerializeWebhookDelivery(arg[i]))
+	}
+
+	return res
+}
@@ -0,0 +1,63 @@
+package shipping
+
+import (
+	"context"
+	"fmt"
+	"order-service/config"
+	"order-service/pkg/money"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	CalculatorFree  = "free"
+	CalculatorTable = "table"
+)
+
+var ErrNoRoute = errors.New("no shipping rate for destination")
+
+// FeeCalculator prices delivery of a parcel to a district. Fees are in the
+// base currency.
+type FeeCalculator interface {
+	Calculate(ctx context.Context, req *Request) (*Fee, error)
+}
+
+type Request struct {
+	Destination Destination
+	Items       []Item
+}
+
+// Destination is a district together with the city and province it belongs
+// to, so rates can be defined at any of the three levels.
+type Destination struct {
+	ProvinceID uint32
+	CityID     uint32
+	DistrictID uint32
+}
+
+type Item struct {
+	ProductID string
+	Quantity  int
+}
+
+type Fee struct {
+	Amount      money.Money
+	WeightGrams int
+	Description string
+}
+
+func NewFeeCalculator(cfg *config.Config) (FeeCalculator, error) {
+	switch cfg.Shipping.Calculator {
+	case "", CalculatorFree:
+		return NewTableCalculator(Table{}), nil
+	case CalculatorTable:
+		table, err := LoadTable(cfg.Shipping.RatesFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewTableCalculator(table), nil
+	default:
+		return nil, fmt.Errorf("unknown shipping calculator %q", cfg.Shipping.Calculator)
+	}
+}
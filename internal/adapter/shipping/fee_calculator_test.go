@@ -0,0 +1,76 @@
+package shipping_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"order-service/internal/adapter/shipping"
+	"order-service/pkg/money"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableCalculator_MostSpecificRateWins(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shipping.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"default_weight_grams": 1000,
+		"product_weights": {"101": 250},
+		"default_rate": {"base_fee": "20000", "per_kg_fee": "8000"},
+		"rates": [
+			{"province_id": 31, "base_fee": "9000", "per_kg_fee": "3000"},
+			{"city_id": 3171, "base_fee": "8000", "per_kg_fee": "2500"},
+			{"district_id": 3171010, "base_fee": "7000", "per_kg_fee": "2000"}
+		]
+	}`), 0o600))
+
+	table, err := shipping.LoadTable(path)
+	require.NoError(t, err)
+
+	c := shipping.NewTableCalculator(table)
+	ctx := context.Background()
+	items := []shipping.Item{{ProductID: "101", Quantity: 2}, {ProductID: "102", Quantity: 1}}
+
+	tests := []struct {
+		name string
+		dest shipping.Destination
+		want money.Money
+	}{
+		{name: "district", dest: shipping.Destination{ProvinceID: 31, CityID: 3171, DistrictID: 3171010}, want: money.FromInt(9000)},
+		{name: "city", dest: shipping.Destination{ProvinceID: 31, CityID: 3171, DistrictID: 3171020}, want: money.FromInt(10500)},
+		{name: "province", dest: shipping.Destination{ProvinceID: 31, CityID: 3172, DistrictID: 3172010}, want: money.FromInt(12000)},
+		{name: "default", dest: shipping.Destination{ProvinceID: 32, CityID: 3201, DistrictID: 3201010}, want: money.FromInt(28000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fee, err := c.Calculate(ctx, &shipping.Request{Destination: tt.dest, Items: items})
+			require.NoError(t, err)
+
+			// 2 x 250g + 1000g = 1.5 kg, charged as 2 kg.
+			assert.Equal(t, 1500, fee.WeightGrams)
+			assert.Equal(t, tt.want, fee.Amount)
+		})
+	}
+}
+
+func TestTableCalculator_NoRoute(t *testing.T) {
+	c := shipping.NewTableCalculator(shipping.Table{
+		Rates: []shipping.TableRate{{ProvinceID: 31, BaseFee: money.FromInt(9000)}},
+	})
+
+	_, err := c.Calculate(context.Background(), &shipping.Request{
+		Destination: shipping.Destination{ProvinceID: 32, CityID: 3201, DistrictID: 3201010},
+	})
+
+	assert.ErrorIs(t, err, shipping.ErrNoRoute)
+}
+
+func TestTableCalculator_EmptyTableIsFree(t *testing.T) {
+	fee, err := shipping.NewTableCalculator(shipping.Table{}).Calculate(context.Background(), &shipping.Request{})
+
+	require.NoError(t, err)
+	assert.True(t, fee.Amount.IsZero())
+}
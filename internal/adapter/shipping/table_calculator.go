@@ -0,0 +1,158 @@
+package shipping
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"order-service/pkg/money"
+	"os"
+
+	"github.com/cockroachdb/errors"
+)
+
+var _ FeeCalculator = (*TableCalculator)(nil)
+
+const gramsPerKg = 1000
+
+// Table holds weight-based rates loaded from a JSON file such as:
+//
+//	{
+//	  "default_weight_grams": 1000,
+//	  "product_weights": {"101": 250},
+//	  "default_rate": {"base_fee": "15000", "per_kg_fee": "5000"},
+//	  "rates": [
+//	    {"province_id": 31, "base_fee": "9000", "per_kg_fee": "3000"},
+//	    {"district_id": 3171010, "base_fee": "7000", "per_kg_fee": "2000"}
+//	  ]
+//	}
+//
+// The most specific rate wins: district, then city, then province, then
+// default_rate. The parcel weight is rounded up to whole kilograms and the
+// base fee covers the first one.
+type Table struct {
+	DefaultWeightGrams int            `json:"default_weight_grams"`
+	ProductWeights     map[string]int `json:"product_weights"`
+	DefaultRate        *TableRate     `json:"default_rate"`
+	Rates              []TableRate    `json:"rates"`
+}
+
+type TableRate struct {
+	ProvinceID uint32      `json:"province_id"`
+	CityID     uint32      `json:"city_id"`
+	DistrictID uint32      `json:"district_id"`
+	BaseFee    money.Money `json:"base_fee"`
+	PerKgFee   money.Money `json:"per_kg_fee"`
+}
+
+func LoadTable(path string) (Table, error) {
+	if path == "" {
+		return Table{}, fmt.Errorf("shipping rates file is required for the %s calculator", CalculatorTable)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Table{}, fmt.Errorf("failed to read shipping rates file: %w", err)
+	}
+
+	var table Table
+	if err := json.Unmarshal(data, &table); err != nil {
+		return Table{}, fmt.Errorf("failed to parse shipping rates file: %w", err)
+	}
+
+	for _, r := range table.Rates {
+		if r.ProvinceID == 0 && r.CityID == 0 && r.DistrictID == 0 {
+			return Table{}, fmt.Errorf("shipping rates file has a rate without a province, city or district")
+		}
+	}
+
+	return table, nil
+}
+
+// TableCalculator looks fees up in a Table. With an empty table shipping is
+// free.
+type TableCalculator struct {
+	table      Table
+	byDistrict map[uint32]TableRate
+	byCity     map[uint32]TableRate
+	byProvince map[uint32]TableRate
+	free       bool
+}
+
+func NewTableCalculator(table Table) *TableCalculator {
+	c := &TableCalculator{
+		table:      table,
+		byDistrict: make(map[uint32]TableRate),
+		byCity:     make(map[uint32]TableRate),
+		byProvince: make(map[uint32]TableRate),
+		free:       len(table.Rates) == 0 && table.DefaultRate == nil,
+	}
+
+	for _, r := range table.Rates {
+		switch {
+		case r.DistrictID != 0:
+			c.byDistrict[r.DistrictID] = r
+		case r.CityID != 0:
+			c.byCity[r.CityID] = r
+		default:
+			c.byProvince[r.ProvinceID] = r
+		}
+	}
+
+	return c
+}
+
+func (c *TableCalculator) Calculate(_ context.Context, req *Request) (*Fee, error) {
+	weight := c.weigh(req.Items)
+
+	if c.free {
+		return &Fee{WeightGrams: weight, Description: "Free shipping"}, nil
+	}
+
+	rate, ok := c.lookup(req.Destination)
+	if !ok {
+		return nil, errors.Wrapf(ErrNoRoute, "district %d", req.Destination.DistrictID)
+	}
+
+	kg := max(1, (weight+gramsPerKg-1)/gramsPerKg)
+
+	return &Fee{
+		Amount:      rate.BaseFee.Add(rate.PerKgFee.Mul(int64(kg - 1))),
+		WeightGrams: weight,
+		Description: fmt.Sprintf("Shipping (%d kg)", kg),
+	}, nil
+}
+
+func (c *TableCalculator) weigh(items []Item) int {
+	var grams int
+
+	for _, item := range items {
+		w, ok := c.table.ProductWeights[item.ProductID]
+		if !ok {
+			w = c.table.DefaultWeightGrams
+		}
+
+		grams += w * item.Quantity
+	}
+
+	return grams
+}
+
+func (c *TableCalculator) lookup(dest Destination) (TableRate, bool) {
+	if r, ok := c.byDistrict[dest.DistrictID]; ok {
+		return r, true
+	}
+
+	if r, ok := c.byCity[dest.CityID]; ok {
+		return r, true
+	}
+
+	if r, ok := c.byProvince[dest.ProvinceID]; ok {
+		return r, true
+	}
+
+	if c.table.DefaultRate != nil {
+		return *c.table.DefaultRate, true
+	}
+
+	return TableRate{}, false
+}
@@ -0,0 +1,185 @@
+package tax
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"order-service/pkg/money"
+	"os"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+var _ TaxCalculator = (*TableCalculator)(nil)
+
+// AnyRegion matches every region that has no rate of its own.
+const AnyRegion = "*"
+
+// Table holds tax rates by region and tax class, loaded from a JSON file such
+// as:
+//
+//	{
+//	  "product_classes": {"101": "reduced"},
+//	  "rates": [
+//	    {"region": "ID", "class": "standard", "rate": "11"},
+//	    {"region": "ID", "class": "reduced", "rate": "0"}
+//	  ]
+//	}
+//
+// A rate for a region like "ID-JK" falls back to its country "ID" and then
+// to AnyRegion.
+type Table struct {
+	ProductClasses map[string]string `json:"product_classes"`
+	Rates          []TableRate       `json:"rates"`
+}
+
+type TableRate struct {
+	Region string        `json:"region"`
+	Class  string        `json:"class"`
+	Rate   money.Percent `json:"rate"`
+}
+
+func LoadTable(path string) (Table, error) {
+	if path == "" {
+		return Table{}, fmt.Errorf("tax rates file is required for the %s calculator", CalculatorTable)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Table{}, fmt.Errorf("failed to read tax rates file: %w", err)
+	}
+
+	var table Table
+	if err := json.Unmarshal(data, &table); err != nil {
+		return Table{}, fmt.Errorf("failed to parse tax rates file: %w", err)
+	}
+
+	for _, r := range table.Rates {
+		if r.Region == "" || r.Class == "" || r.Rate < 0 {
+			return Table{}, fmt.Errorf("tax rates file has an invalid rate for region %q class %q", r.Region, r.Class)
+		}
+	}
+
+	return table, nil
+}
+
+// TableCalculator looks rates up in a Table. With an empty table every line
+// is taxed at zero.
+type TableCalculator struct {
+	classes   map[string]string
+	rates     map[string]money.Percent
+	empty     bool
+	inclusive bool
+	rounding  string
+}
+
+func NewTableCalculator(table Table, inclusive bool, rounding string) *TableCalculator {
+	rates := make(map[string]money.Percent, len(table.Rates))
+	for _, r := range table.Rates {
+		rates[rateKey(strings.ToUpper(r.Region), r.Class)] = r.Rate
+	}
+
+	if rounding == "" {
+		rounding = RoundingLine
+	}
+
+	return &TableCalculator{
+		classes:   table.ProductClasses,
+		rates:     rates,
+		empty:     len(rates) == 0,
+		inclusive: inclusive,
+		rounding:  rounding,
+	}
+}
+
+func (c *TableCalculator) Calculate(_ context.Context, req *Request) (*Result, error) {
+	places := money.MinorUnits(req.Currency)
+	res := &Result{Inclusive: c.inclusive, Lines: make([]LineTax, len(req.Lines))}
+
+	// Exact line taxes at full precision; rounded per line or per order below.
+	exact := make([]money.Money, len(req.Lines))
+
+	for i, line := range req.Lines {
+		class := c.classes[line.ProductID]
+		if class == "" {
+			class = DefaultClass
+		}
+
+		rate, err := c.lookup(req.Region, class)
+		if err != nil {
+			return nil, err
+		}
+
+		exact[i] = line.Amount.MulRat(c.factor(rate), money.Scale, money.RoundHalfUp)
+		res.Lines[i] = LineTax{Class: class, Rate: rate}
+	}
+
+	var amounts []money.Money
+
+	switch c.rounding {
+	case RoundingOrder:
+		var total money.Money
+		for _, e := range exact {
+			total = total.Add(e)
+		}
+
+		amounts = total.Round(places, money.RoundHalfUp).Allocate(exact, places)
+	default:
+		amounts = make([]money.Money, len(exact))
+		for i, e := range exact {
+			amounts[i] = e.Round(places, money.RoundHalfUp)
+		}
+	}
+
+	for i, line := range req.Lines {
+		res.Lines[i].Amount = amounts[i]
+		res.Lines[i].Taxable = line.Amount
+		if c.inclusive {
+			res.Lines[i].Taxable = line.Amount.Sub(amounts[i])
+		}
+
+		res.Total = res.Total.Add(amounts[i])
+	}
+
+	return res, nil
+}
+
+// factor is the share of a line amount that is tax: r for exclusive prices
+// and r/(1+r) when the price already includes the tax.
+func (c *TableCalculator) factor(rate money.Percent) *big.Rat {
+	r := rate.Rat()
+	if !c.inclusive {
+		return r
+	}
+
+	return new(big.Rat).Quo(r, new(big.Rat).Add(big.NewRat(1, 1), r))
+}
+
+func (c *TableCalculator) lookup(region, class string) (money.Percent, error) {
+	if c.empty {
+		return 0, nil
+	}
+
+	region = strings.ToUpper(region)
+	candidates := []string{region}
+
+	if country, _, ok := strings.Cut(region, "-"); ok {
+		candidates = append(candidates, country)
+	}
+
+	candidates = append(candidates, AnyRegion)
+
+	for _, r := range candidates {
+		if rate, ok := c.rates[rateKey(r, class)]; ok {
+			return rate, nil
+		}
+	}
+
+	return 0, errors.Wrapf(ErrRateNotFound, "no %s rate for region %q", class, region)
+}
+
+func rateKey(region, class string) string {
+	return region + "/" + class
+}
@@ -0,0 +1,82 @@
+package tax
+
+import (
+	"context"
+	"fmt"
+	"order-service/config"
+	"order-service/pkg/money"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	CalculatorNone  = "none"
+	CalculatorTable = "table"
+
+	// RoundingLine rounds each line's tax to the currency's minor unit.
+	// RoundingOrder rounds the order's total tax once and allocates it back
+	// over the lines, which can differ from the sum of rounded lines.
+	RoundingLine  = "line"
+	RoundingOrder = "order"
+
+	// DefaultClass is used for products without an explicit tax class.
+	DefaultClass = "standard"
+)
+
+var ErrRateNotFound = errors.New("tax rate not found")
+
+// TaxCalculator computes the tax owed on each line of an order.
+type TaxCalculator interface {
+	Calculate(ctx context.Context, req *Request) (*Result, error)
+}
+
+type Request struct {
+	Region   string
+	Currency string
+	Lines    []Line
+}
+
+// Line is one order item. Amount is what the customer pays for the line
+// after discounts, in the request currency.
+type Line struct {
+	ProductID string
+	Amount    money.Money
+}
+
+type Result struct {
+	// Inclusive reports whether line amounts already contain the tax.
+	Inclusive bool
+	// Lines has one entry per request line, in the same order.
+	Lines []LineTax
+	Total money.Money
+}
+
+type LineTax struct {
+	Class string
+	Rate  money.Percent
+	// Taxable is the amount the rate was applied to, net of tax.
+	Taxable money.Money
+	Amount  money.Money
+}
+
+func NewTaxCalculator(cfg *config.Config) (TaxCalculator, error) {
+	switch cfg.Tax.Rounding {
+	case "", RoundingLine, RoundingOrder:
+	default:
+		return nil, fmt.Errorf("unknown tax rounding %q", cfg.Tax.Rounding)
+	}
+
+	switch cfg.Tax.Calculator {
+	case "", CalculatorNone:
+		return NewTableCalculator(Table{}, cfg.Tax.PricesIncludeTax, cfg.Tax.Rounding), nil
+	case CalculatorTable:
+		table, err := LoadTable(cfg.Tax.RatesFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewTableCalculator(table, cfg.Tax.PricesIncludeTax, cfg.Tax.Rounding), nil
+	default:
+		return nil, fmt.Errorf("unknown tax calculator %q", cfg.Tax.Calculator)
+	}
+}
@@ -0,0 +1,93 @@
+package tax_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"order-service/internal/adapter/tax"
+	"order-service/pkg/money"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableCalculator_LineAndOrderRounding(t *testing.T) {
+	table := tax.Table{Rates: []tax.TableRate{{Region: "US", Class: tax.DefaultClass, Rate: money.MustParsePercent("10")}}}
+	req := &tax.Request{
+		Region:   "US",
+		Currency: "USD",
+		Lines: []tax.Line{
+			{ProductID: "1", Amount: money.MustParse("0.05")},
+			{ProductID: "2", Amount: money.MustParse("0.05")},
+			{ProductID: "3", Amount: money.MustParse("0.05")},
+		},
+	}
+
+	perLine, err := tax.NewTableCalculator(table, false, tax.RoundingLine).Calculate(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, money.MustParse("0.03"), perLine.Total)
+
+	perOrder, err := tax.NewTableCalculator(table, false, tax.RoundingOrder).Calculate(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, money.MustParse("0.02"), perOrder.Total)
+	assert.Equal(t, money.MustParse("0.01"), perOrder.Lines[0].Amount)
+	assert.Equal(t, money.MustParse("0.01"), perOrder.Lines[1].Amount)
+	assert.True(t, perOrder.Lines[2].Amount.IsZero())
+}
+
+func TestTableCalculator_InclusivePricing(t *testing.T) {
+	table := tax.Table{Rates: []tax.TableRate{{Region: "ID", Class: tax.DefaultClass, Rate: money.MustParsePercent("11")}}}
+
+	res, err := tax.NewTableCalculator(table, true, tax.RoundingLine).Calculate(context.Background(), &tax.Request{
+		Region:   "ID-JK",
+		Currency: "USD",
+		Lines:    []tax.Line{{ProductID: "1", Amount: money.FromInt(111)}},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, res.Inclusive)
+	assert.Equal(t, money.FromInt(11), res.Lines[0].Amount)
+	assert.Equal(t, money.FromInt(100), res.Lines[0].Taxable)
+}
+
+func TestTableCalculator_UnknownRegion(t *testing.T) {
+	table := tax.Table{Rates: []tax.TableRate{{Region: "ID", Class: tax.DefaultClass, Rate: money.MustParsePercent("11")}}}
+
+	_, err := tax.NewTableCalculator(table, false, tax.RoundingLine).Calculate(context.Background(), &tax.Request{
+		Region:   "SG",
+		Currency: "SGD",
+		Lines:    []tax.Line{{ProductID: "1", Amount: money.FromInt(10)}},
+	})
+
+	assert.ErrorIs(t, err, tax.ErrRateNotFound)
+}
+
+func TestLoadTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tax.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"product_classes": {"101": "reduced"},
+		"rates": [
+			{"region": "ID", "class": "standard", "rate": "11"},
+			{"region": "ID", "class": "reduced", "rate": "5.5"}
+		]
+	}`), 0o600))
+
+	table, err := tax.LoadTable(path)
+	require.NoError(t, err)
+
+	res, err := tax.NewTableCalculator(table, false, tax.RoundingLine).Calculate(context.Background(), &tax.Request{
+		Region:   "ID",
+		Currency: "USD",
+		Lines: []tax.Line{
+			{ProductID: "101", Amount: money.FromInt(100)},
+			{ProductID: "102", Amount: money.FromInt(100)},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "reduced", res.Lines[0].Class)
+	assert.Equal(t, money.MustParse("5.5"), res.Lines[0].Amount)
+	assert.Equal(t, money.FromInt(11), res.Lines[1].Amount)
+}
@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"order-service/config"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+
+	// maxResponseBody is how much of a receiver's response is kept for the
+	// delivery log.
+	maxResponseBody = 1024
+)
+
+// Sender posts signed webhook requests to subscribers.
+type Sender interface {
+	// Send posts the request and returns the receiver's response, whatever
+	// its status. An error means no response was received.
+	Send(ctx context.Context, req *Request) (*Response, error)
+}
+
+type Request struct {
+	URL        string
+	Secret     string
+	EventType  string
+	DeliveryID uint32
+	Body       []byte
+}
+
+type Response struct {
+	StatusCode int
+	Body       string
+}
+
+// Succeeded reports whether the receiver accepted the delivery.
+func (r *Response) Succeeded() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>". Binding the
+// timestamp into the signature lets receivers reject replayed requests.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type httpSender struct {
+	client *http.Client
+}
+
+func NewSender(cfg *config.Config) Sender {
+	return NewHTTPSender(&http.Client{Timeout: cfg.Webhook.DeliveryTimeout})
+}
+
+func NewHTTPSender(client *http.Client) *httpSender {
+	return &httpSender{client: client}
+}
+
+func (s *httpSender) Send(ctx context.Context, req *Request) (*Response, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	now := time.Now()
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "order-service-webhooks/1.0")
+	httpReq.Header.Set(HeaderEvent, req.EventType)
+	httpReq.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(req.DeliveryID), 10))
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, now, req.Body))
+
+	res, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxResponseBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook response: %w", err)
+	}
+
+	return &Response{StatusCode: res.StatusCode, Body: string(body)}, nil
+}
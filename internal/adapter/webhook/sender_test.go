@@ -0,0 +1,90 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"order-service/internal/adapter/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSender_Send(t *testing.T) {
+	body := []byte(`{"id":"evt_1","type":"order.created"}`)
+
+	var received *http.Request
+	var receivedBody []byte
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer receiver.Close()
+
+	sender := webhook.NewHTTPSender(receiver.Client())
+
+	res, err := sender.Send(context.Background(), &webhook.Request{
+		URL:        receiver.URL,
+		Secret:     "whsec",
+		EventType:  "order.created",
+		DeliveryID: 42,
+		Body:       body,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, res.StatusCode)
+	assert.Equal(t, "ok", res.Body)
+	assert.True(t, res.Succeeded())
+
+	require.NotNil(t, received)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, body, receivedBody)
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, "order.created", received.Header.Get(webhook.HeaderEvent))
+	assert.Equal(t, "42", received.Header.Get(webhook.HeaderDelivery))
+
+	unix, err := strconv.ParseInt(received.Header.Get(webhook.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), time.Unix(unix, 0), 5*time.Second)
+	assert.Equal(t, webhook.Sign("whsec", time.Unix(unix, 0), body), received.Header.Get(webhook.HeaderSignature))
+}
+
+func TestHTTPSender_Send_KeepsFailedResponses(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(strings.Repeat("x", 4096)))
+	}))
+	defer receiver.Close()
+
+	res, err := webhook.NewHTTPSender(receiver.Client()).Send(context.Background(), &webhook.Request{URL: receiver.URL, Body: []byte(`{}`)})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	assert.False(t, res.Succeeded())
+	assert.Len(t, res.Body, 1024)
+}
+
+func TestHTTPSender_Send_ConnectionError(t *testing.T) {
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	url := receiver.URL
+	receiver.Close()
+
+	_, err := webhook.NewHTTPSender(http.DefaultClient).Send(context.Background(), &webhook.Request{URL: url, Body: []byte(`{}`)})
+	assert.Error(t, err)
+}
+
+func TestSign(t *testing.T) {
+	ts := time.Unix(1_700_000_000, 0)
+	body := []byte(`{}`)
+
+	assert.Equal(t, webhook.Sign("a", ts, body), webhook.Sign("a", ts, body))
+	assert.NotEqual(t, webhook.Sign("a", ts, body), webhook.Sign("b", ts, body))
+	assert.NotEqual(t, webhook.Sign("a", ts, body), webhook.Sign("a", ts.Add(time.Second), body))
+}
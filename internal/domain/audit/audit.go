@@ -0,0 +1,158 @@
+// Package audit describes who makes a change and what the change was, for
+// the audit log written alongside every mutation.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+)
+
+// Actor types.
+const (
+	ActorUser            = "user"
+	ActorAdmin           = "admin"
+	ActorPaymentProvider = "payment_provider"
+	ActorSystem          = "system"
+)
+
+// redacted replaces the value of sensitive fields in recorded changes.
+const redacted = "[REDACTED]"
+
+// ignoredFields change on every write and say nothing about the change.
+var ignoredFields = map[string]bool{"UpdatedAt": true}
+
+// sensitiveFields are recorded as changed without their values.
+var sensitiveFields = map[string]bool{"Secret": true}
+
+// Actor is whoever makes a change: a user, an admin, a payment provider
+// calling back, or the service itself.
+type Actor struct {
+	Type string
+	ID   string
+}
+
+// System is the service acting on its own, e.g. from a background job;
+// process names which part of it.
+func System(process string) Actor {
+	return Actor{Type: ActorSystem, ID: process}
+}
+
+// Request describes the request a change is made for. Changes made outside
+// of a request carry only an actor.
+type Request struct {
+	Actor     Actor
+	RequestID string
+	IP        string
+	UserAgent string
+}
+
+type contextKey struct{}
+
+// WithRequest returns a copy of ctx carrying req.
+func WithRequest(ctx context.Context, req Request) context.Context {
+	return context.WithValue(ctx, contextKey{}, req)
+}
+
+// WithActor returns a copy of ctx whose request is made by actor.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	req := FromContext(ctx)
+	req.Actor = actor
+
+	return WithRequest(ctx, req)
+}
+
+// FromContext returns the request ctx carries. Without one the change is
+// attributed to the system.
+func FromContext(ctx context.Context) Request {
+	req, ok := ctx.Value(contextKey{}).(Request)
+	if !ok || req.Actor.Type == "" {
+		req.Actor = Actor{Type: ActorSystem}
+	}
+
+	return req
+}
+
+// Change is the value of one field before and after a change. A field that
+// did not exist before, or no longer exists, is null on that side.
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Snapshot captures v as it is now, so it can be diffed after v is changed
+// in place.
+func Snapshot(v any) (json.RawMessage, error) {
+	return json.Marshal(v)
+}
+
+// Diff returns the fields that differ between before and after, by their JSON
+// encoding. Either may be nil, for a creation or a deletion. Sensitive
+// fields are redacted.
+func Diff(before, after any) (map[string]Change, error) {
+	b, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]Change)
+
+	for name, value := range b {
+		if ignoredFields[name] || reflect.DeepEqual(value, a[name]) {
+			continue
+		}
+
+		changes[name] = Change{Before: value, After: a[name]}
+	}
+
+	for name, value := range a {
+		if _, ok := b[name]; ok || ignoredFields[name] || value == nil {
+			continue
+		}
+
+		changes[name] = Change{After: value}
+	}
+
+	for name, change := range changes {
+		if !sensitiveFields[name] {
+			continue
+		}
+
+		if change.Before != nil {
+			change.Before = redacted
+		}
+		if change.After != nil {
+			change.After = redacted
+		}
+
+		changes[name] = change
+	}
+
+	return changes, nil
+}
+
+func fields(v any) (map[string]any, error) {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+		return nil, nil
+	}
+
+	raw, ok := v.(json.RawMessage)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
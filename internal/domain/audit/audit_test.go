@@ -0,0 +1,103 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+
+	"order-service/internal/domain/audit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type record struct {
+	Status    string
+	Secret    string
+	Note      *string
+	UpdatedAt string
+}
+
+func TestDiff(t *testing.T) {
+	note := "left at the door"
+
+	tests := []struct {
+		name   string
+		before any
+		after  any
+		want   map[string]audit.Change
+	}{
+		{
+			name:   "changed fields only",
+			before: &record{Status: "CONFIRMED", UpdatedAt: "t1"},
+			after:  &record{Status: "DELIVERED", Note: &note, UpdatedAt: "t2"},
+			want: map[string]audit.Change{
+				"Status": {Before: "CONFIRMED", After: "DELIVERED"},
+				"Note":   {Before: nil, After: note},
+			},
+		},
+		{
+			name:  "creation",
+			after: &record{Status: "REQUESTED"},
+			want: map[string]audit.Change{
+				"Status": {After: "REQUESTED"},
+				"Secret": {After: "[REDACTED]"},
+			},
+		},
+		{
+			name:   "deletion",
+			before: &record{Status: "REQUESTED"},
+			after:  (*record)(nil),
+			want: map[string]audit.Change{
+				"Status": {Before: "REQUESTED"},
+				"Secret": {Before: "[REDACTED]"},
+			},
+		},
+		{
+			name:   "secrets are redacted",
+			before: &record{Secret: "old-secret-value"},
+			after:  &record{Secret: "new-secret-value"},
+			want: map[string]audit.Change{
+				"Secret": {Before: "[REDACTED]", After: "[REDACTED]"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := audit.Diff(tt.before, tt.after)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiff_Snapshot(t *testing.T) {
+	r := &record{Status: "REQUESTED"}
+
+	before, err := audit.Snapshot(r)
+	assert.NoError(t, err)
+
+	r.Status = "APPROVED"
+
+	got, err := audit.Diff(before, r)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]audit.Change{"Status": {Before: "REQUESTED", After: "APPROVED"}}, got)
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, audit.Request{Actor: audit.Actor{Type: audit.ActorSystem}}, audit.FromContext(context.Background()))
+
+	ctx := audit.WithRequest(context.Background(), audit.Request{
+		Actor:     audit.Actor{Type: audit.ActorUser, ID: "1"},
+		RequestID: "req-1",
+		IP:        "203.0.113.7",
+	})
+	ctx = audit.WithActor(ctx, audit.Actor{Type: audit.ActorAdmin})
+
+	assert.Equal(t, audit.Request{
+		Actor:     audit.Actor{Type: audit.ActorAdmin},
+		RequestID: "req-1",
+		IP:        "203.0.113.7",
+	}, audit.FromContext(ctx))
+}
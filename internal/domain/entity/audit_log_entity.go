@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLog records one change: who made it, from where, and the fields it
+// changed on which entity. Changes maps each changed field to its value
+// before and after.
+type AuditLog struct {
+	ID         uint64
+	ActorType  string
+	ActorID    string
+	RequestID  string
+	IP         string
+	UserAgent  string
+	Action     string
+	EntityType string
+	EntityID   uint32
+	Changes    json.RawMessage
+	CreatedAt  time.Time
+}
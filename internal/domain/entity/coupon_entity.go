@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"order-service/pkg/money"
+	"time"
+)
+
+// Coupon amounts (AmountOff, MaxDiscount, MinSpend) are in the base currency
+// and converted with the order's FX rate when applied.
+type Coupon struct {
+	Base
+	Code              string
+	Name              string
+	Description       string
+	Type              string
+	PercentOff        money.Percent
+	AmountOff         money.Money
+	MaxDiscount       money.Money
+	MinSpend          money.Money
+	FreeProductID     string
+	FreeQuantity      int
+	StartsAt          *time.Time
+	EndsAt            *time.Time
+	UsageLimit        int
+	UsageLimitPerUser int
+	UsedCount         int
+	IsActive          bool
+
+	EligibleProductIDs []string
+}
+
+type CouponUsage struct {
+	Base
+	CouponID uint32
+	UserID   uint32
+	OrderID  uint32
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Job is a unit of background work. Payload holds the JSON-encoded argument
+// of the handler registered for Type. A MaxAttempts of 0 uses the queue's
+// default.
+type Job struct {
+	Base
+	Type        string
+	Payload     json.RawMessage
+	Status      string
+	Attempts    int
+	MaxAttempts int
+	RunAt       time.Time
+	LockedAt    *time.Time
+	LastError   string
+	CompletedAt *time.Time
+	FailedAt    *time.Time
+}
@@ -0,0 +1,22 @@
+package entity
+
+type Province struct {
+	ID   uint32
+	Name string
+}
+
+type City struct {
+	ID         uint32
+	ProvinceID uint32
+	Name       string
+
+	Province *Province
+}
+
+type District struct {
+	ID     uint32
+	CityID uint32
+	Name   string
+
+	City *City
+}
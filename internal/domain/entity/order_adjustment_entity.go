@@ -0,0 +1,19 @@
+package entity
+
+import "order-service/pkg/money"
+
+// OrderAdjustment is a signed amount applied on top of the item subtotals,
+// such as a coupon discount (negative). Adjustments tied to a single line
+// reference it through OrderItem.
+type OrderAdjustment struct {
+	Base
+	OrderID     uint32
+	OrderItemID *uint32
+	CouponID    *uint32
+	Type        string
+	Code        string
+	Description string
+	Amount      money.Money
+
+	OrderItem *OrderItem
+}
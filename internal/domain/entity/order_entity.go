@@ -1,11 +1,50 @@
 package entity
 
+import (
+	"order-service/pkg/money"
+	"time"
+)
+
 type Order struct {
 	Base
-	UserID     uint32
-	Status     string
-	TotalPrice float64
+	UserID        uint32
+	Status        string
+	Currency      string
+	Subtotal      money.Money
+	DiscountTotal money.Money
+	ShippingTotal money.Money
+	TaxTotal      money.Money
+	// TotalPrice is the grand total charged: Subtotal less discounts, plus
+	// shipping, plus TaxTotal unless prices already include tax.
+	TotalPrice money.Money
 
-	Items []*OrderItem
-}
+	TaxRegion    string
+	TaxInclusive bool
+
+	// DeliveredAt starts the return window of the order's items.
+	DeliveredAt *time.Time
+
+	CancellationReason string
+	CancelledAt        *time.Time
 
+	// BaseCurrency is the currency inventory prices are quoted in. FXRate is
+	// the snapshot used to convert them into Currency when the order was
+	// created, so totals can be reproduced later.
+	BaseCurrency   string
+	BaseTotalPrice money.Money
+	FXRate         money.Rate
+	FXRateSource   string
+	FXRateAt       time.Time
+
+	// CouponCodes are the codes requested at checkout. Applied coupons are
+	// persisted as Adjustments.
+	CouponCodes []string
+
+	ShippingAddress *ShippingAddress
+
+	Items       []*OrderItem
+	Adjustments []*OrderAdjustment
+	Payments    []*Payment
+	Refunds     []*Refund
+	Returns     []*OrderReturn
+}
@@ -1,12 +1,26 @@
 package entity
 
+import "order-service/pkg/money"
+
+// OrderItem is an ordered line. Quantity and the amounts are as ordered;
+// CancelledQuantity of those units have been cancelled since, and
+// ReservationID is the inventory reservation that holds the remaining units.
 type OrderItem struct {
 	Base
 	OrderID   uint32
 	ProductID string
 	Quantity  int
-	Price     float64
-	Subtotal  float64
+	Price     money.Money
+	BasePrice money.Money
+	Subtotal  money.Money
+
+	CancelledQuantity int
+	ReservationID     *uint32
+
+	TaxClass      string
+	TaxRate       money.Percent
+	TaxableAmount money.Money
+	TaxAmount     money.Money
 
 	Order *Order
 }
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"order-service/pkg/money"
+	"time"
+)
+
+// OrderReturn is a request to send back some units of one delivered order
+// item. Once the goods are received they are restocked and refunded; RefundID
+// and RefundAmount then point at that refund.
+type OrderReturn struct {
+	Base
+	OrderID         uint32
+	OrderItemID     uint32
+	Quantity        int
+	ReasonCode      string
+	Note            string
+	Status          string
+	RejectionReason string
+	RefundID        *uint32
+	RefundAmount    money.Money
+	ApprovedAt      *time.Time
+	RejectedAt      *time.Time
+	ReceivedAt      *time.Time
+	RestockedAt     *time.Time
+}
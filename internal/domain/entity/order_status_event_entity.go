@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// OrderStatusEvent records an order taking a status. Its ID grows with every
+// event, so streams resume from the last ID a client saw.
+type OrderStatusEvent struct {
+	ID        uint64
+	OrderID   uint32
+	UserID    uint32
+	Status    string
+	CreatedAt time.Time
+}
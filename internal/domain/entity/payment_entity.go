@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"order-service/pkg/money"
+	"time"
+)
+
+// Payment is one attempt to pay for an order. An order may have several
+// attempts; at most one of them succeeds.
+type Payment struct {
+	Base
+	OrderID           uint32
+	Provider          string
+	Reference         string
+	ProviderReference string
+	Status            string
+	Amount            money.Money
+	Currency          string
+	CheckoutURL       string
+	FailureReason     string
+	PaidAt            *time.Time
+	FailedAt          *time.Time
+}
+
+// PaymentEvent records a verified provider callback. Its (Provider, EventID)
+// pair is unique, which is what makes webhook delivery idempotent.
+type PaymentEvent struct {
+	Base
+	Provider  string
+	EventID   string
+	Type      string
+	PaymentID *uint32
+	Payload   []byte
+}
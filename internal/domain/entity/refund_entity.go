@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"order-service/pkg/money"
+	"time"
+)
+
+// Refund returns part or all of a captured payment. Requested and succeeded
+// refunds count towards the payment's refunded amount; failed ones do not.
+type Refund struct {
+	Base
+	OrderID           uint32
+	PaymentID         uint32
+	Reference         string
+	ProviderReference string
+	Status            string
+	Amount            money.Money
+	Currency          string
+	Reason            string
+	Attempts          int
+	LastError         string
+	RefundedAt        *time.Time
+	FailedAt          *time.Time
+
+	// Items is set when the refund is for specific order items rather than
+	// an arbitrary amount.
+	Items []*RefundItem
+}
+
+type RefundItem struct {
+	Base
+	RefundID    uint32
+	OrderItemID uint32
+	Quantity    int
+	Amount      money.Money
+}
@@ -0,0 +1,15 @@
+package entity
+
+type ShippingAddress struct {
+	Base
+	OrderID       uint32
+	RecipientName string
+	Phone         string
+	Street        string
+	PostalCode    string
+	DistrictID    uint32
+
+	// District is resolved with its city and province when the address is
+	// validated or loaded.
+	District *District
+}
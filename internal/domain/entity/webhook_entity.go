@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookSubscription asks for the events of EventTypes to be posted to URL,
+// signed with Secret. It is disabled automatically, with DisabledAt and
+// DisabledReason set, once too many deliveries in a row have failed.
+type WebhookSubscription struct {
+	Base
+	URL                 string
+	Secret              string
+	EventTypes          []string
+	IsActive            bool
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+	DisabledReason      string
+}
+
+// WebhookDelivery is one event sent to one subscription. Attempts and the
+// last response are kept for the delivery log.
+type WebhookDelivery struct {
+	Base
+	SubscriptionID uint32
+	EventID        string
+	EventType      string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int
+	ResponseStatus int
+	ResponseBody   string
+	LastError      string
+	DeliveredAt    *time.Time
+	FailedAt       *time.Time
+}
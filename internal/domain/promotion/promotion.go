@@ -0,0 +1,240 @@
+// Package promotion applies coupons to a priced order.
+//
+// Coupons are applied in a fixed order so the same cart and codes always
+// produce the same discounts: free-item rules first, then percentage rules,
+// then fixed amounts, with ties broken by code. Each rule only discounts what
+// is left of a line after the rules before it, so lines never go negative.
+// Percentage and fixed discounts are spread over the eligible lines in
+// proportion to their remaining amounts.
+package promotion
+
+import (
+	"order-service/constant"
+	"order-service/internal/domain/entity"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/money"
+	"slices"
+	"strings"
+	"time"
+)
+
+// rounding is used whenever a discount is converted or computed from a
+// percentage.
+const rounding = money.RoundHalfUp
+
+var typeRank = map[string]int{
+	string(constant.CouponTypeFreeItem):   0,
+	string(constant.CouponTypePercentage): 1,
+	string(constant.CouponTypeFixed):      2,
+}
+
+// NormalizeCode trims and upper-cases a coupon code.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// CheckRules reports whether c is a well-formed coupon definition: the fields
+// its type relies on are set and amounts and limits are not negative.
+func CheckRules(c *entity.Coupon) error {
+	switch c.Type {
+	case string(constant.CouponTypePercentage):
+		if c.PercentOff <= 0 || c.PercentOff > money.MustParsePercent("100") {
+			return invalid("percent_off must be greater than 0 and at most 100")
+		}
+	case string(constant.CouponTypeFixed):
+		if c.AmountOff.Cmp(money.Zero) <= 0 {
+			return invalid("amount_off must be greater than 0")
+		}
+	case string(constant.CouponTypeFreeItem):
+		if c.FreeProductID == "" || c.FreeQuantity <= 0 {
+			return invalid("free_product_id and free_quantity are required for %s coupons", c.Type)
+		}
+	default:
+		return invalid("unknown coupon type %q", c.Type)
+	}
+
+	switch {
+	case c.MaxDiscount.IsNegative(), c.MinSpend.IsNegative():
+		return invalid("max_discount and min_spend must not be negative")
+	case c.UsageLimit < 0, c.UsageLimitPerUser < 0:
+		return invalid("usage limits must not be negative")
+	case c.StartsAt != nil && c.EndsAt != nil && !c.EndsAt.After(*c.StartsAt):
+		return invalid("ends_at must be after starts_at")
+	}
+
+	return nil
+}
+
+// Validate checks whether c may be used on order. userUses is how many times
+// the ordering user has already used c.
+func Validate(c *entity.Coupon, order *entity.Order, userUses int, now time.Time) error {
+	switch {
+	case !c.IsActive:
+		return invalid("coupon %s is not active", c.Code)
+	case c.StartsAt != nil && now.Before(*c.StartsAt):
+		return invalid("coupon %s is not valid yet", c.Code)
+	case c.EndsAt != nil && !now.Before(*c.EndsAt):
+		return invalid("coupon %s has expired", c.Code)
+	case c.UsageLimit > 0 && c.UsedCount >= c.UsageLimit:
+		return exception.Newf(exception.TypeConflict, exception.CodeCouponUsageExceeded, "coupon %s has reached its usage limit", c.Code)
+	case c.UsageLimitPerUser > 0 && userUses >= c.UsageLimitPerUser:
+		return exception.Newf(exception.TypeConflict, exception.CodeCouponUsageExceeded, "coupon %s has already been used the maximum number of times", c.Code)
+	}
+
+	return CheckMinSpend(c, order)
+}
+
+// CheckMinSpend checks that the order's subtotal reaches the minimum spend of
+// c.
+func CheckMinSpend(c *entity.Coupon, order *entity.Order) error {
+	if minSpend := order.FXRate.Convert(c.MinSpend, order.Currency, rounding); order.Subtotal.Cmp(minSpend) < 0 {
+		return invalid("coupon %s requires a minimum spend of %s %s", c.Code, minSpend, order.Currency)
+	}
+
+	return nil
+}
+
+// Apply returns the discount adjustments for coupons on order. The order's
+// items must already be priced and Subtotal set. Coupons are expected to have
+// been checked with Validate.
+func Apply(order *entity.Order, coupons []*entity.Coupon) ([]*entity.OrderAdjustment, error) {
+	sorted := slices.Clone(coupons)
+	slices.SortStableFunc(sorted, func(a, b *entity.Coupon) int {
+		if d := typeRank[a.Type] - typeRank[b.Type]; d != 0 {
+			return d
+		}
+
+		return strings.Compare(a.Code, b.Code)
+	})
+
+	remaining := make([]money.Money, len(order.Items))
+	for i, item := range order.Items {
+		remaining[i] = item.Subtotal
+	}
+
+	places := money.MinorUnits(order.Currency)
+
+	var adjustments []*entity.OrderAdjustment
+
+	for _, c := range sorted {
+		var discounts []money.Money
+
+		switch c.Type {
+		case string(constant.CouponTypeFreeItem):
+			discounts = freeItemDiscounts(c, order.Items, remaining)
+		case string(constant.CouponTypePercentage):
+			base := eligibleWeights(c, order.Items, remaining)
+			amount := c.PercentOff.Of(sum(base), places, rounding)
+			discounts = spread(amount, capDiscount(c, order), base, places)
+		case string(constant.CouponTypeFixed):
+			base := eligibleWeights(c, order.Items, remaining)
+			amount := order.FXRate.Convert(c.AmountOff, order.Currency, rounding)
+			discounts = spread(amount, capDiscount(c, order), base, places)
+		default:
+			return nil, invalid("coupon %s has an unknown type %q", c.Code, c.Type)
+		}
+
+		applied := false
+
+		for i, d := range discounts {
+			if d.IsZero() {
+				continue
+			}
+
+			applied = true
+			remaining[i] = remaining[i].Sub(d)
+
+			couponID := c.ID
+			adjustments = append(adjustments, &entity.OrderAdjustment{
+				CouponID:    &couponID,
+				Type:        string(constant.AdjustmentTypeDiscount),
+				Code:        c.Code,
+				Description: c.Name,
+				Amount:      d.Neg(),
+				OrderItem:   order.Items[i],
+			})
+		}
+
+		if !applied {
+			return nil, invalid("coupon %s is not applicable to this order", c.Code)
+		}
+	}
+
+	return adjustments, nil
+}
+
+func eligibleWeights(c *entity.Coupon, items []*entity.OrderItem, remaining []money.Money) []money.Money {
+	weights := make([]money.Money, len(items))
+
+	for i, item := range items {
+		if len(c.EligibleProductIDs) == 0 || slices.Contains(c.EligibleProductIDs, item.ProductID) {
+			weights[i] = remaining[i]
+		}
+	}
+
+	return weights
+}
+
+func freeItemDiscounts(c *entity.Coupon, items []*entity.OrderItem, remaining []money.Money) []money.Money {
+	discounts := make([]money.Money, len(items))
+	freeUnits := c.FreeQuantity
+
+	for i, item := range items {
+		if freeUnits <= 0 {
+			break
+		}
+
+		if item.ProductID != c.FreeProductID {
+			continue
+		}
+
+		units := min(freeUnits, item.Quantity)
+		freeUnits -= units
+
+		d := item.Price.Mul(int64(units))
+		if d.Cmp(remaining[i]) > 0 {
+			d = remaining[i]
+		}
+
+		discounts[i] = d
+	}
+
+	return discounts
+}
+
+// capDiscount returns the coupon's maximum discount in the order currency, or
+// zero when there is no cap.
+func capDiscount(c *entity.Coupon, order *entity.Order) money.Money {
+	if c.MaxDiscount.IsZero() {
+		return money.Zero
+	}
+
+	return order.FXRate.Convert(c.MaxDiscount, order.Currency, rounding)
+}
+
+// spread limits amount to the cap and to what is left on the eligible lines,
+// then allocates it across them.
+func spread(amount, maxDiscount money.Money, weights []money.Money, places int) []money.Money {
+	if !maxDiscount.IsZero() && amount.Cmp(maxDiscount) > 0 {
+		amount = maxDiscount
+	}
+
+	if total := sum(weights); amount.Cmp(total) > 0 {
+		amount = total
+	}
+
+	return amount.Allocate(weights, places)
+}
+
+func sum(values []money.Money) money.Money {
+	var total money.Money
+	for _, v := range values {
+		total = total.Add(v)
+	}
+
+	return total
+}
+
+func invalid(format string, args ...any) error {
+	return exception.Newf(exception.TypeBadRequest, exception.CodeCouponInvalid, format, args...)
+}
@@ -0,0 +1,118 @@
+package promotion_test
+
+import (
+	"testing"
+	"time"
+
+	"order-service/constant"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/promotion"
+	"order-service/pkg/money"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pricedOrder(items ...*entity.OrderItem) *entity.Order {
+	order := &entity.Order{Currency: "IDR", BaseCurrency: "IDR", Items: items}
+
+	for _, item := range items {
+		item.Subtotal = item.Price.Mul(int64(item.Quantity))
+		order.Subtotal = order.Subtotal.Add(item.Subtotal)
+	}
+
+	return order
+}
+
+func TestApply_OrderIsDeterministic(t *testing.T) {
+	order := pricedOrder(
+		&entity.OrderItem{ProductID: "1", Quantity: 2, Price: money.FromInt(10000)},
+		&entity.OrderItem{ProductID: "2", Quantity: 1, Price: money.FromInt(30000)},
+	)
+
+	fixed := &entity.Coupon{Base: entity.Base{ID: 1}, Code: "FIXED", Type: string(constant.CouponTypeFixed), AmountOff: money.FromInt(5000)}
+	percent := &entity.Coupon{Base: entity.Base{ID: 2}, Code: "PCT", Type: string(constant.CouponTypePercentage), PercentOff: money.MustParsePercent("50")}
+	free := &entity.Coupon{Base: entity.Base{ID: 3}, Code: "FREE", Type: string(constant.CouponTypeFreeItem), FreeProductID: "1", FreeQuantity: 1}
+
+	a, err := promotion.Apply(order, []*entity.Coupon{fixed, percent, free})
+	require.NoError(t, err)
+
+	b, err := promotion.Apply(order, []*entity.Coupon{free, fixed, percent})
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b)
+
+	// 50000 - 10000 free = 40000, half off = 20000, minus 5000 fixed.
+	var total money.Money
+	for _, adj := range a {
+		total = total.Add(adj.Amount)
+	}
+
+	assert.Equal(t, money.FromInt(-35000), total)
+	assert.Equal(t, "FREE", a[0].Code)
+}
+
+func TestApply_MaxDiscountAndEligibleProducts(t *testing.T) {
+	order := pricedOrder(
+		&entity.OrderItem{ProductID: "1", Quantity: 1, Price: money.FromInt(100000)},
+		&entity.OrderItem{ProductID: "2", Quantity: 1, Price: money.FromInt(100000)},
+	)
+
+	c := &entity.Coupon{
+		Code:               "CAP",
+		Type:               string(constant.CouponTypePercentage),
+		PercentOff:         money.MustParsePercent("20"),
+		MaxDiscount:        money.FromInt(15000),
+		EligibleProductIDs: []string{"2"},
+	}
+
+	adjustments, err := promotion.Apply(order, []*entity.Coupon{c})
+	require.NoError(t, err)
+	require.Len(t, adjustments, 1)
+
+	assert.Same(t, order.Items[1], adjustments[0].OrderItem)
+	assert.Equal(t, money.FromInt(-15000), adjustments[0].Amount)
+}
+
+func TestApply_NotApplicable(t *testing.T) {
+	order := pricedOrder(&entity.OrderItem{ProductID: "1", Quantity: 1, Price: money.FromInt(1000)})
+
+	c := &entity.Coupon{Code: "FREE", Type: string(constant.CouponTypeFreeItem), FreeProductID: "9", FreeQuantity: 1}
+
+	_, err := promotion.Apply(order, []*entity.Coupon{c})
+
+	assert.ErrorContains(t, err, "not applicable")
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	order := pricedOrder(&entity.OrderItem{ProductID: "1", Quantity: 1, Price: money.FromInt(50000)})
+
+	tests := []struct {
+		name     string
+		coupon   entity.Coupon
+		userUses int
+		wantErr  string
+	}{
+		{name: "valid", coupon: entity.Coupon{Code: "OK", IsActive: true}},
+		{name: "inactive", coupon: entity.Coupon{Code: "X"}, wantErr: "not active"},
+		{name: "not started", coupon: entity.Coupon{Code: "X", IsActive: true, StartsAt: &later}, wantErr: "not valid yet"},
+		{name: "expired", coupon: entity.Coupon{Code: "X", IsActive: true, EndsAt: &now}, wantErr: "expired"},
+		{name: "global cap", coupon: entity.Coupon{Code: "X", IsActive: true, UsageLimit: 5, UsedCount: 5}, wantErr: "usage limit"},
+		{name: "per user cap", coupon: entity.Coupon{Code: "X", IsActive: true, UsageLimitPerUser: 1}, userUses: 1, wantErr: "maximum number"},
+		{name: "min spend", coupon: entity.Coupon{Code: "X", IsActive: true, MinSpend: money.FromInt(60000)}, wantErr: "minimum spend"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := promotion.Validate(&tt.coupon, order, tt.userUses, now)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
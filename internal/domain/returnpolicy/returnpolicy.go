@@ -0,0 +1,65 @@
+// Package returnpolicy decides how long after delivery an order item may be
+// returned.
+package returnpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Policy holds return windows in days. A product listed in
+// ProductWindowDays uses its own window instead of DefaultWindowDays; a window
+// of zero makes the product non-returnable.
+type Policy struct {
+	DefaultWindowDays int            `json:"default_window_days"`
+	ProductWindowDays map[string]int `json:"product_window_days"`
+}
+
+// Load reads a policy file. Without a file every product gets
+// defaultWindowDays; a file that omits default_window_days also falls back to
+// it.
+func Load(path string, defaultWindowDays int) (*Policy, error) {
+	policy := &Policy{DefaultWindowDays: defaultWindowDays}
+	if path == "" {
+		return policy, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read return policy file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse return policy file: %w", err)
+	}
+
+	if policy.DefaultWindowDays < 0 {
+		return nil, fmt.Errorf("return policy file has a negative default window")
+	}
+
+	for productID, days := range policy.ProductWindowDays {
+		if days < 0 {
+			return nil, fmt.Errorf("return policy file has a negative window for product %q", productID)
+		}
+	}
+
+	return policy, nil
+}
+
+// Deadline returns the last moment an item of productID delivered at
+// deliveredAt can be returned. It reports false when the product cannot be
+// returned at all.
+func (p *Policy) Deadline(productID string, deliveredAt time.Time) (time.Time, bool) {
+	days := p.DefaultWindowDays
+	if d, ok := p.ProductWindowDays[productID]; ok {
+		days = d
+	}
+
+	if days <= 0 {
+		return time.Time{}, false
+	}
+
+	return deliveredAt.AddDate(0, 0, days), true
+}
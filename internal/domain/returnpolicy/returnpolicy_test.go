@@ -0,0 +1,51 @@
+package returnpolicy_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"order-service/internal/domain/returnpolicy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Deadline(t *testing.T) {
+	deliveredAt := time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC)
+	policy := &returnpolicy.Policy{
+		DefaultWindowDays: 30,
+		ProductWindowDays: map[string]int{"101": 7, "102": 0},
+	}
+
+	deadline, ok := policy.Deadline("100", deliveredAt)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC), deadline)
+
+	deadline, ok = policy.Deadline("101", deliveredAt)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 2, 7, 10, 0, 0, 0, time.UTC), deadline)
+
+	_, ok = policy.Deadline("102", deliveredAt)
+	assert.False(t, ok)
+}
+
+func TestLoad(t *testing.T) {
+	policy, err := returnpolicy.Load("", 14)
+	require.NoError(t, err)
+	assert.Equal(t, 14, policy.DefaultWindowDays)
+
+	path := filepath.Join(t.TempDir(), "returns.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"product_window_days": {"101": 7}}`), 0o600))
+
+	policy, err = returnpolicy.Load(path, 14)
+	require.NoError(t, err)
+	assert.Equal(t, 14, policy.DefaultWindowDays)
+	assert.Equal(t, 7, policy.ProductWindowDays["101"])
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"product_window_days": {"101": -1}}`), 0o600))
+
+	_, err = returnpolicy.Load(path, 14)
+	assert.Error(t, err)
+}
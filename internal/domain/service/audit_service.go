@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"order-service/constant"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/domain/audit"
+	"order-service/internal/domain/entity"
+)
+
+var _ AuditService = (*auditService)(nil)
+
+type AuditService interface {
+	Find(ctx context.Context, filter *postgresrepository.FilterAuditLogPayload) ([]*entity.AuditLog, int, error)
+}
+
+type auditService struct {
+	Properties
+}
+
+func NewAuditService(props Properties) *auditService {
+	return &auditService{
+		Properties: props,
+	}
+}
+
+func (s *auditService) Find(ctx context.Context, filter *postgresrepository.FilterAuditLogPayload) ([]*entity.AuditLog, int, error) {
+	return s.Repo.Postgres().Audit().Find(ctx, filter)
+}
+
+// recordAudit writes an audit entry for a change of an entity from before
+// to after, attributed to the request in ctx. It must be called with the
+// repository of the transaction making the change, so the entry is kept if
+// and only if the change is. before is nil for a creation and after for a
+// deletion; an entity changed in place is passed as its audit.Snapshot.
+func recordAudit(ctx context.Context, r postgresrepository.PostgresRepository, action constant.AuditAction, entityType constant.AuditEntityType, entityID uint32, before, after any) error {
+	changes, err := audit.Diff(before, after)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	req := audit.FromContext(ctx)
+
+	return r.Audit().Create(ctx, &entity.AuditLog{
+		ActorType:  req.Actor.Type,
+		ActorID:    req.Actor.ID,
+		RequestID:  req.RequestID,
+		IP:         req.IP,
+		UserAgent:  req.UserAgent,
+		Action:     string(action),
+		EntityType: string(entityType),
+		EntityID:   entityID,
+		Changes:    raw,
+	})
+}
@@ -0,0 +1,66 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"order-service/constant"
+	"order-service/internal/domain/audit"
+	"order-service/internal/domain/entity"
+	"order-service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// withAuditLog accepts every audit entry the service writes.
+func withAuditLog(t *testing.T, mPostgres *mocks.MockPostgresRepository) {
+	mAudit := mocks.NewMockAuditRepository(t)
+
+	mPostgres.EXPECT().Audit().Return(mAudit).Maybe()
+	mAudit.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Maybe()
+}
+
+func TestOrderService_Cancel_RecordsAuditEntry(t *testing.T) {
+	s, _, mPostgres, mOrder, mInventory := setupOrderTest(t)
+	ctx := audit.WithRequest(context.Background(), audit.Request{
+		Actor:     audit.Actor{Type: audit.ActorUser, ID: "1"},
+		RequestID: "req-1",
+		IP:        "203.0.113.7",
+	})
+
+	mOrder.EXPECT().FindByID(ctx, uint32(1)).Return(&entity.Order{
+		Base:   entity.Base{ID: 1},
+		Status: string(constant.OrderStatusConfirmed),
+	}, nil)
+	mOrder.EXPECT().
+		TransitionStatus(ctx, uint32(1), time.Time{}, string(constant.OrderStatusConfirmed), string(constant.OrderStatusCancelled)).
+		Return(true, nil)
+	mOrder.EXPECT().
+		SetCancelled(ctx, uint32(1), string(constant.CancellationReasonRequested), mock.Anything).
+		Return(nil)
+	mInventory.EXPECT().UpdateReservationStatus(ctx, mock.Anything).Return(&emptypb.Empty{}, nil).Maybe()
+
+	err := s.Cancel(ctx, 1)
+	assert.NoError(t, err)
+
+	mAudit := mPostgres.Audit().(*mocks.MockAuditRepository)
+	mAudit.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(entry *entity.AuditLog) bool {
+		var changes map[string]audit.Change
+		if err := json.Unmarshal(entry.Changes, &changes); err != nil {
+			return false
+		}
+
+		return entry.ActorType == audit.ActorUser &&
+			entry.ActorID == "1" &&
+			entry.RequestID == "req-1" &&
+			entry.IP == "203.0.113.7" &&
+			entry.Action == string(constant.AuditActionOrderCancel) &&
+			entry.EntityType == string(constant.AuditEntityOrder) &&
+			entry.EntityID == 1 &&
+			changes["Status"] == audit.Change{Before: string(constant.OrderStatusConfirmed), After: string(constant.OrderStatusCancelled)}
+	}))
+}
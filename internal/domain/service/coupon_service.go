@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"order-service/constant"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/promotion"
+	"order-service/internal/shared/exception"
+)
+
+var _ CouponService = (*couponService)(nil)
+
+type CouponService interface {
+	FindByID(ctx context.Context, id uint32) (*entity.Coupon, error)
+	Find(ctx context.Context, filter *postgresrepository.FilterCouponPayload) ([]*entity.Coupon, int, error)
+	Create(ctx context.Context, coupon *entity.Coupon) (*entity.Coupon, error)
+	Update(ctx context.Context, coupon *entity.Coupon) (*entity.Coupon, error)
+	Delete(ctx context.Context, id uint32) error
+}
+
+type couponService struct {
+	Properties
+}
+
+func NewCouponService(props Properties) *couponService {
+	return &couponService{
+		Properties: props,
+	}
+}
+
+func (s *couponService) FindByID(ctx context.Context, id uint32) (*entity.Coupon, error) {
+	return findCoupon(ctx, s.Repo.Postgres(), id)
+}
+
+func findCoupon(ctx context.Context, r postgresrepository.PostgresRepository, id uint32) (*entity.Coupon, error) {
+	coupon, err := r.Coupon().FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if coupon == nil {
+		return nil, exception.New(exception.TypeNotFound, exception.CodeNotFound, "coupon not found")
+	}
+
+	return coupon, nil
+}
+
+func (s *couponService) Find(ctx context.Context, filter *postgresrepository.FilterCouponPayload) ([]*entity.Coupon, int, error) {
+	return s.Repo.Postgres().Coupon().Find(ctx, filter)
+}
+
+func (s *couponService) Create(ctx context.Context, coupon *entity.Coupon) (*entity.Coupon, error) {
+	coupon.Code = promotion.NormalizeCode(coupon.Code)
+
+	if err := promotion.CheckRules(coupon); err != nil {
+		return nil, err
+	}
+
+	var created *entity.Coupon
+
+	err := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		if err := ensureCodeAvailable(ctx, r, coupon); err != nil {
+			return err
+		}
+
+		var err error
+		if created, err = r.Coupon().Create(ctx, coupon); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, r, constant.AuditActionCouponCreate, constant.AuditEntityCoupon, created.ID, nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *couponService) Update(ctx context.Context, coupon *entity.Coupon) (*entity.Coupon, error) {
+	if err := promotion.CheckRules(coupon); err != nil {
+		return nil, err
+	}
+
+	coupon.Code = promotion.NormalizeCode(coupon.Code)
+
+	var updated *entity.Coupon
+
+	err := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		existing, err := findCoupon(ctx, r, coupon.ID)
+		if err != nil {
+			return err
+		}
+
+		coupon.UsedCount = existing.UsedCount
+		coupon.CreatedAt = existing.CreatedAt
+
+		if err := ensureCodeAvailable(ctx, r, coupon); err != nil {
+			return err
+		}
+
+		if updated, err = r.Coupon().Update(ctx, coupon); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, r, constant.AuditActionCouponUpdate, constant.AuditEntityCoupon, coupon.ID, existing, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *couponService) Delete(ctx context.Context, id uint32) error {
+	return s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		existing, err := findCoupon(ctx, r, id)
+		if err != nil {
+			return err
+		}
+
+		if err := r.Coupon().Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, r, constant.AuditActionCouponDelete, constant.AuditEntityCoupon, id, existing, nil)
+	})
+}
+
+func ensureCodeAvailable(ctx context.Context, r postgresrepository.PostgresRepository, coupon *entity.Coupon) error {
+	existing, err := r.Coupon().FindByCode(ctx, coupon.Code)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != coupon.ID {
+		return exception.Newf(exception.TypeConflict, exception.CodeDuplicateResource, "coupon code %s already exists", coupon.Code)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"order-service/pkg/bundb"
+	"order-service/pkg/bundb/querystats"
+)
+
+var _ DatabaseService = (*databaseService)(nil)
+
+type DatabaseService interface {
+	PoolStats() []bundb.PoolStats
+	QueryStats(key querystats.SortKey, limit int) querystats.Report
+	ResetQueryStats()
+}
+
+type databaseService struct {
+	Properties
+}
+
+func NewDatabaseService(props Properties) *databaseService {
+	return &databaseService{
+		Properties: props,
+	}
+}
+
+// PoolStats returns the connection pool statistics of the primary followed
+// by those of the read replicas.
+func (s *databaseService) PoolStats() []bundb.PoolStats {
+	return s.Repo.Postgres().PoolStats()
+}
+
+// QueryStats returns the stats of at most limit query fingerprints, largest
+// first by key.
+func (s *databaseService) QueryStats(key querystats.SortKey, limit int) querystats.Report {
+	recorder := s.Repo.Postgres().QueryStats()
+	if recorder == nil {
+		return querystats.Report{}
+	}
+
+	return recorder.Report(key, limit)
+}
+
+// ResetQueryStats starts collecting query stats afresh, e.g. after an index
+// was added.
+func (s *databaseService) ResetQueryStats() {
+	if recorder := s.Repo.Postgres().QueryStats(); recorder != nil {
+		recorder.Reset()
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/domain/entity"
+	"order-service/internal/jobqueue"
+)
+
+// JobTypeReleaseReservations releases stock reservations that could not be
+// released right away, e.g. while the inventory service was unavailable.
+const JobTypeReleaseReservations = "inventory.release_reservations"
+
+// JobTypeSyncReservation resizes the reservation of an order item to its
+// remaining units after resizing it failed.
+const JobTypeSyncReservation = "inventory.sync_reservation"
+
+// JobTypeDeliverWebhook posts a webhook delivery to its subscriber.
+const JobTypeDeliverWebhook = "webhook.deliver"
+
+// JobTypeProcessRefund sends a requested refund to the payment provider.
+const JobTypeProcessRefund = "payment.process_refund"
+
+// JobTypeRestockReturn puts the goods of a received return back in stock.
+const JobTypeRestockReturn = "inventory.restock_return"
+
+type releaseReservationsPayload struct {
+	ReservationIDs []uint32 `json:"reservation_ids"`
+}
+
+type syncReservationPayload struct {
+	OrderID     uint32 `json:"order_id"`
+	OrderItemID uint32 `json:"order_item_id"`
+}
+
+type deliverWebhookPayload struct {
+	DeliveryID uint32 `json:"delivery_id"`
+}
+
+type processRefundPayload struct {
+	RefundID uint32 `json:"refund_id"`
+}
+
+type restockReturnPayload struct {
+	ReturnID uint32 `json:"return_id"`
+}
+
+// RegisterJobHandlers registers the handlers of the jobs the services queue.
+func (s *service) RegisterJobHandlers(q *jobqueue.Queue) {
+	jobqueue.Register(q, JobTypeReleaseReservations, func(ctx context.Context, payload releaseReservationsPayload) error {
+		return releaseReservations(ctx, s.Properties, payload.ReservationIDs)
+	})
+
+	jobqueue.Register(q, JobTypeSyncReservation, func(ctx context.Context, payload syncReservationPayload) error {
+		return s.orderService.SyncReservation(ctx, payload.OrderID, payload.OrderItemID)
+	})
+
+	// The delivery handler needs the job's attempt count to know whether a
+	// failure is final, so it decodes the payload itself.
+	q.Handle(JobTypeDeliverWebhook, func(ctx context.Context, job *entity.Job) error {
+		var payload deliverWebhookPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return jobqueue.Permanent(fmt.Errorf("failed to decode payload: %w", err))
+		}
+
+		return s.webhookService.Deliver(ctx, payload.DeliveryID, q.IsLastAttempt(job))
+	})
+
+	// Like deliveries, refunds are marked failed on their last attempt.
+	q.Handle(JobTypeProcessRefund, func(ctx context.Context, job *entity.Job) error {
+		var payload processRefundPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return jobqueue.Permanent(fmt.Errorf("failed to decode payload: %w", err))
+		}
+
+		return s.refundService.Process(ctx, payload.RefundID, q.IsLastAttempt(job))
+	})
+
+	jobqueue.Register(q, JobTypeRestockReturn, func(ctx context.Context, payload restockReturnPayload) error {
+		return s.returnService.Restock(ctx, payload.ReturnID)
+	})
+}
+
+func enqueueJob(ctx context.Context, r postgresrepository.PostgresRepository, jobType string, payload any, opts ...jobqueue.Option) error {
+	job, err := jobqueue.NewJob(jobType, payload, opts...)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Job().Create(ctx, job)
+
+	return err
+}
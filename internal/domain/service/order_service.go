@@ -2,26 +2,49 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"math/big"
 	"order-service/constant"
+	"order-service/internal/adapter/fxrate"
 	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/adapter/shipping"
+	"order-service/internal/adapter/tax"
+	"order-service/internal/domain/audit"
 	"order-service/internal/domain/entity"
+	"order-service/internal/domain/promotion"
 	"order-service/internal/shared/exception"
+	"order-service/pkg/money"
 	"order-service/proto/pb"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
 )
 
 var _ OrderService = (*orderService)(nil)
 
 type OrderService interface {
 	FindByID(ctx context.Context, id uint32) (*entity.Order, error)
-	Find(ctx context.Context, userID uint32, page, perPage int) ([]*entity.Order, int, error)
+	Find(ctx context.Context, filter *postgresrepository.FilterOrderPayload) ([]*entity.Order, int, error)
 	Create(ctx context.Context, order *entity.Order) (*entity.Order, error)
 	Cancel(ctx context.Context, id uint32) error
+	ExpirePending(ctx context.Context, placedBefore time.Time, limit int) (int, error)
+	CancelItem(ctx context.Context, orderID, itemID uint32, quantity int) (*entity.Order, error)
+	UpdateItems(ctx context.Context, orderID uint32, changes []*entity.OrderItem) (*entity.Order, error)
+	SyncReservation(ctx context.Context, orderID, itemID uint32) error
+	Deliver(ctx context.Context, id uint32) error
+	Delete(ctx context.Context, id uint32) error
+	Restore(ctx context.Context, id uint32) (*entity.Order, error)
 }
 
 type orderService struct {
 	Properties
 }
 
+// errOrderTotalTooLarge rejects an order whose amounts do not fit in Money.
+var errOrderTotalTooLarge = exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "order total is too large")
+
 func NewOrderService(props Properties) *orderService {
 	return &orderService{
 		Properties: props,
@@ -40,12 +63,22 @@ func (s *orderService) FindByID(ctx context.Context, id uint32) (*entity.Order,
 	return order, nil
 }
 
-func (s *orderService) Find(ctx context.Context, userID uint32, page, perPage int) ([]*entity.Order, int, error) {
-	orders, total, err := s.Repo.Postgres().Order().Find(ctx, &postgresrepository.FilterOrderPayload{
-		UserID:  userID,
-		Page:    page,
-		PerPage: perPage,
-	})
+// findByKey is FindByID for callers that already know when the order was
+// created, which lets the lookup skip the other partitions.
+func (s *orderService) findByKey(ctx context.Context, id uint32, createdAt time.Time) (*entity.Order, error) {
+	order, err := s.Repo.Postgres().Order().FindByKey(ctx, id, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, exception.New(exception.TypeNotFound, "404", "order not found")
+	}
+
+	return order, nil
+}
+
+func (s *orderService) Find(ctx context.Context, filter *postgresrepository.FilterOrderPayload) ([]*entity.Order, int, error) {
+	orders, total, err := s.Repo.Postgres().Order().Find(ctx, filter)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -54,9 +87,19 @@ func (s *orderService) Find(ctx context.Context, userID uint32, page, perPage in
 }
 
 func (s *orderService) Create(ctx context.Context, order *entity.Order) (*entity.Order, error) {
-	var totalPrice float64
+	fx, err := s.resolveFXRate(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	var subtotal, baseSubtotal money.Money
 	for i, item := range order.Items {
-		product, err := s.InventoryServiceClient.GetProduct(ctx, &pb.GetProductRequest{Id: item.ID})
+		productID, err := strconv.ParseUint(item.ProductID, 10, 32)
+		if err != nil {
+			return nil, exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "invalid product id %q", item.ProductID)
+		}
+
+		product, err := s.InventoryServiceClient.GetProduct(ctx, &pb.GetProductRequest{Id: uint32(productID)})
 		if err != nil {
 			return nil, err
 		}
@@ -65,44 +108,1253 @@ func (s *orderService) Create(ctx context.Context, order *entity.Order) (*entity
 			return nil, exception.New(exception.TypeBadRequest, "400", "stock is not enough")
 		}
 
-		order.Items[i].Price = product.GetPrice()
-		order.Items[i].Subtotal = product.GetPrice() * float64(item.Quantity)
-		totalPrice += order.Items[i].Subtotal
+		// Inventory still reports prices as float64 in the base currency;
+		// convert them once, here, so every amount derived from them is exact
+		// decimal arithmetic. Each unit price is rounded to the order
+		// currency before it is multiplied by the quantity.
+		basePrice := money.FromFloat(product.GetPrice(), money.RoundHalfUp).RoundTo(order.BaseCurrency, money.RoundHalfUp)
+		price := fx.Rate.Convert(basePrice, order.Currency, money.RoundHalfUp)
+
+		order.Items[i].BasePrice = basePrice
+		order.Items[i].Price = price
+
+		// Quantities and prices come from outside, so their products and
+		// sums are checked rather than left to wrap around.
+		itemSubtotal, err := price.MulChecked(int64(item.Quantity))
+		if err != nil {
+			return nil, errOrderTotalTooLarge
+		}
+
+		itemBaseSubtotal, err := basePrice.MulChecked(int64(item.Quantity))
+		if err != nil {
+			return nil, errOrderTotalTooLarge
+		}
+
+		if subtotal, err = subtotal.AddChecked(itemSubtotal); err != nil {
+			return nil, errOrderTotalTooLarge
+		}
+
+		if baseSubtotal, err = baseSubtotal.AddChecked(itemBaseSubtotal); err != nil {
+			return nil, errOrderTotalTooLarge
+		}
+
+		order.Items[i].Subtotal = itemSubtotal
 	}
 
-	order.TotalPrice = totalPrice
-	order.Status = string(constant.OrderStatusConfirmed)
+	order.Subtotal = subtotal
+	order.Status = string(constant.OrderStatusPendingPayment)
+	order.CouponCodes = normalizeCouponCodes(order.CouponCodes)
 
-	createdOrder, err := s.Repo.Postgres().Order().Create(ctx, order)
+	shippingFee, err := s.quoteShipping(ctx, order, fx)
 	if err != nil {
 		return nil, err
 	}
 
+	var createdOrder *entity.Order
+
+	// Coupons are locked, checked and their usage reserved in the same
+	// transaction as the order insert, so concurrent checkouts cannot exceed
+	// a usage cap. The callback may be retried and must not keep state
+	// between attempts.
+	err = s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		coupons, err := s.lockCoupons(ctx, r, order)
+		if err != nil {
+			return err
+		}
+
+		order.Adjustments, err = promotion.Apply(order, coupons)
+		if err != nil {
+			return err
+		}
+
+		if shippingFee != nil {
+			order.Adjustments = append(order.Adjustments, shippingFee)
+		}
+
+		if err := s.applyTax(ctx, order); err != nil {
+			return err
+		}
+
+		recalculateTotals(order, baseSubtotal)
+
+		createdOrder, err = r.Order().Create(ctx, order)
+		if err != nil {
+			return err
+		}
+
+		if err := s.reserveCoupons(ctx, r, coupons, createdOrder); err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, r, constant.AuditActionOrderCreate, constant.AuditEntityOrder, createdOrder.ID, nil, createdOrder); err != nil {
+			return err
+		}
+
+		return publishOrderEvent(ctx, s.Properties, r, constant.WebhookEventOrderCreated, createdOrder)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reserveStock(ctx, createdOrder); err != nil {
+		return nil, err
+	}
+
+	// The order is already committed at this point, so a failure to open the
+	// first payment attempt is not returned; the client can retry it through
+	// the payments endpoint.
+	p, err := startPayment(ctx, s.Properties, createdOrder)
+	if err != nil {
+		s.log(ctx).Error().Err(err).Msgf("Failed to start payment for order %d", createdOrder.ID)
+	} else {
+		createdOrder.Payments = append(createdOrder.Payments, p)
+	}
+
 	return createdOrder, nil
 }
 
-func (s *orderService) Cancel(ctx context.Context, id uint32) error {
-	order, err := s.FindByID(ctx, id)
+// reserveStock reserves inventory for every item of a newly created order.
+// If a reservation fails, the ones already made are released and the order is
+// cancelled.
+func (s *orderService) reserveStock(ctx context.Context, order *entity.Order) error {
+	var reserved []uint32
+
+	for _, item := range order.Items {
+		err := reserveItem(ctx, s.Properties, item, item.Quantity)
+		if err == nil {
+			reserved = append(reserved, *item.ReservationID)
+
+			var swapped bool
+			swapped, err = s.Repo.Postgres().Order().SwapItemReservation(ctx, item.ID, nil, item.ReservationID)
+			if err == nil && !swapped {
+				err = errReservationChanged
+			}
+		}
+
+		if err != nil {
+			if err := releaseReservations(ctx, s.Properties, reserved); err != nil {
+				s.log(ctx).Error().Err(err).Msgf("Failed to release reservations of order %d", order.ID)
+			}
+
+			cancelErr := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+				return cancelOrder(ctx, s.Properties, r, order, string(constant.CancellationReasonStockUnavailable))
+			})
+			if cancelErr != nil {
+				s.log(ctx).Error().Err(cancelErr).Msgf("Failed to cancel order %d", order.ID)
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reserveItem reserves quantity units of the item's product and records the
+// reservation on the item.
+func reserveItem(ctx context.Context, props Properties, item *entity.OrderItem, quantity int) error {
+	productID, err := strconv.ParseUint(item.ProductID, 10, 32)
+	if err != nil {
+		return exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "invalid product id %q", item.ProductID)
+	}
+
+	reservation, err := props.InventoryServiceClient.CreateReservation(ctx, &pb.CreateReservationRequest{
+		ProductId: uint32(productID),
+		OrderId:   item.OrderID,
+		Quantity:  int32(quantity),
+	})
 	if err != nil {
 		return err
 	}
 
-	if order.Status != string(constant.OrderStatusConfirmed) {
-		return exception.New(exception.TypeBadRequest, "400", "order cannot be cancelled")
+	id := reservation.GetId()
+	item.ReservationID = &id
+
+	return nil
+}
+
+// errReservationChanged is returned by resizeReservation when the item's
+// reservation was replaced by someone else while it was being resized.
+var errReservationChanged = errors.New("reservation changed concurrently")
+
+// resizeReservation replaces the item's reservation with one for quantity
+// units, as the inventory service cannot resize a reservation in place. It
+// runs once the change that needs it has committed. The replacement is
+// recorded only if the item still holds the reservation it was loaded with,
+// and the old reservation is released only after that, so the item always
+// points at a reservation that exists.
+func resizeReservation(ctx context.Context, props Properties, item *entity.OrderItem, quantity int) error {
+	previous := item.ReservationID
+
+	item.ReservationID = nil
+	if quantity > 0 {
+		if err := reserveItem(ctx, props, item, quantity); err != nil {
+			item.ReservationID = previous
+			return err
+		}
 	}
 
-	var reservationIDs []uint32
-	for _, item := range order.Items {
-		reservationIDs = append(reservationIDs, item.ID)
+	next := item.ReservationID
+
+	swapped, err := props.Repo.Postgres().Order().SwapItemReservation(ctx, item.ID, previous, next)
+	if err == nil && !swapped {
+		err = errReservationChanged
+	}
+	if err != nil {
+		item.ReservationID = previous
+		if next != nil {
+			releaseReservationsLater(ctx, props, []uint32{*next})
+		}
+
+		return err
 	}
 
-	_, err = s.InventoryServiceClient.UpdateReservationStatus(ctx, &pb.UpdateReservationStatusRequest{
-		Ids:    reservationIDs,
+	if previous != nil {
+		releaseReservationsLater(ctx, props, []uint32{*previous})
+	}
+
+	return nil
+}
+
+// syncReservation resizes the item's reservation to its remaining units once
+// an order change has committed. The change stands if that fails; a job
+// retries it.
+func (s *orderService) syncReservation(ctx context.Context, orderID uint32, item *entity.OrderItem) {
+	if err := resizeReservation(ctx, s.Properties, item, item.Quantity-item.CancelledQuantity); err != nil {
+		s.log(ctx).Warn().Err(err).Msgf("Failed to resize reservation of order item %d, queueing a retry", item.ID)
+
+		payload := syncReservationPayload{OrderID: orderID, OrderItemID: item.ID}
+		if err := enqueueJob(ctx, s.Repo.Postgres(), JobTypeSyncReservation, payload); err != nil {
+			s.log(ctx).Error().Err(err).Msgf("Failed to queue resize of reservation of order item %d", item.ID)
+		}
+	}
+}
+
+// SyncReservation makes the reservation of an order item hold the item's
+// remaining units again after resizing it failed. Items of orders that no
+// longer hold stock are left alone, as is a reservation that is already
+// right, so the job that runs it can be retried.
+func (s *orderService) SyncReservation(ctx context.Context, orderID, itemID uint32) error {
+	order, err := s.Repo.Postgres().Order().FindByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if order == nil || (order.Status != string(constant.OrderStatusPendingPayment) && order.Status != string(constant.OrderStatusConfirmed)) {
+		return nil
+	}
+
+	// An item removed by an edit had its reservation released with it.
+	item := findOrderItem(order, itemID)
+	if item == nil {
+		return nil
+	}
+
+	quantity := item.Quantity - item.CancelledQuantity
+
+	if item.ReservationID == nil {
+		if quantity == 0 {
+			return nil
+		}
+	} else {
+		reservation, err := s.InventoryServiceClient.GetReservation(ctx, &pb.GetReservationRequest{Id: *item.ReservationID})
+		if err != nil {
+			return err
+		}
+
+		if reservation.GetStatus() != pb.ReservationStatus_RESERVATION_STATUS_CANCELLED && int(reservation.GetQuantity()) == quantity {
+			return nil
+		}
+	}
+
+	return resizeReservation(ctx, s.Properties, item, quantity)
+}
+
+func releaseReservations(ctx context.Context, props Properties, ids []uint32) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := props.InventoryServiceClient.UpdateReservationStatus(ctx, &pb.UpdateReservationStatusRequest{
+		Ids:    ids,
 		Status: pb.ReservationStatus_RESERVATION_STATUS_CANCELLED,
 	})
+
+	return err
+}
+
+// releaseReservationsLater releases reservations that a committed change no
+// longer needs. A failure is not returned but retried by a job.
+func releaseReservationsLater(ctx context.Context, props Properties, ids []uint32) {
+	if err := releaseReservations(ctx, props, ids); err != nil {
+		props.log(ctx).Warn().Err(err).Msgf("Failed to release reservations %v, queueing a retry", ids)
+
+		payload := releaseReservationsPayload{ReservationIDs: ids}
+		if err := enqueueJob(ctx, props.Repo.Postgres(), JobTypeReleaseReservations, payload); err != nil {
+			props.log(ctx).Error().Err(err).Msgf("Failed to queue release of reservations %v", ids)
+		}
+	}
+}
+
+// lockCoupons loads the order's coupons with a row lock and validates them
+// for the ordering user.
+func (s *orderService) lockCoupons(ctx context.Context, r postgresrepository.PostgresRepository, order *entity.Order) ([]*entity.Coupon, error) {
+	if len(order.CouponCodes) == 0 {
+		return nil, nil
+	}
+
+	coupons, err := r.Coupon().FindByCodesForUpdate(ctx, order.CouponCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool, len(coupons))
+	for _, c := range coupons {
+		found[c.Code] = true
+	}
+
+	for _, code := range order.CouponCodes {
+		if !found[code] {
+			return nil, exception.Newf(exception.TypeBadRequest, exception.CodeCouponInvalid, "coupon %s does not exist", code)
+		}
+	}
+
+	now := time.Now()
+
+	for _, c := range coupons {
+		uses, err := r.Coupon().CountUsagesByUser(ctx, c.ID, order.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := promotion.Validate(c, order, uses, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return coupons, nil
+}
+
+// reserveCoupons records one use of each coupon against order.
+func (s *orderService) reserveCoupons(ctx context.Context, r postgresrepository.PostgresRepository, coupons []*entity.Coupon, order *entity.Order) error {
+	for _, c := range coupons {
+		ok, err := r.Coupon().IncrementUsage(ctx, c.ID)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return exception.Newf(exception.TypeConflict, exception.CodeCouponUsageExceeded, "coupon %s has reached its usage limit", c.Code)
+		}
+
+		err = r.Coupon().CreateUsage(ctx, &entity.CouponUsage{
+			CouponID: c.ID,
+			UserID:   order.UserID,
+			OrderID:  order.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// quoteShipping resolves the order's shipping address against the
+// province/city/district hierarchy and returns the shipping fee line in the
+// order currency. Orders without an address are not charged for shipping.
+func (s *orderService) quoteShipping(ctx context.Context, order *entity.Order, fx *fxrate.FXRate) (*entity.OrderAdjustment, error) {
+	address := order.ShippingAddress
+	if address == nil {
+		return nil, nil
+	}
+
+	district, err := s.Repo.Postgres().Location().FindDistrictByID(ctx, address.DistrictID)
+	if err != nil {
+		return nil, err
+	}
+	if district == nil {
+		return nil, exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "district %d does not exist", address.DistrictID)
+	}
+
+	address.District = district
+
+	req := &shipping.Request{
+		Destination: shipping.Destination{
+			ProvinceID: district.City.ProvinceID,
+			CityID:     district.CityID,
+			DistrictID: district.ID,
+		},
+		Items: make([]shipping.Item, len(order.Items)),
+	}
+	for i, item := range order.Items {
+		req.Items[i] = shipping.Item{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+
+	fee, err := s.ShippingFeeCalculator.Calculate(ctx, req)
+	if err != nil {
+		if errors.Is(err, shipping.ErrNoRoute) {
+			return nil, exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "shipping to district %s is not available", district.Name)
+		}
+
+		return nil, err
+	}
+
+	return &entity.OrderAdjustment{
+		Type:        string(constant.AdjustmentTypeShipping),
+		Code:        string(constant.AdjustmentTypeShipping),
+		Description: fee.Description,
+		Amount:      fx.Rate.Convert(fee.Amount, order.Currency, money.RoundHalfUp),
+	}, nil
+}
+
+// applyTax computes tax on each item after its discounts and sets the item
+// and order tax fields.
+func (s *orderService) applyTax(ctx context.Context, order *entity.Order) error {
+	if order.TaxRegion == "" {
+		order.TaxRegion = s.Config.Tax.DefaultRegion
+	}
+
+	net := make(map[*entity.OrderItem]money.Money, len(order.Items))
+	for _, item := range order.Items {
+		net[item] = item.Subtotal
+	}
+
+	for _, adj := range order.Adjustments {
+		if adj.OrderItem != nil {
+			net[adj.OrderItem] = net[adj.OrderItem].Add(adj.Amount)
+		}
+	}
+
+	req := &tax.Request{Region: order.TaxRegion, Currency: order.Currency, Lines: make([]tax.Line, len(order.Items))}
+	for i, item := range order.Items {
+		req.Lines[i] = tax.Line{ProductID: item.ProductID, Amount: net[item]}
+	}
+
+	res, err := s.TaxCalculator.Calculate(ctx, req)
+	if err != nil {
+		if errors.Is(err, tax.ErrRateNotFound) {
+			return exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "tax region %s is not supported", order.TaxRegion)
+		}
+
+		return err
+	}
+
+	for i, item := range order.Items {
+		line := res.Lines[i]
+		item.TaxClass = line.Class
+		item.TaxRate = line.Rate
+		item.TaxableAmount = line.Taxable
+		item.TaxAmount = line.Amount
+	}
+
+	order.TaxInclusive = res.Inclusive
+	order.TaxTotal = res.Total
+
+	return nil
+}
+
+// recalculateTotals derives DiscountTotal, ShippingTotal, TotalPrice and
+// BaseTotalPrice from the order's Subtotal, Adjustments and TaxTotal.
+// baseSubtotal is the undiscounted total in the base currency; everything
+// added to it is converted back with the order's rate.
+func recalculateTotals(order *entity.Order, baseSubtotal money.Money) {
+	var adjustments, discounts, shippingFees money.Money
+	for _, adj := range order.Adjustments {
+		adjustments = adjustments.Add(adj.Amount)
+
+		switch adj.Type {
+		case string(constant.AdjustmentTypeDiscount), string(constant.AdjustmentTypeCancellation):
+			discounts = discounts.Add(adj.Amount)
+		case string(constant.AdjustmentTypeShipping):
+			shippingFees = shippingFees.Add(adj.Amount)
+		}
+	}
+
+	added := adjustments
+	if !order.TaxInclusive {
+		added = added.Add(order.TaxTotal)
+	}
+
+	order.DiscountTotal = discounts.Neg()
+	order.ShippingTotal = shippingFees
+	order.TotalPrice = order.Subtotal.Add(added)
+	order.BaseTotalPrice = baseSubtotal.Add(order.FXRate.Inverse().Convert(added, order.BaseCurrency, money.RoundHalfUp))
+}
+
+// activeBaseSubtotal is the undiscounted total of the units that have not
+// been cancelled, in the base currency.
+func activeBaseSubtotal(order *entity.Order) money.Money {
+	var total money.Money
+	for _, item := range order.Items {
+		total = total.Add(item.BasePrice.Mul(int64(item.Quantity - item.CancelledQuantity)))
+	}
+
+	return total
+}
+
+// normalizeCouponCodes normalizes codes and drops blanks and duplicates,
+// keeping the first occurrence.
+func normalizeCouponCodes(codes []string) []string {
+	var normalized []string
+
+	for _, code := range codes {
+		code = promotion.NormalizeCode(code)
+		if code == "" || slices.Contains(normalized, code) {
+			continue
+		}
+
+		normalized = append(normalized, code)
+	}
+
+	return normalized
+}
+
+// resolveFXRate defaults the order to the base currency and snapshots the
+// rate used to convert inventory prices into the order currency.
+func (s *orderService) resolveFXRate(ctx context.Context, order *entity.Order) (*fxrate.FXRate, error) {
+	order.BaseCurrency = s.Config.FX.BaseCurrency
+	if order.Currency == "" {
+		order.Currency = order.BaseCurrency
+	}
+
+	if !money.IsCurrencyCode(order.Currency) {
+		return nil, exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "invalid currency %q", order.Currency)
+	}
+
+	fx, err := s.FXRateProvider.GetRate(ctx, order.BaseCurrency, order.Currency)
+	if err != nil {
+		if errors.Is(err, fxrate.ErrRateNotFound) {
+			return nil, exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "currency %s is not supported", order.Currency)
+		}
+
+		return nil, err
+	}
+
+	order.FXRate = fx.Rate
+	order.FXRateSource = fx.Source
+	order.FXRateAt = fx.AsOf
+
+	return fx, nil
+}
+
+func (s *orderService) Cancel(ctx context.Context, id uint32) error {
+	order, err := s.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	return s.Repo.Postgres().Order().UpdateStatus(ctx, id, string(constant.OrderStatusCancelled))
+	if order.Status != string(constant.OrderStatusConfirmed) && order.Status != string(constant.OrderStatusPendingPayment) {
+		return exception.New(exception.TypeBadRequest, "400", "order cannot be cancelled")
+	}
+
+	return s.cancel(ctx, order, string(constant.CancellationReasonRequested))
+}
+
+// ExpirePending cancels up to limit orders that were placed before
+// placedBefore and are still awaiting payment, and returns how many were
+// cancelled. An order that cannot be cancelled, e.g. because it was paid in
+// the meantime, is skipped.
+func (s *orderService) ExpirePending(ctx context.Context, placedBefore time.Time, limit int) (int, error) {
+	orders, _, err := s.Repo.Postgres().Order().Find(ctx, &postgresrepository.FilterOrderPayload{
+		Status:        string(constant.OrderStatusPendingPayment),
+		CreatedBefore: placedBefore,
+		PerPage:       limit,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+
+	for _, order := range orders {
+		if err := s.cancel(ctx, order, string(constant.CancellationReasonExpired)); err != nil {
+			s.log(ctx).Warn().Err(err).Msgf("Failed to expire order %d", order.ID)
+			continue
+		}
+
+		expired++
+	}
+
+	return expired, nil
+}
+
+// cancel cancels a loaded order for reason, refunds whatever was paid and
+// releases its stock. The reservations are released only once the
+// cancellation has committed, so an order paid concurrently keeps its stock.
+func (s *orderService) cancel(ctx context.Context, order *entity.Order, reason string) error {
+	err := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		return cancelOrder(ctx, s.Properties, r, order, reason)
+	})
+	if err != nil {
+		return err
+	}
+
+	var reservationIDs []uint32
+	for _, item := range order.Items {
+		if item.ReservationID != nil {
+			reservationIDs = append(reservationIDs, *item.ReservationID)
+		}
+	}
+
+	// The order is cancelled at this point; a failure to release its stock is
+	// retried in the background rather than returned.
+	releaseReservationsLater(ctx, s.Properties, reservationIDs)
+
+	return nil
+}
+
+// CancelItem cancels quantity units of one order item. The units' price
+// leaves the order subtotal, their share of the item's discounts and tax goes
+// with them, their stock is released and, if the order is paid, what was paid
+// for them is refunded. Cancelling the last remaining unit cancels the order.
+func (s *orderService) CancelItem(ctx context.Context, orderID, itemID uint32, quantity int) (*entity.Order, error) {
+	var (
+		item           *entity.OrderItem
+		createdAt      time.Time
+		repricePending bool
+	)
+
+	// The order row lock serialises item cancellations of the same order, so
+	// each one sees the quantities left by the previous one. Like cancel, the
+	// transaction makes no inventory calls: it may be retried, so the
+	// reservation is only resized once it has committed.
+	err := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		repricePending = false
+
+		var err error
+		createdAt, err = r.Order().LockByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		order, err := r.Order().FindByKey(ctx, orderID, createdAt)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return exception.New(exception.TypeNotFound, exception.CodeNotFound, "order not found")
+		}
+
+		if order.Status != string(constant.OrderStatusConfirmed) && order.Status != string(constant.OrderStatusPendingPayment) {
+			return exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "order cannot be cancelled")
+		}
+
+		before, err := audit.Snapshot(order)
+		if err != nil {
+			return err
+		}
+
+		item = findOrderItem(order, itemID)
+		if item == nil {
+			return exception.Newf(exception.TypeNotFound, exception.CodeNotFound, "order item %d not found", itemID)
+		}
+
+		cancellable, err := unrefundedUnits(ctx, r, order, item)
+		if err != nil {
+			return err
+		}
+		if quantity <= 0 || quantity > cancellable {
+			return exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest,
+				"only %d of order item %d can still be cancelled", cancellable, item.ID)
+		}
+
+		adjustment, err := r.Order().CreateAdjustment(ctx, cancelUnits(order, item, quantity))
+		if err != nil {
+			return err
+		}
+
+		order.Adjustments = append(order.Adjustments, adjustment)
+		recalculateTotals(order, activeBaseSubtotal(order))
+
+		if err := r.Order().UpdateTotals(ctx, order); err != nil {
+			return err
+		}
+
+		switch {
+		case !hasRemainingUnits(order):
+			err = cancelOrder(ctx, s.Properties, r, order, string(constant.CancellationReasonItemsCancelled))
+		case order.Status == string(constant.OrderStatusPendingPayment):
+			repricePending, err = supersedePendingPayments(ctx, r, order)
+		default:
+			err = refundCancelledUnits(ctx, s.Properties, r, order, item, quantity)
+		}
+		if err != nil {
+			return err
+		}
+
+		if hasRemainingUnits(order) {
+			if err := publishOrderEvent(ctx, s.Properties, r, constant.WebhookEventOrderItemCancelled, order); err != nil {
+				return err
+			}
+		}
+
+		if err := r.Order().UpdateItem(ctx, item); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, r, constant.AuditActionOrderCancelItem, constant.AuditEntityOrder, order.ID, before, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.syncReservation(ctx, orderID, item)
+
+	order, err := s.findByKey(ctx, orderID, createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if repricePending {
+		p, err := startPayment(ctx, s.Properties, order)
+		if err != nil {
+			s.log(ctx).Error().Err(err).Msgf("Failed to start payment for order %d", orderID)
+		} else {
+			order.Payments = append(order.Payments, p)
+		}
+	}
+
+	return order, nil
+}
+
+// UpdateItems edits the items of an order that is still awaiting payment.
+// A change with an ID sets the quantity of that item, removing it at zero; a
+// change without one adds a line for its product. Changed lines are repriced
+// from inventory, discounts, shipping and tax are recalculated for the new
+// items and, once the edit has committed, their stock reservations are
+// adjusted. Open payment attempts are replaced by one for the new total.
+func (s *orderService) UpdateItems(ctx context.Context, orderID uint32, changes []*entity.OrderItem) (*entity.Order, error) {
+	var (
+		changed        []itemChange
+		removed        []*entity.OrderItem
+		createdAt      time.Time
+		repricePending bool
+	)
+
+	// Like item cancellations, edits take the order row lock so they are
+	// applied one at a time, and make no inventory calls that a retried
+	// transaction would repeat.
+	err := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		var err error
+		createdAt, err = r.Order().LockByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		order, err := r.Order().FindByKey(ctx, orderID, createdAt)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return exception.New(exception.TypeNotFound, exception.CodeNotFound, "order not found")
+		}
+
+		if order.Status != string(constant.OrderStatusPendingPayment) {
+			return exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "only orders awaiting payment can be edited")
+		}
+
+		for _, item := range order.Items {
+			if item.CancelledQuantity > 0 {
+				return exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "orders with cancelled items cannot be edited")
+			}
+		}
+
+		before, err := audit.Snapshot(order)
+		if err != nil {
+			return err
+		}
+
+		changed, removed, err = applyItemChanges(order, changes)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repriceItems(ctx, order, changed); err != nil {
+			return err
+		}
+
+		if err := s.recalculateOrder(ctx, r, order); err != nil {
+			return err
+		}
+
+		if err := r.Order().UpdateItems(ctx, order, removed); err != nil {
+			return err
+		}
+
+		if repricePending, err = supersedePendingPayments(ctx, r, order); err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, r, constant.AuditActionOrderUpdate, constant.AuditEntityOrder, order.ID, before, order); err != nil {
+			return err
+		}
+
+		return publishOrderEvent(ctx, s.Properties, r, constant.WebhookEventOrderUpdated, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Stock was checked while repricing, so replacing the reservations should
+	// succeed; if it does not, a job retries it.
+	for _, c := range changed {
+		s.syncReservation(ctx, orderID, c.item)
+	}
+
+	var released []uint32
+	for _, item := range removed {
+		if item.ReservationID != nil {
+			released = append(released, *item.ReservationID)
+		}
+	}
+
+	releaseReservationsLater(ctx, s.Properties, released)
+
+	order, err := s.findByKey(ctx, orderID, createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if repricePending {
+		p, err := startPayment(ctx, s.Properties, order)
+		if err != nil {
+			s.log(ctx).Error().Err(err).Msgf("Failed to start payment for order %d", orderID)
+		} else {
+			order.Payments = append(order.Payments, p)
+		}
+	}
+
+	return order, nil
+}
+
+// itemChange is an added or changed order item and the quantity it had
+// before the edit.
+type itemChange struct {
+	item     *entity.OrderItem
+	previous int
+}
+
+// applyItemChanges applies changes to the order's items and returns the added
+// or changed items and the removed ones.
+func applyItemChanges(order *entity.Order, changes []*entity.OrderItem) ([]itemChange, []*entity.OrderItem, error) {
+	var (
+		changed []itemChange
+		removed []*entity.OrderItem
+	)
+
+	seen := make(map[uint32]bool, len(changes))
+
+	for _, change := range changes {
+		if change.Quantity < 0 {
+			return nil, nil, exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "quantity must not be negative")
+		}
+
+		if change.ID == 0 {
+			if change.Quantity == 0 {
+				return nil, nil, exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "quantity of new product %s must be positive", change.ProductID)
+			}
+
+			item := &entity.OrderItem{OrderID: order.ID, ProductID: change.ProductID, Quantity: change.Quantity}
+			order.Items = append(order.Items, item)
+			changed = append(changed, itemChange{item: item})
+
+			continue
+		}
+
+		if seen[change.ID] {
+			return nil, nil, exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "order item %d is changed more than once", change.ID)
+		}
+		seen[change.ID] = true
+
+		item := findOrderItem(order, change.ID)
+		if item == nil {
+			return nil, nil, exception.Newf(exception.TypeNotFound, exception.CodeNotFound, "order item %d not found", change.ID)
+		}
+
+		switch {
+		case change.Quantity == 0:
+			removed = append(removed, item)
+		case change.Quantity != item.Quantity:
+			changed = append(changed, itemChange{item: item, previous: item.Quantity})
+			item.Quantity = change.Quantity
+		}
+	}
+
+	order.Items = slices.DeleteFunc(order.Items, func(item *entity.OrderItem) bool {
+		return slices.Contains(removed, item)
+	})
+
+	if len(order.Items) == 0 {
+		return nil, nil, exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "an order must keep at least one item, cancel it instead")
+	}
+
+	return changed, removed, nil
+}
+
+// repriceItems prices changed items at the current inventory price, converted
+// with the order's rate, and checks that inventory can cover the extra units
+// they need.
+func (s *orderService) repriceItems(ctx context.Context, order *entity.Order, changed []itemChange) error {
+	for _, c := range changed {
+		item := c.item
+
+		productID, err := strconv.ParseUint(item.ProductID, 10, 32)
+		if err != nil {
+			return exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "invalid product id %q", item.ProductID)
+		}
+
+		product, err := s.InventoryServiceClient.GetProduct(ctx, &pb.GetProductRequest{Id: uint32(productID)})
+		if err != nil {
+			return err
+		}
+
+		extra := item.Quantity - c.previous
+		if extra > 0 && product.GetStock() < int32(extra) {
+			return exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest,
+				"stock is not enough for product %s: %d more requested, %d available", item.ProductID, extra, product.GetStock())
+		}
+
+		item.BasePrice = money.FromFloat(product.GetPrice(), money.RoundHalfUp).RoundTo(order.BaseCurrency, money.RoundHalfUp)
+		item.Price = order.FXRate.Convert(item.BasePrice, order.Currency, money.RoundHalfUp)
+		item.Subtotal = item.Price.Mul(int64(item.Quantity))
+	}
+
+	return nil
+}
+
+// recalculateOrder recomputes the discounts, shipping fee, tax and totals of
+// an edited order. Coupons applied at checkout stay applied; their usage has
+// already been counted, so only their minimum spend is checked again.
+func (s *orderService) recalculateOrder(ctx context.Context, r postgresrepository.PostgresRepository, order *entity.Order) error {
+	var subtotal money.Money
+	for _, item := range order.Items {
+		subtotal = subtotal.Add(item.Subtotal)
+	}
+
+	order.Subtotal = subtotal
+
+	coupons, err := appliedCoupons(ctx, r, order)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range coupons {
+		if err := promotion.CheckMinSpend(c, order); err != nil {
+			return err
+		}
+	}
+
+	adjustments, err := promotion.Apply(order, coupons)
+	if err != nil {
+		return err
+	}
+
+	shippingFee, err := s.quoteShipping(ctx, order, &fxrate.FXRate{Rate: order.FXRate})
+	if err != nil {
+		return err
+	}
+
+	if shippingFee != nil {
+		adjustments = append(adjustments, shippingFee)
+	}
+
+	order.Adjustments = adjustments
+
+	if err := s.applyTax(ctx, order); err != nil {
+		return err
+	}
+
+	recalculateTotals(order, activeBaseSubtotal(order))
+
+	return nil
+}
+
+// appliedCoupons loads, with a row lock, the coupons behind the order's
+// discounts.
+func appliedCoupons(ctx context.Context, r postgresrepository.PostgresRepository, order *entity.Order) ([]*entity.Coupon, error) {
+	var codes []string
+	for _, adj := range order.Adjustments {
+		if adj.CouponID != nil && !slices.Contains(codes, adj.Code) {
+			codes = append(codes, adj.Code)
+		}
+	}
+
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	coupons, err := r.Coupon().FindByCodesForUpdate(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range codes {
+		if !slices.ContainsFunc(coupons, func(c *entity.Coupon) bool { return c.Code == code }) {
+			return nil, exception.Newf(exception.TypeBadRequest, exception.CodeCouponInvalid, "coupon %s applied to this order no longer exists", code)
+		}
+	}
+
+	return coupons, nil
+}
+
+// cancelUnits takes quantity units of item out of the order's subtotal and
+// tax total, and returns the adjustment that gives back their share of the
+// item's discounts. Shares are computed cumulatively, like item refunds, so
+// cancelling a line in several parts adds up to exactly what it was priced
+// at.
+func cancelUnits(order *entity.Order, item *entity.OrderItem, quantity int) *entity.OrderAdjustment {
+	places := money.MinorUnits(order.Currency)
+	done := item.CancelledQuantity
+
+	share := func(amount money.Money) money.Money {
+		upTo := amount.MulRat(big.NewRat(int64(done+quantity), int64(item.Quantity)), places, money.RoundHalfUp)
+		before := amount.MulRat(big.NewRat(int64(done), int64(item.Quantity)), places, money.RoundHalfUp)
+
+		return upTo.Sub(before)
+	}
+
+	gross := item.Price.Mul(int64(quantity))
+	taxAmount := share(item.TaxAmount)
+
+	net := share(item.TaxableAmount)
+	if order.TaxInclusive {
+		net = net.Add(taxAmount)
+	}
+
+	item.CancelledQuantity += quantity
+	order.Subtotal = order.Subtotal.Sub(gross)
+	order.TaxTotal = order.TaxTotal.Sub(taxAmount)
+
+	return &entity.OrderAdjustment{
+		OrderID:     order.ID,
+		OrderItemID: &item.ID,
+		Type:        string(constant.AdjustmentTypeCancellation),
+		Code:        string(constant.AdjustmentTypeCancellation),
+		Description: fmt.Sprintf("Cancelled %d x product %s", quantity, item.ProductID),
+		Amount:      gross.Sub(net),
+	}
+}
+
+func hasRemainingUnits(order *entity.Order) bool {
+	for _, item := range order.Items {
+		if item.CancelledQuantity < item.Quantity {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cancelOrder moves the order to CANCELLED for reason and reserves a refund of
+// whatever has not been refunded yet. The status only changes if it is still the one
+// the order was loaded with: a payment confirmed in the meantime must not be
+// cancelled without a refund.
+func cancelOrder(ctx context.Context, props Properties, r postgresrepository.PostgresRepository, order *entity.Order, reason string) error {
+	cancelled, err := r.Order().TransitionStatus(ctx, order.ID, order.CreatedAt, order.Status, string(constant.OrderStatusCancelled))
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return exception.New(exception.TypeConflict, exception.CodeConflict, "order status changed, please retry")
+	}
+
+	now := time.Now()
+	if err := r.Order().SetCancelled(ctx, order.ID, reason, now); err != nil {
+		return err
+	}
+
+	// The event describes the cancelled order, but the loaded one must keep
+	// its status in case the transaction is retried.
+	event := *order
+	event.Status = string(constant.OrderStatusCancelled)
+	event.CancellationReason = reason
+	event.CancelledAt = &now
+
+	if err := recordAudit(ctx, r, constant.AuditActionOrderCancel, constant.AuditEntityOrder, order.ID, order, &event); err != nil {
+		return err
+	}
+
+	if err := publishOrderEvent(ctx, props, r, constant.WebhookEventOrderCancelled, &event); err != nil {
+		return err
+	}
+
+	for _, p := range order.Payments {
+		if p.Status != string(constant.PaymentStatusSucceeded) {
+			continue
+		}
+
+		_, err := reserveRefund(ctx, props, r, order, p.ID, &entity.Refund{Reason: "order cancelled"})
+		if err != nil && !errors.Is(err, errNothingToRefund) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// refundCancelledUnits reserves a refund of the cancelled units of item if the
+// order has been paid.
+func refundCancelledUnits(ctx context.Context, props Properties, r postgresrepository.PostgresRepository, order *entity.Order, item *entity.OrderItem, quantity int) error {
+	p := capturedPayment(order)
+	if p == nil {
+		return nil
+	}
+
+	// The units are refunded what cancelUnits took off the order for them.
+	// They no longer count as units of the line, so the refund is for an
+	// amount rather than for the items.
+	places := money.MinorUnits(order.Currency)
+	amount := unitsCost(item, item.CancelledQuantity, places).Sub(unitsCost(item, item.CancelledQuantity-quantity, places))
+
+	_, err := reserveRefund(ctx, props, r, order, p.ID, &entity.Refund{
+		Reason: fmt.Sprintf("%d x order item %d cancelled", quantity, item.ID),
+		Amount: amount,
+	})
+
+	return err
+}
+
+// unrefundedUnits returns how many of the item's remaining units have not
+// been refunded on their own. Refunded units cannot be cancelled, which would
+// refund them a second time.
+func unrefundedUnits(ctx context.Context, r postgresrepository.PostgresRepository, order *entity.Order, item *entity.OrderItem) (int, error) {
+	remaining := item.Quantity - item.CancelledQuantity
+
+	p := capturedPayment(order)
+	if p == nil {
+		return remaining, nil
+	}
+
+	refunds, err := r.Refund().FindByPaymentID(ctx, p.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	_, refundedQty := refundedSoFar(refunds)
+
+	return max(remaining-refundedQty[item.ID], 0), nil
+}
+
+func capturedPayment(order *entity.Order) *entity.Payment {
+	for _, p := range order.Payments {
+		if p.Status == string(constant.PaymentStatusSucceeded) {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// Deliver marks a confirmed order as delivered, which opens the return window
+// of its items.
+func (s *orderService) Deliver(ctx context.Context, id uint32) error {
+	return s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		createdAt, err := r.Order().LockByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		delivered, err := r.Order().TransitionStatus(ctx, id, createdAt, string(constant.OrderStatusConfirmed), string(constant.OrderStatusDelivered))
+		if err != nil {
+			return err
+		}
+		if !delivered {
+			return exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "only confirmed orders can be delivered")
+		}
+
+		if err := r.Order().SetDeliveredAt(ctx, id, time.Now()); err != nil {
+			return err
+		}
+
+		order, err := r.Order().FindByKey(ctx, id, createdAt)
+		if err != nil {
+			return err
+		}
+
+		before := *order
+		before.Status = string(constant.OrderStatusConfirmed)
+		before.DeliveredAt = nil
+
+		if err := recordAudit(ctx, r, constant.AuditActionOrderDeliver, constant.AuditEntityOrder, id, &before, order); err != nil {
+			return err
+		}
+
+		return publishOrderEvent(ctx, s.Properties, r, constant.WebhookEventOrderDelivered, order)
+	})
+}
+
+// Delete soft-deletes a closed order. It is hidden from every lookup but the
+// admin listing until it is restored.
+func (s *orderService) Delete(ctx context.Context, id uint32) error {
+	return s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		createdAt, err := r.Order().LockByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		order, err := r.Order().FindByKey(ctx, id, createdAt)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return exception.New(exception.TypeNotFound, exception.CodeNotFound, "order not found")
+		}
+
+		if !slices.Contains(constant.ClosedOrderStatuses, order.Status) {
+			return exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "only delivered, rejected or cancelled orders can be deleted")
+		}
+
+		if err := r.Order().Delete(ctx, id); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		deleted := *order
+		deleted.DeletedAt = &now
+
+		return recordAudit(ctx, r, constant.AuditActionOrderDelete, constant.AuditEntityOrder, id, order, &deleted)
+	})
+}
+
+// Restore undeletes a deleted order.
+func (s *orderService) Restore(ctx context.Context, id uint32) (*entity.Order, error) {
+	var order *entity.Order
+
+	err := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		orders, _, err := r.Order().Find(ctx, &postgresrepository.FilterOrderPayload{
+			IDs:            []uint32{id},
+			IncludeDeleted: true,
+		})
+		if err != nil {
+			return err
+		}
+		if len(orders) == 0 {
+			return exception.New(exception.TypeNotFound, exception.CodeNotFound, "order not found")
+		}
+
+		deleted := orders[0]
+		if deleted.DeletedAt == nil {
+			return exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "order is not deleted")
+		}
+
+		restored, err := r.Order().Restore(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !restored {
+			return exception.New(exception.TypeConflict, exception.CodeConflict, "order changed, please retry")
+		}
+
+		order, err = r.Order().FindByKey(ctx, id, deleted.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, r, constant.AuditActionOrderRestore, constant.AuditEntityOrder, id, deleted, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
 }
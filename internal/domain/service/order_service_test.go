@@ -2,12 +2,22 @@ package service_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"order-service/config"
 	"order-service/constant"
+	"order-service/internal/adapter/fxrate"
+	"order-service/internal/adapter/payment"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/adapter/shipping"
+	"order-service/internal/adapter/tax"
 	"order-service/internal/domain/entity"
 	"order-service/internal/domain/service"
 	"order-service/mocks"
+	"order-service/pkg/logger"
+	"order-service/pkg/money"
 	"order-service/proto/pb"
 
 	"github.com/stretchr/testify/assert"
@@ -16,6 +26,10 @@ import (
 )
 
 // setupOrderTest initializes the service with all required mock layers
+// orderCreatedAt is the created_at of the order that LockByID returns, which
+// the order is then looked up by.
+var orderCreatedAt = time.Date(2026, 1, 15, 9, 30, 0, 0, time.UTC)
+
 func setupOrderTest(t *testing.T) (
 	service.OrderService,
 	*mocks.MockRepository,
@@ -27,27 +41,81 @@ func setupOrderTest(t *testing.T) (
 	mPostgres := mocks.NewMockPostgresRepository(t)
 	mOrder := mocks.NewMockOrderRepository(t)
 	mInventory := mocks.NewMockInventoryServiceClient(t)
+	mPayment := mocks.NewMockPaymentRepository(t)
 
 	// Link the Repository layers
 	mRepo.EXPECT().Postgres().Return(mPostgres).Maybe()
 	mPostgres.EXPECT().Order().Return(mOrder).Maybe()
+	withoutWebhookSubscriptions(t, mPostgres)
+	withAuditLog(t, mPostgres)
+	mPostgres.EXPECT().Payment().Return(mPayment).Maybe()
+	mPostgres.EXPECT().
+		Atomic(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ *config.Config, fn postgresrepository.RepositoryAtomicCallback) error {
+			return fn(mPostgres)
+		}).
+		Maybe()
+
+	// Every created order opens its first payment attempt.
+	mPayment.EXPECT().
+		Create(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, p *entity.Payment) (*entity.Payment, error) {
+			p.ID = 1
+			return p, nil
+		}).
+		Maybe()
+	mPayment.EXPECT().UpdateProviderDetails(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	mOrder.EXPECT().UpdateItem(mock.Anything, mock.Anything).Return(nil).Maybe()
+	mOrder.EXPECT().SwapItemReservation(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Maybe()
 
 	// Initialize service with properties
 	s := service.NewOrderService(service.Properties{
+		Config: &config.Config{
+			FX:  &config.FXConfig{BaseCurrency: "IDR"},
+			Tax: &config.TaxConfig{DefaultRegion: "ID"},
+		},
+		Logger:                 logger.NewZerologLogger(false),
 		Repo:                   mRepo,
 		InventoryServiceClient: mInventory,
+		PaymentProvider:        payment.NewFakeProvider("secret", time.Minute),
+		FXRateProvider: fxrate.NewInMemoryProvider("IDR", map[string]money.Rate{
+			"USD": money.MustParseRate("0.00006"),
+		}),
+		TaxCalculator: tax.NewTableCalculator(tax.Table{
+			ProductClasses: map[string]string{"102": "reduced"},
+			Rates: []tax.TableRate{
+				{Region: tax.AnyRegion, Class: tax.DefaultClass, Rate: 0},
+				{Region: tax.AnyRegion, Class: "reduced", Rate: 0},
+				{Region: "SG", Class: tax.DefaultClass, Rate: money.MustParsePercent("9")},
+				{Region: "SG", Class: "reduced", Rate: 0},
+			},
+		}, false, tax.RoundingLine),
+		ShippingFeeCalculator: shipping.NewTableCalculator(shipping.Table{
+			DefaultWeightGrams: 600,
+			Rates: []shipping.TableRate{
+				{ProvinceID: 31, BaseFee: money.FromInt(9000), PerKgFee: money.FromInt(3000)},
+			},
+		}),
 	})
 
 	return s, mRepo, mPostgres, mOrder, mInventory
 }
 
+// expectReservations lets every item of a created order reserve its stock.
+func expectReservations(mInventory *mocks.MockInventoryServiceClient) {
+	mInventory.EXPECT().
+		CreateReservation(mock.Anything, mock.Anything).
+		Return(&pb.Reservation{Id: 900}, nil)
+}
+
 func TestOrderService_Create_Success(t *testing.T) {
 	s, _, _, mOrder, mInventory := setupOrderTest(t)
 	ctx := context.Background()
 
 	inputOrder := &entity.Order{
 		Items: []*entity.OrderItem{
-			{Base: entity.Base{ID: 101}, Quantity: 2},
+			{ProductID: "101", Quantity: 2},
 		},
 	}
 
@@ -61,10 +129,10 @@ func TestOrderService_Create_Success(t *testing.T) {
 		}, nil)
 
 	// 2. Mock DB: Create Order
-	expectedCreated := &entity.Order{Base: entity.Base{ID: 1}, TotalPrice: 100.0}
+	expectedCreated := &entity.Order{Base: entity.Base{ID: 1}, TotalPrice: money.FromInt(100)}
 	mOrder.EXPECT().
 		Create(ctx, mock.MatchedBy(func(o *entity.Order) bool {
-			return o.TotalPrice == 100.0 && o.Status == string(constant.OrderStatusConfirmed)
+			return o.TotalPrice == money.FromInt(100) && o.Status == string(constant.OrderStatusPendingPayment)
 		})).
 		Return(expectedCreated, nil)
 
@@ -74,7 +142,365 @@ func TestOrderService_Create_Success(t *testing.T) {
 	// Assertions
 	assert.NoError(t, err)
 	assert.Equal(t, uint32(1), result.ID)
-	assert.Equal(t, 100.0, result.TotalPrice)
+	assert.Equal(t, money.FromInt(100), result.TotalPrice)
+	if assert.Len(t, result.Payments, 1) {
+		assert.Equal(t, string(constant.PaymentStatusPending), result.Payments[0].Status)
+		assert.NotEmpty(t, result.Payments[0].CheckoutURL)
+	}
+}
+
+func TestOrderService_Create_ExactDecimalTotals(t *testing.T) {
+	s, _, _, mOrder, mInventory := setupOrderTest(t)
+	ctx := context.Background()
+	expectReservations(mInventory)
+
+	inputOrder := &entity.Order{
+		Items: []*entity.OrderItem{
+			{ProductID: "101", Quantity: 3},
+			{ProductID: "102", Quantity: 7},
+		},
+	}
+
+	// 0.1 and 0.7 are not representable in binary floating point; summing
+	// them as float64 drifts away from 5.2.
+	mInventory.EXPECT().
+		GetProduct(ctx, &pb.GetProductRequest{Id: 101}, mock.Anything).
+		Return(&pb.Product{Id: 101, Stock: 10, Price: 0.1}, nil)
+	mInventory.EXPECT().
+		GetProduct(ctx, &pb.GetProductRequest{Id: 102}, mock.Anything).
+		Return(&pb.Product{Id: 102, Stock: 10, Price: 0.7}, nil)
+
+	mOrder.EXPECT().
+		Create(ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, o *entity.Order) (*entity.Order, error) {
+			return o, nil
+		})
+
+	result, err := s.Create(ctx, inputOrder)
+
+	assert.NoError(t, err)
+	assert.Equal(t, money.MustParse("0.3"), result.Items[0].Subtotal)
+	assert.Equal(t, money.MustParse("4.9"), result.Items[1].Subtotal)
+	assert.Equal(t, money.MustParse("5.2"), result.TotalPrice)
+}
+
+func TestOrderService_Create_ConvertsIntoOrderCurrency(t *testing.T) {
+	s, _, _, mOrder, mInventory := setupOrderTest(t)
+	ctx := context.Background()
+	expectReservations(mInventory)
+
+	inputOrder := &entity.Order{
+		Currency: "USD",
+		Items:    []*entity.OrderItem{{ProductID: "101", Quantity: 2}},
+	}
+
+	mInventory.EXPECT().
+		GetProduct(ctx, &pb.GetProductRequest{Id: 101}, mock.Anything).
+		Return(&pb.Product{Id: 101, Stock: 10, Price: 150000}, nil)
+
+	mOrder.EXPECT().
+		Create(ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, o *entity.Order) (*entity.Order, error) {
+			return o, nil
+		})
+
+	result, err := s.Create(ctx, inputOrder)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", result.Currency)
+	assert.Equal(t, "IDR", result.BaseCurrency)
+	assert.Equal(t, "0.00006", result.FXRate.String())
+	assert.Equal(t, money.MustParse("9"), result.Items[0].Price)
+	assert.Equal(t, money.FromInt(150000), result.Items[0].BasePrice)
+	assert.Equal(t, money.MustParse("18"), result.TotalPrice)
+	assert.Equal(t, money.FromInt(300000), result.BaseTotalPrice)
+}
+
+func TestOrderService_Create_UnsupportedCurrency(t *testing.T) {
+	s, _, _, _, _ := setupOrderTest(t)
+	ctx := context.Background()
+
+	result, err := s.Create(ctx, &entity.Order{
+		Currency: "EUR",
+		Items:    []*entity.OrderItem{{ProductID: "101", Quantity: 1}},
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "currency EUR is not supported")
+}
+
+func TestOrderService_Create_AppliesCoupon(t *testing.T) {
+	s, _, mPostgres, mOrder, mInventory := setupOrderTest(t)
+	mCoupon := mocks.NewMockCouponRepository(t)
+	mPostgres.EXPECT().Coupon().Return(mCoupon)
+	ctx := context.Background()
+	expectReservations(mInventory)
+
+	inputOrder := &entity.Order{
+		UserID:      7,
+		CouponCodes: []string{" save10 ", "SAVE10"},
+		Items:       []*entity.OrderItem{{ProductID: "101", Quantity: 2}},
+	}
+
+	mInventory.EXPECT().
+		GetProduct(ctx, &pb.GetProductRequest{Id: 101}, mock.Anything).
+		Return(&pb.Product{Id: 101, Stock: 10, Price: 50000}, nil)
+
+	coupon := &entity.Coupon{
+		Base:       entity.Base{ID: 3},
+		Code:       "SAVE10",
+		Type:       string(constant.CouponTypePercentage),
+		PercentOff: money.MustParsePercent("10"),
+		UsageLimit: 100,
+		IsActive:   true,
+	}
+	mCoupon.EXPECT().FindByCodesForUpdate(ctx, []string{"SAVE10"}).Return([]*entity.Coupon{coupon}, nil)
+	mCoupon.EXPECT().CountUsagesByUser(ctx, uint32(3), uint32(7)).Return(0, nil)
+
+	mOrder.EXPECT().
+		Create(ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, o *entity.Order) (*entity.Order, error) {
+			o.ID = 1
+			return o, nil
+		})
+
+	mCoupon.EXPECT().IncrementUsage(ctx, uint32(3)).Return(true, nil)
+	mCoupon.EXPECT().CreateUsage(ctx, &entity.CouponUsage{CouponID: 3, UserID: 7, OrderID: 1}).Return(nil)
+
+	result, err := s.Create(ctx, inputOrder)
+
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromInt(100000), result.Subtotal)
+	assert.Equal(t, money.FromInt(10000), result.DiscountTotal)
+	assert.Equal(t, money.FromInt(90000), result.TotalPrice)
+	assert.Equal(t, money.FromInt(90000), result.BaseTotalPrice)
+	assert.Len(t, result.Adjustments, 1)
+	assert.Equal(t, money.FromInt(-10000), result.Adjustments[0].Amount)
+}
+
+func TestOrderService_Create_CouponUsageExceeded(t *testing.T) {
+	s, _, mPostgres, mOrder, mInventory := setupOrderTest(t)
+	mCoupon := mocks.NewMockCouponRepository(t)
+	mPostgres.EXPECT().Coupon().Return(mCoupon)
+	ctx := context.Background()
+
+	inputOrder := &entity.Order{
+		UserID:      7,
+		CouponCodes: []string{"ONCE"},
+		Items:       []*entity.OrderItem{{ProductID: "101", Quantity: 1}},
+	}
+
+	mInventory.EXPECT().
+		GetProduct(ctx, mock.Anything, mock.Anything).
+		Return(&pb.Product{Id: 101, Stock: 10, Price: 50000}, nil)
+
+	coupon := &entity.Coupon{
+		Base:       entity.Base{ID: 4},
+		Code:       "ONCE",
+		Type:       string(constant.CouponTypeFixed),
+		AmountOff:  money.FromInt(5000),
+		UsageLimit: 1,
+		IsActive:   true,
+	}
+	mCoupon.EXPECT().FindByCodesForUpdate(ctx, []string{"ONCE"}).Return([]*entity.Coupon{coupon}, nil)
+	mCoupon.EXPECT().CountUsagesByUser(ctx, uint32(4), uint32(7)).Return(0, nil)
+	mOrder.EXPECT().Create(ctx, mock.Anything).Return(&entity.Order{Base: entity.Base{ID: 1}}, nil)
+
+	// Another checkout consumed the last use after the coupon was read.
+	mCoupon.EXPECT().IncrementUsage(ctx, uint32(4)).Return(false, nil)
+
+	result, err := s.Create(ctx, inputOrder)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "usage limit")
+}
+
+func TestOrderService_Create_UnknownCoupon(t *testing.T) {
+	s, _, mPostgres, _, mInventory := setupOrderTest(t)
+	mCoupon := mocks.NewMockCouponRepository(t)
+	mPostgres.EXPECT().Coupon().Return(mCoupon)
+	ctx := context.Background()
+
+	mInventory.EXPECT().
+		GetProduct(ctx, mock.Anything, mock.Anything).
+		Return(&pb.Product{Id: 101, Stock: 10, Price: 50000}, nil)
+	mCoupon.EXPECT().FindByCodesForUpdate(ctx, []string{"NOPE"}).Return(nil, nil)
+
+	result, err := s.Create(ctx, &entity.Order{
+		CouponCodes: []string{"nope"},
+		Items:       []*entity.OrderItem{{ProductID: "101", Quantity: 1}},
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "coupon NOPE does not exist")
+}
+
+func TestOrderService_Create_TaxesDiscountedLines(t *testing.T) {
+	s, _, mPostgres, mOrder, mInventory := setupOrderTest(t)
+	mCoupon := mocks.NewMockCouponRepository(t)
+	mPostgres.EXPECT().Coupon().Return(mCoupon)
+	ctx := context.Background()
+	expectReservations(mInventory)
+
+	inputOrder := &entity.Order{
+		TaxRegion:   "SG",
+		CouponCodes: []string{"HALF"},
+		Items: []*entity.OrderItem{
+			{ProductID: "101", Quantity: 1},
+			{ProductID: "102", Quantity: 1},
+		},
+	}
+
+	mInventory.EXPECT().
+		GetProduct(ctx, &pb.GetProductRequest{Id: 101}, mock.Anything).
+		Return(&pb.Product{Id: 101, Stock: 10, Price: 10000}, nil)
+	mInventory.EXPECT().
+		GetProduct(ctx, &pb.GetProductRequest{Id: 102}, mock.Anything).
+		Return(&pb.Product{Id: 102, Stock: 10, Price: 10000}, nil)
+
+	coupon := &entity.Coupon{
+		Base:               entity.Base{ID: 5},
+		Code:               "HALF",
+		Type:               string(constant.CouponTypePercentage),
+		PercentOff:         money.MustParsePercent("50"),
+		IsActive:           true,
+		EligibleProductIDs: []string{"101"},
+	}
+	mCoupon.EXPECT().FindByCodesForUpdate(ctx, []string{"HALF"}).Return([]*entity.Coupon{coupon}, nil)
+	mCoupon.EXPECT().CountUsagesByUser(ctx, uint32(5), uint32(0)).Return(0, nil)
+	mCoupon.EXPECT().IncrementUsage(ctx, uint32(5)).Return(true, nil)
+	mCoupon.EXPECT().CreateUsage(ctx, mock.Anything).Return(nil)
+
+	mOrder.EXPECT().
+		Create(ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, o *entity.Order) (*entity.Order, error) {
+			return o, nil
+		})
+
+	result, err := s.Create(ctx, inputOrder)
+
+	assert.NoError(t, err)
+
+	// Product 101 is taxed at 9% on its discounted 5000; product 102 is in
+	// the zero-rated reduced class.
+	assert.Equal(t, money.FromInt(450), result.Items[0].TaxAmount)
+	assert.Equal(t, "reduced", result.Items[1].TaxClass)
+	assert.True(t, result.Items[1].TaxAmount.IsZero())
+	assert.Equal(t, money.FromInt(20000), result.Subtotal)
+	assert.Equal(t, money.FromInt(5000), result.DiscountTotal)
+	assert.Equal(t, money.FromInt(450), result.TaxTotal)
+	assert.Equal(t, money.FromInt(15450), result.TotalPrice)
+	assert.Equal(t, money.FromInt(15450), result.BaseTotalPrice)
+}
+
+func TestOrderService_Create_ShippingFee(t *testing.T) {
+	s, _, mPostgres, mOrder, mInventory := setupOrderTest(t)
+	mLocation := mocks.NewMockLocationRepository(t)
+	mPostgres.EXPECT().Location().Return(mLocation)
+	ctx := context.Background()
+	expectReservations(mInventory)
+
+	inputOrder := &entity.Order{
+		ShippingAddress: &entity.ShippingAddress{RecipientName: "Budi", DistrictID: 3171010},
+		Items:           []*entity.OrderItem{{ProductID: "101", Quantity: 3}},
+	}
+
+	mLocation.EXPECT().FindDistrictByID(ctx, uint32(3171010)).Return(&entity.District{
+		ID:     3171010,
+		CityID: 3171,
+		Name:   "Gambir",
+		City: &entity.City{
+			ID:         3171,
+			ProvinceID: 31,
+			Name:       "Jakarta Pusat",
+			Province:   &entity.Province{ID: 31, Name: "DKI Jakarta"},
+		},
+	}, nil)
+
+	mInventory.EXPECT().
+		GetProduct(ctx, mock.Anything, mock.Anything).
+		Return(&pb.Product{Id: 101, Stock: 10, Price: 10000}, nil)
+
+	mOrder.EXPECT().
+		Create(ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, o *entity.Order) (*entity.Order, error) {
+			return o, nil
+		})
+
+	result, err := s.Create(ctx, inputOrder)
+
+	assert.NoError(t, err)
+
+	// 3 x 600g rounds up to 2 kg: the base fee plus one extra kilogram.
+	assert.Equal(t, money.FromInt(12000), result.ShippingTotal)
+	assert.Equal(t, money.FromInt(42000), result.TotalPrice)
+	assert.Equal(t, "DKI Jakarta", result.ShippingAddress.District.City.Province.Name)
+	assert.Len(t, result.Adjustments, 1)
+	assert.Equal(t, string(constant.AdjustmentTypeShipping), result.Adjustments[0].Type)
+	assert.Nil(t, result.Adjustments[0].OrderItem)
+}
+
+func TestOrderService_Create_WithoutShippingAddress(t *testing.T) {
+	s, _, _, mOrder, mInventory := setupOrderTest(t)
+	ctx := context.Background()
+	expectReservations(mInventory)
+
+	mInventory.EXPECT().
+		GetProduct(ctx, mock.Anything, mock.Anything).
+		Return(&pb.Product{Id: 101, Stock: 10, Price: 10000}, nil)
+
+	mOrder.EXPECT().
+		Create(ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, o *entity.Order) (*entity.Order, error) {
+			return o, nil
+		})
+
+	result, err := s.Create(ctx, &entity.Order{
+		Items: []*entity.OrderItem{{ProductID: "101", Quantity: 3}},
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.ShippingTotal.IsZero())
+	assert.Equal(t, money.FromInt(30000), result.TotalPrice)
+	assert.Nil(t, result.ShippingAddress)
+	assert.Empty(t, result.Adjustments)
+}
+
+func TestOrderService_Create_UnknownDistrict(t *testing.T) {
+	s, _, mPostgres, _, mInventory := setupOrderTest(t)
+	mLocation := mocks.NewMockLocationRepository(t)
+	mPostgres.EXPECT().Location().Return(mLocation)
+	ctx := context.Background()
+
+	mInventory.EXPECT().
+		GetProduct(ctx, mock.Anything, mock.Anything).
+		Return(&pb.Product{Id: 101, Stock: 10, Price: 10000}, nil)
+	mLocation.EXPECT().FindDistrictByID(ctx, uint32(9)).Return(nil, nil)
+
+	result, err := s.Create(ctx, &entity.Order{
+		ShippingAddress: &entity.ShippingAddress{DistrictID: 9},
+		Items:           []*entity.OrderItem{{ProductID: "101", Quantity: 1}},
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "district 9 does not exist")
+}
+
+func TestOrderService_Create_InvalidProductID(t *testing.T) {
+	s, _, _, _, _ := setupOrderTest(t)
+	ctx := context.Background()
+
+	result, err := s.Create(ctx, &entity.Order{
+		Items: []*entity.OrderItem{{ProductID: "abc", Quantity: 1}},
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), `invalid product id "abc"`)
 }
 
 func TestOrderService_Create_StockShortage(t *testing.T) {
@@ -82,7 +508,7 @@ func TestOrderService_Create_StockShortage(t *testing.T) {
 	ctx := context.Background()
 
 	inputOrder := &entity.Order{
-		Items: []*entity.OrderItem{{Base: entity.Base{ID: 101}, Quantity: 5}},
+		Items: []*entity.OrderItem{{ProductID: "101", Quantity: 5}},
 	}
 
 	// Mock gRPC: Return stock less than requested
@@ -97,6 +523,70 @@ func TestOrderService_Create_StockShortage(t *testing.T) {
 	assert.Contains(t, err.Error(), "stock is not enough")
 }
 
+func TestOrderService_Create_TotalTooLarge(t *testing.T) {
+	s, _, _, _, mInventory := setupOrderTest(t)
+	ctx := context.Background()
+
+	inputOrder := &entity.Order{
+		Items: []*entity.OrderItem{{ProductID: "101", Quantity: 5}},
+	}
+
+	// Five units cost more than a Money can hold.
+	mInventory.EXPECT().
+		GetProduct(ctx, mock.Anything, mock.Anything).
+		Return(&pb.Product{Id: 101, Stock: 10, Price: 2e14}, nil)
+
+	result, err := s.Create(ctx, inputOrder)
+
+	assert.Nil(t, result)
+	assert.ErrorContains(t, err, "order total is too large")
+}
+
+func TestOrderService_Create_ReservationFailureCancelsOrder(t *testing.T) {
+	s, _, _, mOrder, mInventory := setupOrderTest(t)
+	ctx := context.Background()
+
+	mInventory.EXPECT().
+		GetProduct(ctx, mock.Anything, mock.Anything).
+		Return(&pb.Product{Id: 101, Stock: 10, Price: 50.0}, nil)
+	mOrder.EXPECT().Create(ctx, mock.Anything).Return(&entity.Order{
+		Base:   entity.Base{ID: 1},
+		Status: string(constant.OrderStatusPendingPayment),
+		Items: []*entity.OrderItem{
+			{Base: entity.Base{ID: 10}, OrderID: 1, ProductID: "101", Quantity: 2},
+			{Base: entity.Base{ID: 11}, OrderID: 1, ProductID: "102", Quantity: 1},
+		},
+	}, nil)
+
+	// The second reservation fails, so the first one is released again.
+	mInventory.EXPECT().
+		CreateReservation(ctx, &pb.CreateReservationRequest{ProductId: 101, OrderId: 1, Quantity: 2}).
+		Return(&pb.Reservation{Id: 900}, nil)
+	mInventory.EXPECT().
+		CreateReservation(ctx, &pb.CreateReservationRequest{ProductId: 102, OrderId: 1, Quantity: 1}).
+		Return(nil, errors.New("out of stock"))
+	mInventory.EXPECT().
+		UpdateReservationStatus(ctx, &pb.UpdateReservationStatusRequest{
+			Ids:    []uint32{900},
+			Status: pb.ReservationStatus_RESERVATION_STATUS_CANCELLED,
+		}).
+		Return(&emptypb.Empty{}, nil)
+	mOrder.EXPECT().
+		TransitionStatus(ctx, uint32(1), time.Time{}, string(constant.OrderStatusPendingPayment), string(constant.OrderStatusCancelled)).
+		Return(true, nil)
+	mOrder.EXPECT().
+		SetCancelled(ctx, uint32(1), string(constant.CancellationReasonStockUnavailable), mock.Anything).
+		Return(nil)
+
+	result, err := s.Create(ctx, &entity.Order{Items: []*entity.OrderItem{
+		{ProductID: "101", Quantity: 2},
+		{ProductID: "102", Quantity: 1},
+	}})
+
+	assert.ErrorContains(t, err, "out of stock")
+	assert.Nil(t, result)
+}
+
 func TestOrderService_Cancel_Success(t *testing.T) {
 	s, _, _, mOrder, mInventory := setupOrderTest(t)
 	ctx := context.Background()
@@ -106,18 +596,24 @@ func TestOrderService_Cancel_Success(t *testing.T) {
 	existingOrder := &entity.Order{
 		Base:   entity.Base{ID: orderID},
 		Status: string(constant.OrderStatusConfirmed),
-		Items:  []*entity.OrderItem{{Base: entity.Base{ID: 500}}},
+		Items:  []*entity.OrderItem{{Base: entity.Base{ID: 500}, ReservationID: ptr(uint32(900))}},
 	}
 	mOrder.EXPECT().FindByID(ctx, orderID).Return(existingOrder, nil)
 
 	// 2. Mock gRPC: Update Inventory Status
 	mInventory.EXPECT().
-		UpdateReservationStatus(ctx, mock.Anything, mock.Anything).
+		UpdateReservationStatus(ctx, &pb.UpdateReservationStatusRequest{
+			Ids:    []uint32{900},
+			Status: pb.ReservationStatus_RESERVATION_STATUS_CANCELLED,
+		}).
 		Return(&emptypb.Empty{}, nil)
 
 	// 3. Mock DB: Update Order Status
 	mOrder.EXPECT().
-		UpdateStatus(ctx, orderID, string(constant.OrderStatusCancelled)).
+		TransitionStatus(ctx, orderID, time.Time{}, string(constant.OrderStatusConfirmed), string(constant.OrderStatusCancelled)).
+		Return(true, nil)
+	mOrder.EXPECT().
+		SetCancelled(ctx, orderID, string(constant.CancellationReasonRequested), mock.Anything).
 		Return(nil)
 
 	err := s.Cancel(ctx, orderID)
@@ -125,6 +621,516 @@ func TestOrderService_Cancel_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestOrderService_Cancel_QueuesFailedRelease(t *testing.T) {
+	s, _, mPostgres, mOrder, mInventory := setupOrderTest(t)
+	mJob := mocks.NewMockJobRepository(t)
+	mPostgres.EXPECT().Job().Return(mJob)
+	ctx := context.Background()
+
+	mOrder.EXPECT().FindByID(ctx, uint32(1)).Return(&entity.Order{
+		Base:   entity.Base{ID: 1},
+		Status: string(constant.OrderStatusPendingPayment),
+		Items:  []*entity.OrderItem{{Base: entity.Base{ID: 500}, ReservationID: ptr(uint32(900))}},
+	}, nil)
+	mOrder.EXPECT().
+		TransitionStatus(ctx, uint32(1), time.Time{}, string(constant.OrderStatusPendingPayment), string(constant.OrderStatusCancelled)).
+		Return(true, nil)
+	mOrder.EXPECT().
+		SetCancelled(ctx, uint32(1), string(constant.CancellationReasonRequested), mock.Anything).
+		Return(nil)
+	mInventory.EXPECT().
+		UpdateReservationStatus(ctx, mock.Anything).
+		Return(nil, errors.New("inventory unavailable"))
+	mJob.EXPECT().
+		Create(ctx, mock.MatchedBy(func(job *entity.Job) bool {
+			return job.Type == service.JobTypeReleaseReservations && string(job.Payload) == `{"reservation_ids":[900]}`
+		})).
+		RunAndReturn(func(_ context.Context, job *entity.Job) (*entity.Job, error) {
+			return job, nil
+		})
+
+	err := s.Cancel(ctx, 1)
+
+	assert.NoError(t, err)
+}
+
+func TestOrderService_ExpirePending(t *testing.T) {
+	s, _, _, mOrder, mInventory := setupOrderTest(t)
+	ctx := context.Background()
+	placedBefore := time.Now().Add(-30 * time.Minute)
+
+	expiring := &entity.Order{
+		Base:   entity.Base{ID: 1},
+		Status: string(constant.OrderStatusPendingPayment),
+		Items:  []*entity.OrderItem{{Base: entity.Base{ID: 500}, ReservationID: ptr(uint32(900))}},
+	}
+	paid := &entity.Order{
+		Base:   entity.Base{ID: 2},
+		Status: string(constant.OrderStatusPendingPayment),
+		Items:  []*entity.OrderItem{{Base: entity.Base{ID: 501}, ReservationID: ptr(uint32(901))}},
+	}
+
+	mOrder.EXPECT().
+		Find(ctx, &postgresrepository.FilterOrderPayload{
+			Status:        string(constant.OrderStatusPendingPayment),
+			CreatedBefore: placedBefore,
+			PerPage:       10,
+		}).
+		Return([]*entity.Order{expiring, paid}, 2, nil)
+	mOrder.EXPECT().
+		TransitionStatus(ctx, uint32(1), time.Time{}, string(constant.OrderStatusPendingPayment), string(constant.OrderStatusCancelled)).
+		Return(true, nil)
+	mOrder.EXPECT().
+		SetCancelled(ctx, uint32(1), string(constant.CancellationReasonExpired), mock.Anything).
+		Return(nil)
+	mInventory.EXPECT().
+		UpdateReservationStatus(ctx, &pb.UpdateReservationStatusRequest{
+			Ids:    []uint32{900},
+			Status: pb.ReservationStatus_RESERVATION_STATUS_CANCELLED,
+		}).
+		Return(&emptypb.Empty{}, nil)
+
+	// The second order was paid after it was listed, so it keeps its stock.
+	mOrder.EXPECT().
+		TransitionStatus(ctx, uint32(2), time.Time{}, string(constant.OrderStatusPendingPayment), string(constant.OrderStatusCancelled)).
+		Return(false, nil)
+
+	expired, err := s.ExpirePending(ctx, placedBefore, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// discountedOrder has one line of 3 units at 10.00 with a 3.00 discount and
+// 10% tax on top, and a second line that is untouched.
+func discountedOrder() *entity.Order {
+	item := &entity.OrderItem{
+		Base:          entity.Base{ID: 500},
+		OrderID:       1,
+		ProductID:     "101",
+		Quantity:      3,
+		Price:         money.FromInt(10),
+		BasePrice:     money.FromInt(10),
+		Subtotal:      money.FromInt(30),
+		TaxableAmount: money.FromInt(27),
+		TaxAmount:     money.MustParse("2.70"),
+		ReservationID: ptr(uint32(900)),
+	}
+	other := &entity.OrderItem{
+		Base:          entity.Base{ID: 501},
+		OrderID:       1,
+		ProductID:     "102",
+		Quantity:      1,
+		Price:         money.FromInt(5),
+		BasePrice:     money.FromInt(5),
+		Subtotal:      money.FromInt(5),
+		TaxableAmount: money.FromInt(5),
+		ReservationID: ptr(uint32(901)),
+	}
+
+	return &entity.Order{
+		Base:           entity.Base{ID: 1},
+		Status:         string(constant.OrderStatusPendingPayment),
+		Currency:       "IDR",
+		BaseCurrency:   "IDR",
+		FXRate:         money.MustParseRate("1"),
+		Subtotal:       money.FromInt(35),
+		DiscountTotal:  money.FromInt(3),
+		TaxTotal:       money.MustParse("2.70"),
+		TotalPrice:     money.MustParse("34.70"),
+		BaseTotalPrice: money.MustParse("34.70"),
+		Items:          []*entity.OrderItem{item, other},
+		Adjustments: []*entity.OrderAdjustment{{
+			OrderID:     1,
+			OrderItemID: &item.ID,
+			Type:        string(constant.AdjustmentTypeDiscount),
+			Amount:      money.FromInt(-3),
+		}},
+	}
+}
+
+func TestOrderService_CancelItem_Partial(t *testing.T) {
+	s, _, _, mOrder, mInventory := setupOrderTest(t)
+	ctx := context.Background()
+	order := discountedOrder()
+
+	mOrder.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+	mOrder.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(order, nil)
+
+	// One of three units gives back a third of the line's discount.
+	mOrder.EXPECT().
+		CreateAdjustment(ctx, mock.MatchedBy(func(a *entity.OrderAdjustment) bool {
+			return a.Type == string(constant.AdjustmentTypeCancellation) && *a.OrderItemID == 500 && a.Amount.Cmp(money.FromInt(1)) == 0
+		})).
+		RunAndReturn(func(_ context.Context, a *entity.OrderAdjustment) (*entity.OrderAdjustment, error) {
+			return a, nil
+		})
+	mOrder.EXPECT().UpdateTotals(ctx, order).Return(nil)
+
+	// The reservation is replaced by one for the two remaining units.
+	mInventory.EXPECT().
+		CreateReservation(ctx, &pb.CreateReservationRequest{ProductId: 101, OrderId: 1, Quantity: 2}).
+		Return(&pb.Reservation{Id: 902}, nil).
+		Once()
+	mInventory.EXPECT().
+		UpdateReservationStatus(ctx, &pb.UpdateReservationStatusRequest{
+			Ids:    []uint32{900},
+			Status: pb.ReservationStatus_RESERVATION_STATUS_CANCELLED,
+		}).
+		Return(&emptypb.Empty{}, nil)
+
+	result, err := s.CancelItem(ctx, 1, 500, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(constant.OrderStatusPendingPayment), result.Status)
+	assert.Equal(t, 1, order.Items[0].CancelledQuantity)
+	assert.Equal(t, uint32(902), *order.Items[0].ReservationID)
+	assert.Equal(t, 0, order.Subtotal.Cmp(money.FromInt(25)))
+	assert.Equal(t, 0, order.DiscountTotal.Cmp(money.FromInt(2)))
+	assert.Equal(t, 0, order.TaxTotal.Cmp(money.MustParse("1.80")))
+	assert.Equal(t, 0, order.TotalPrice.Cmp(money.MustParse("24.80")))
+	assert.Equal(t, 0, order.BaseTotalPrice.Cmp(money.MustParse("24.80")))
+}
+
+func TestOrderService_CancelItem_LastUnitCancelsOrder(t *testing.T) {
+	s, _, _, mOrder, mInventory := setupOrderTest(t)
+	ctx := context.Background()
+	order := discountedOrder()
+	order.Items[0].CancelledQuantity = 3
+	order.Items[0].ReservationID = nil
+
+	mOrder.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+	mOrder.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(order, nil)
+	mOrder.EXPECT().CreateAdjustment(ctx, mock.Anything).RunAndReturn(func(_ context.Context, a *entity.OrderAdjustment) (*entity.OrderAdjustment, error) {
+		return a, nil
+	})
+	mOrder.EXPECT().UpdateTotals(ctx, order).Return(nil)
+	mOrder.EXPECT().
+		TransitionStatus(ctx, uint32(1), time.Time{}, string(constant.OrderStatusPendingPayment), string(constant.OrderStatusCancelled)).
+		Return(true, nil)
+	mOrder.EXPECT().
+		SetCancelled(ctx, uint32(1), string(constant.CancellationReasonItemsCancelled), mock.Anything).
+		Return(nil)
+	mInventory.EXPECT().
+		UpdateReservationStatus(ctx, &pb.UpdateReservationStatusRequest{
+			Ids:    []uint32{901},
+			Status: pb.ReservationStatus_RESERVATION_STATUS_CANCELLED,
+		}).
+		Return(&emptypb.Empty{}, nil)
+
+	_, err := s.CancelItem(ctx, 1, 501, 1)
+
+	assert.NoError(t, err)
+	assert.Nil(t, order.Items[1].ReservationID)
+	mInventory.AssertNotCalled(t, "CreateReservation", mock.Anything, mock.Anything)
+}
+
+func TestOrderService_CancelItem_QueuesFailedResize(t *testing.T) {
+	s, _, mPostgres, mOrder, mInventory := setupOrderTest(t)
+	mJob := mocks.NewMockJobRepository(t)
+	mPostgres.EXPECT().Job().Return(mJob)
+	ctx := context.Background()
+	order := discountedOrder()
+
+	mOrder.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+	mOrder.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(order, nil)
+	mOrder.EXPECT().CreateAdjustment(ctx, mock.Anything).RunAndReturn(func(_ context.Context, a *entity.OrderAdjustment) (*entity.OrderAdjustment, error) {
+		return a, nil
+	})
+	mOrder.EXPECT().UpdateTotals(ctx, order).Return(nil)
+	mInventory.EXPECT().
+		CreateReservation(ctx, mock.Anything).
+		Return(nil, errors.New("inventory unavailable"))
+
+	// The cancellation has committed, so it stands and the resize is retried
+	// by a job.
+	mJob.EXPECT().
+		Create(ctx, mock.MatchedBy(func(job *entity.Job) bool {
+			return job.Type == service.JobTypeSyncReservation && string(job.Payload) == `{"order_id":1,"order_item_id":500}`
+		})).
+		RunAndReturn(func(_ context.Context, job *entity.Job) (*entity.Job, error) {
+			return job, nil
+		})
+
+	_, err := s.CancelItem(ctx, 1, 500, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, order.Items[0].CancelledQuantity)
+	assert.Equal(t, uint32(900), *order.Items[0].ReservationID)
+}
+
+func TestOrderService_SyncReservation_LeavesCurrentReservation(t *testing.T) {
+	s, _, _, mOrder, mInventory := setupOrderTest(t)
+	ctx := context.Background()
+
+	mOrder.EXPECT().FindByID(ctx, uint32(1)).Return(discountedOrder(), nil)
+	mInventory.EXPECT().
+		GetReservation(ctx, &pb.GetReservationRequest{Id: 900}).
+		Return(&pb.Reservation{Id: 900, Quantity: 3, Status: pb.ReservationStatus_RESERVATION_STATUS_PENDING}, nil)
+
+	err := s.SyncReservation(ctx, 1, 500)
+
+	assert.NoError(t, err)
+	mInventory.AssertNotCalled(t, "CreateReservation", mock.Anything, mock.Anything)
+}
+
+func TestOrderService_SyncReservation_ResizesStaleReservation(t *testing.T) {
+	s, _, _, mOrder, mInventory := setupOrderTest(t)
+	ctx := context.Background()
+	order := discountedOrder()
+	order.Items[0].CancelledQuantity = 1
+
+	mOrder.EXPECT().FindByID(ctx, uint32(1)).Return(order, nil)
+	mInventory.EXPECT().
+		GetReservation(ctx, &pb.GetReservationRequest{Id: 900}).
+		Return(&pb.Reservation{Id: 900, Quantity: 3, Status: pb.ReservationStatus_RESERVATION_STATUS_PENDING}, nil)
+	mInventory.EXPECT().
+		CreateReservation(ctx, &pb.CreateReservationRequest{ProductId: 101, OrderId: 1, Quantity: 2}).
+		Return(&pb.Reservation{Id: 902}, nil)
+	mInventory.EXPECT().
+		UpdateReservationStatus(ctx, &pb.UpdateReservationStatusRequest{
+			Ids:    []uint32{900},
+			Status: pb.ReservationStatus_RESERVATION_STATUS_CANCELLED,
+		}).
+		Return(&emptypb.Empty{}, nil)
+
+	err := s.SyncReservation(ctx, 1, 500)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(902), *order.Items[0].ReservationID)
+}
+
+func TestOrderService_CancelItem_QuantityExceeded(t *testing.T) {
+	s, _, _, mOrder, _ := setupOrderTest(t)
+	ctx := context.Background()
+	order := discountedOrder()
+	order.Items[0].CancelledQuantity = 2
+
+	mOrder.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+	mOrder.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(order, nil)
+
+	_, err := s.CancelItem(ctx, 1, 500, 2)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "only 1 of order item 500 can still be cancelled")
+}
+
+// editableOrder is awaiting payment with two reserved lines.
+func editableOrder() *entity.Order {
+	return &entity.Order{
+		Base:         entity.Base{ID: 1},
+		Status:       string(constant.OrderStatusPendingPayment),
+		Currency:     "IDR",
+		BaseCurrency: "IDR",
+		FXRate:       money.MustParseRate("1"),
+		TaxRegion:    "ID",
+		Items: []*entity.OrderItem{
+			{Base: entity.Base{ID: 500}, OrderID: 1, ProductID: "101", Quantity: 2, Price: money.FromInt(10), ReservationID: ptr(uint32(900))},
+			{Base: entity.Base{ID: 501}, OrderID: 1, ProductID: "102", Quantity: 1, Price: money.FromInt(5), ReservationID: ptr(uint32(901))},
+		},
+	}
+}
+
+func TestOrderService_UpdateItems_Success(t *testing.T) {
+	s, _, _, mOrder, mInventory := setupOrderTest(t)
+	ctx := context.Background()
+
+	mOrder.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+	mOrder.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(editableOrder(), nil)
+
+	// Changed and added lines are repriced; the removed one is not.
+	mInventory.EXPECT().
+		GetProduct(ctx, &pb.GetProductRequest{Id: 101}, mock.Anything).
+		Return(&pb.Product{Id: 101, Stock: 1, Price: 12.0}, nil)
+	mInventory.EXPECT().
+		GetProduct(ctx, &pb.GetProductRequest{Id: 103}, mock.Anything).
+		Return(&pb.Product{Id: 103, Stock: 1, Price: 7.0}, nil)
+
+	mOrder.EXPECT().
+		UpdateItems(ctx, mock.MatchedBy(func(o *entity.Order) bool {
+			return len(o.Items) == 2 && o.Subtotal.Cmp(money.FromInt(43)) == 0 && o.TotalPrice.Cmp(money.FromInt(43)) == 0
+		}), mock.MatchedBy(func(removed []*entity.OrderItem) bool {
+			return len(removed) == 1 && removed[0].ID == 501
+		})).
+		RunAndReturn(func(_ context.Context, o *entity.Order, _ []*entity.OrderItem) error {
+			o.Items[1].ID = 502
+			return nil
+		})
+
+	mInventory.EXPECT().
+		CreateReservation(ctx, &pb.CreateReservationRequest{ProductId: 101, OrderId: 1, Quantity: 3}).
+		Return(&pb.Reservation{Id: 910}, nil)
+	mInventory.EXPECT().
+		CreateReservation(ctx, &pb.CreateReservationRequest{ProductId: 103, OrderId: 1, Quantity: 1}).
+		Return(&pb.Reservation{Id: 911}, nil)
+
+	// The replaced reservation and that of the removed line are released.
+	for _, id := range []uint32{900, 901} {
+		mInventory.EXPECT().
+			UpdateReservationStatus(ctx, &pb.UpdateReservationStatusRequest{
+				Ids:    []uint32{id},
+				Status: pb.ReservationStatus_RESERVATION_STATUS_CANCELLED,
+			}).
+			Return(&emptypb.Empty{}, nil)
+	}
+
+	_, err := s.UpdateItems(ctx, 1, []*entity.OrderItem{
+		{Base: entity.Base{ID: 500}, Quantity: 3},
+		{Base: entity.Base{ID: 501}, Quantity: 0},
+		{ProductID: "103", Quantity: 1},
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestOrderService_UpdateItems_StockShortage(t *testing.T) {
+	s, _, _, mOrder, mInventory := setupOrderTest(t)
+	ctx := context.Background()
+
+	mOrder.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+	mOrder.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(editableOrder(), nil)
+	mInventory.EXPECT().
+		GetProduct(ctx, &pb.GetProductRequest{Id: 101}, mock.Anything).
+		Return(&pb.Product{Id: 101, Stock: 2, Price: 10.0}, nil)
+
+	_, err := s.UpdateItems(ctx, 1, []*entity.OrderItem{{Base: entity.Base{ID: 500}, Quantity: 5}})
+
+	assert.ErrorContains(t, err, "stock is not enough for product 101: 3 more requested, 2 available")
+	mOrder.AssertNotCalled(t, "UpdateItems", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderService_UpdateItems_QueuesFailedReservation(t *testing.T) {
+	s, _, mPostgres, mOrder, mInventory := setupOrderTest(t)
+	mJob := mocks.NewMockJobRepository(t)
+	mPostgres.EXPECT().Job().Return(mJob)
+	ctx := context.Background()
+	order := editableOrder()
+
+	mOrder.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+	mOrder.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(order, nil)
+	mInventory.EXPECT().
+		GetProduct(ctx, mock.Anything, mock.Anything).
+		Return(&pb.Product{Stock: 10, Price: 10.0}, nil)
+	mOrder.EXPECT().UpdateItems(ctx, mock.Anything, mock.Anything).Return(nil)
+
+	mInventory.EXPECT().
+		CreateReservation(ctx, &pb.CreateReservationRequest{ProductId: 101, OrderId: 1, Quantity: 3}).
+		Return(&pb.Reservation{Id: 910}, nil)
+	mInventory.EXPECT().
+		UpdateReservationStatus(ctx, &pb.UpdateReservationStatusRequest{
+			Ids:    []uint32{900},
+			Status: pb.ReservationStatus_RESERVATION_STATUS_CANCELLED,
+		}).
+		Return(&emptypb.Empty{}, nil)
+	mInventory.EXPECT().
+		CreateReservation(ctx, &pb.CreateReservationRequest{ProductId: 102, OrderId: 1, Quantity: 4}).
+		Return(nil, errors.New("insufficient stock"))
+
+	// The edit has committed, so the line keeps its old reservation until a
+	// job manages to replace it.
+	mJob.EXPECT().
+		Create(ctx, mock.MatchedBy(func(job *entity.Job) bool {
+			return job.Type == service.JobTypeSyncReservation && string(job.Payload) == `{"order_id":1,"order_item_id":501}`
+		})).
+		RunAndReturn(func(_ context.Context, job *entity.Job) (*entity.Job, error) {
+			return job, nil
+		})
+
+	_, err := s.UpdateItems(ctx, 1, []*entity.OrderItem{
+		{Base: entity.Base{ID: 500}, Quantity: 3},
+		{Base: entity.Base{ID: 501}, Quantity: 4},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(910), *order.Items[0].ReservationID)
+	assert.Equal(t, uint32(901), *order.Items[1].ReservationID)
+}
+
+func TestOrderService_UpdateItems_NotEditable(t *testing.T) {
+	s, _, _, mOrder, _ := setupOrderTest(t)
+	ctx := context.Background()
+	order := editableOrder()
+	order.Status = string(constant.OrderStatusConfirmed)
+
+	mOrder.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+	mOrder.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(order, nil)
+
+	_, err := s.UpdateItems(ctx, 1, []*entity.OrderItem{{Base: entity.Base{ID: 500}, Quantity: 3}})
+
+	assert.ErrorContains(t, err, "only orders awaiting payment can be edited")
+}
+
+func TestOrderService_Delete_Success(t *testing.T) {
+	s, _, _, mOrder, _ := setupOrderTest(t)
+	ctx := context.Background()
+
+	mOrder.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+	mOrder.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(&entity.Order{
+		Base:   entity.Base{ID: 1},
+		Status: string(constant.OrderStatusDelivered),
+	}, nil)
+	mOrder.EXPECT().Delete(ctx, uint32(1)).Return(nil)
+
+	err := s.Delete(ctx, 1)
+
+	assert.NoError(t, err)
+}
+
+func TestOrderService_Delete_OpenOrder(t *testing.T) {
+	s, _, _, mOrder, _ := setupOrderTest(t)
+	ctx := context.Background()
+
+	mOrder.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+	mOrder.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(&entity.Order{
+		Base:   entity.Base{ID: 1},
+		Status: string(constant.OrderStatusConfirmed),
+	}, nil)
+
+	err := s.Delete(ctx, 1)
+
+	assert.ErrorContains(t, err, "only delivered, rejected or cancelled orders can be deleted")
+}
+
+func TestOrderService_Restore_Success(t *testing.T) {
+	s, _, _, mOrder, _ := setupOrderTest(t)
+	ctx := context.Background()
+	deletedAt := time.Now().Add(-time.Hour)
+
+	mOrder.EXPECT().
+		Find(ctx, &postgresrepository.FilterOrderPayload{IDs: []uint32{1}, IncludeDeleted: true}).
+		Return([]*entity.Order{{
+			Base:   entity.Base{ID: 1, CreatedAt: orderCreatedAt, DeletedAt: &deletedAt},
+			Status: string(constant.OrderStatusCancelled),
+		}}, 1, nil)
+	mOrder.EXPECT().Restore(ctx, uint32(1)).Return(true, nil)
+	mOrder.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(&entity.Order{
+		Base:   entity.Base{ID: 1},
+		Status: string(constant.OrderStatusCancelled),
+	}, nil)
+
+	order, err := s.Restore(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Nil(t, order.DeletedAt)
+}
+
+func TestOrderService_Restore_NotDeleted(t *testing.T) {
+	s, _, _, mOrder, _ := setupOrderTest(t)
+	ctx := context.Background()
+
+	mOrder.EXPECT().
+		Find(ctx, &postgresrepository.FilterOrderPayload{IDs: []uint32{1}, IncludeDeleted: true}).
+		Return([]*entity.Order{{Base: entity.Base{ID: 1}, Status: string(constant.OrderStatusCancelled)}}, 1, nil)
+
+	_, err := s.Restore(ctx, 1)
+
+	assert.ErrorContains(t, err, "order is not deleted")
+}
+
 func TestOrderService_FindByID_NotFound(t *testing.T) {
 	s, _, _, mOrder, _ := setupOrderTest(t)
 	ctx := context.Background()
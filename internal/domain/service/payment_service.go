@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"order-service/constant"
+	"order-service/internal/adapter/payment"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/domain/entity"
+	"order-service/internal/shared"
+	"order-service/internal/shared/exception"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+var _ PaymentService = (*paymentService)(nil)
+
+type PaymentService interface {
+	Start(ctx context.Context, orderID uint32) (*entity.Payment, error)
+	HandleWebhook(ctx context.Context, header http.Header, body []byte) error
+}
+
+type paymentService struct {
+	Properties
+}
+
+func NewPaymentService(props Properties) *paymentService {
+	return &paymentService{
+		Properties: props,
+	}
+}
+
+// Start opens a new payment attempt for an order that is still waiting for
+// payment, e.g. after a previous attempt failed. The check for an attempt in
+// progress gives the usual answer; a concurrent Start that passes it too is
+// stopped by the unique index on the pending attempt of an order.
+func (s *paymentService) Start(ctx context.Context, orderID uint32) (*entity.Payment, error) {
+	order, err := s.Repo.Postgres().Order().FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, exception.New(exception.TypeNotFound, exception.CodeNotFound, "order not found")
+	}
+
+	if order.Status != string(constant.OrderStatusPendingPayment) {
+		return nil, exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "order is not awaiting payment")
+	}
+
+	for _, p := range order.Payments {
+		if p.Status == string(constant.PaymentStatusPending) {
+			return nil, exception.New(exception.TypeConflict, exception.CodeConflict, "a payment for this order is already in progress")
+		}
+	}
+
+	return startPayment(ctx, s.Properties, order)
+}
+
+// startPayment records a pending attempt and hands it to the provider. The
+// attempt is stored before the provider is called so that a callback which
+// arrives early can still be matched by its reference. A provider error is
+// recorded on the attempt rather than returned.
+func startPayment(ctx context.Context, props Properties, order *entity.Order) (*entity.Payment, error) {
+	id, err := shared.GenerateUUIDString()
+	if err != nil {
+		return nil, err
+	}
+
+	repo := props.Repo.Postgres().Payment()
+
+	p, err := repo.Create(ctx, &entity.Payment{
+		OrderID:   order.ID,
+		Provider:  props.PaymentProvider.Name(),
+		Reference: "pay_" + id,
+		Status:    string(constant.PaymentStatusPending),
+		Amount:    order.TotalPrice,
+		Currency:  order.Currency,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := props.PaymentProvider.CreatePayment(ctx, &payment.CreatePaymentRequest{
+		Reference:   p.Reference,
+		OrderID:     order.ID,
+		Amount:      p.Amount,
+		Currency:    p.Currency,
+		Description: "Order #" + p.Reference,
+	})
+	if err != nil {
+		props.log(ctx).Warn().Err(err).Msgf("Failed to start payment %s for order %d", p.Reference, order.ID)
+
+		now := time.Now()
+		p.Status = string(constant.PaymentStatusFailed)
+		p.FailureReason = "provider error: " + err.Error()
+		p.FailedAt = &now
+
+		return repo.Update(ctx, p)
+	}
+
+	p.ProviderReference = res.ProviderReference
+	p.CheckoutURL = res.CheckoutURL
+
+	if err := repo.UpdateProviderDetails(ctx, p.ID, p.ProviderReference, p.CheckoutURL); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// HandleWebhook verifies a provider callback and applies it exactly once:
+// redelivered events are recognised by their event ID and ignored, and an
+// attempt that has already succeeded or failed is never changed again.
+func (s *paymentService) HandleWebhook(ctx context.Context, header http.Header, body []byte) error {
+	event, err := s.PaymentProvider.ParseWebhook(header, body, time.Now())
+	if err != nil {
+		if errors.Is(err, payment.ErrInvalidWebhook) {
+			return exception.Wrap(err, exception.TypeUnauthorized, exception.CodeUnauthorized, "invalid payment webhook")
+		}
+
+		return err
+	}
+
+	provider := s.PaymentProvider.Name()
+
+	return s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		p, err := r.Payment().FindByReferenceForUpdate(ctx, provider, event.Reference)
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return exception.Newf(exception.TypeNotFound, exception.CodeNotFound, "payment %s not found", event.Reference)
+		}
+
+		recorded, err := r.Payment().CreateEvent(ctx, &entity.PaymentEvent{
+			Provider:  provider,
+			EventID:   event.ID,
+			Type:      event.Type,
+			PaymentID: &p.ID,
+			Payload:   event.Payload,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !recorded || p.Status != string(constant.PaymentStatusPending) {
+			return nil
+		}
+
+		return s.applyEvent(ctx, r, p, event)
+	})
+}
+
+// applyEvent updates the payment from a new callback. A payment that succeeds
+// after its order stopped waiting for one, e.g. because it was cancelled, is
+// not kept: it is refunded once the transaction commits.
+func (s *paymentService) applyEvent(ctx context.Context, r postgresrepository.PostgresRepository, p *entity.Payment, event *payment.WebhookEvent) error {
+	now := time.Now()
+
+	switch {
+	case event.Type == payment.EventPaymentFailed:
+		p.Status = string(constant.PaymentStatusFailed)
+		p.FailureReason = event.FailureReason
+		p.FailedAt = &now
+	case event.Amount != p.Amount || event.Currency != p.Currency:
+		p.Status = string(constant.PaymentStatusFailed)
+		p.FailureReason = "paid " + event.Amount.String() + " " + event.Currency + ", expected " + p.Amount.String() + " " + p.Currency
+		p.FailedAt = &now
+	default:
+		p.Status = string(constant.PaymentStatusSucceeded)
+		p.PaidAt = &now
+	}
+
+	if _, err := r.Payment().Update(ctx, p); err != nil {
+		return err
+	}
+
+	if p.Status != string(constant.PaymentStatusSucceeded) {
+		return nil
+	}
+
+	createdAt, err := r.Order().LockByID(ctx, p.OrderID)
+	if err != nil {
+		return err
+	}
+
+	confirmed, err := r.Order().TransitionStatus(ctx, p.OrderID, createdAt, string(constant.OrderStatusPendingPayment), string(constant.OrderStatusConfirmed))
+	if err != nil {
+		return err
+	}
+
+	if confirmed {
+		order, err := r.Order().FindByKey(ctx, p.OrderID, createdAt)
+		if err != nil {
+			return err
+		}
+
+		before := *order
+		before.Status = string(constant.OrderStatusPendingPayment)
+
+		if err := recordAudit(ctx, r, constant.AuditActionOrderConfirm, constant.AuditEntityOrder, order.ID, &before, order); err != nil {
+			return err
+		}
+
+		return publishOrderEvent(ctx, s.Properties, r, constant.WebhookEventOrderConfirmed, order)
+	}
+
+	s.log(ctx).Warn().Msgf("Payment %s succeeded for order %d which is no longer awaiting payment, refunding it", p.Reference, p.OrderID)
+
+	order, err := r.Order().FindByKey(ctx, p.OrderID, createdAt)
+	if err != nil {
+		return err
+	}
+	if order == nil {
+		return exception.Newf(exception.TypeNotFound, exception.CodeNotFound, "order %d not found", p.OrderID)
+	}
+
+	_, err = reserveRefund(ctx, s.Properties, r, order, p.ID, &entity.Refund{Reason: "order was no longer awaiting payment"})
+
+	return err
+}
+
+// supersedePendingPayments fails the order's open payment attempts after its
+// total has changed, so none of them can be paid at the old amount. It
+// reports whether there were any, in which case a new attempt should be
+// started once the change commits.
+func supersedePendingPayments(ctx context.Context, r postgresrepository.PostgresRepository, order *entity.Order) (bool, error) {
+	superseded := false
+	now := time.Now()
+
+	for _, p := range order.Payments {
+		if p.Status != string(constant.PaymentStatusPending) {
+			continue
+		}
+
+		p.Status = string(constant.PaymentStatusFailed)
+		p.FailureReason = "order total changed"
+		p.FailedAt = &now
+
+		if _, err := r.Payment().Update(ctx, p); err != nil {
+			return false, err
+		}
+
+		superseded = true
+	}
+
+	return superseded, nil
+}
@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"order-service/config"
+	"order-service/constant"
+	"order-service/internal/adapter/payment"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/service"
+	"order-service/internal/shared/exception"
+	"order-service/mocks"
+	"order-service/pkg/logger"
+	"order-service/pkg/money"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testWebhookSecret = "whsec_test"
+
+func setupPaymentTest(t *testing.T) (service.PaymentService, *mocks.MockOrderRepository, *mocks.MockPaymentRepository) {
+	mRepo := mocks.NewMockRepository(t)
+	mPostgres := mocks.NewMockPostgresRepository(t)
+	mOrder := mocks.NewMockOrderRepository(t)
+	mPayment := mocks.NewMockPaymentRepository(t)
+
+	mRepo.EXPECT().Postgres().Return(mPostgres).Maybe()
+	mPostgres.EXPECT().Order().Return(mOrder).Maybe()
+	withoutWebhookSubscriptions(t, mPostgres)
+	withAuditLog(t, mPostgres)
+	mPostgres.EXPECT().Payment().Return(mPayment).Maybe()
+	mPostgres.EXPECT().
+		Atomic(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ *config.Config, fn postgresrepository.RepositoryAtomicCallback) error {
+			return fn(mPostgres)
+		}).
+		Maybe()
+
+	s := service.NewPaymentService(service.Properties{
+		Config:          &config.Config{},
+		Logger:          logger.NewZerologLogger(false),
+		Repo:            mRepo,
+		PaymentProvider: payment.NewFakeProvider(testWebhookSecret, 5*time.Minute),
+	})
+
+	return s, mOrder, mPayment
+}
+
+func signWebhook(body string) http.Header {
+	now := time.Now()
+	h := http.Header{}
+	h.Set(payment.HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	h.Set(payment.HeaderSignature, payment.Sign(testWebhookSecret, now, []byte(body)))
+
+	return h
+}
+
+func pendingPayment() *entity.Payment {
+	return &entity.Payment{
+		Base:      entity.Base{ID: 7},
+		OrderID:   1,
+		Provider:  payment.ProviderFake,
+		Reference: "pay_1",
+		Status:    string(constant.PaymentStatusPending),
+		Amount:    money.MustParse("150000"),
+		Currency:  "IDR",
+	}
+}
+
+func TestPaymentService_HandleWebhook_Succeeded(t *testing.T) {
+	s, mOrder, mPayment := setupPaymentTest(t)
+	ctx := context.Background()
+	body := `{"id":"evt_1","type":"payment.succeeded","data":{"reference":"pay_1","amount":"150000","currency":"IDR"}}`
+
+	mPayment.EXPECT().FindByReferenceForUpdate(ctx, payment.ProviderFake, "pay_1").Return(pendingPayment(), nil)
+	mPayment.EXPECT().
+		CreateEvent(ctx, mock.MatchedBy(func(e *entity.PaymentEvent) bool {
+			return e.EventID == "evt_1" && *e.PaymentID == 7
+		})).
+		Return(true, nil)
+	mPayment.EXPECT().
+		Update(ctx, mock.MatchedBy(func(p *entity.Payment) bool {
+			return p.Status == string(constant.PaymentStatusSucceeded) && p.PaidAt != nil
+		})).
+		RunAndReturn(func(_ context.Context, p *entity.Payment) (*entity.Payment, error) {
+			return p, nil
+		})
+	mOrder.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+	mOrder.EXPECT().
+		TransitionStatus(ctx, uint32(1), orderCreatedAt, string(constant.OrderStatusPendingPayment), string(constant.OrderStatusConfirmed)).
+		Return(true, nil)
+	mOrder.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(&entity.Order{Base: entity.Base{ID: 1}, Status: string(constant.OrderStatusConfirmed)}, nil)
+
+	err := s.HandleWebhook(ctx, signWebhook(body), []byte(body))
+
+	assert.NoError(t, err)
+}
+
+func TestPaymentService_HandleWebhook_DuplicateEventIgnored(t *testing.T) {
+	s, _, mPayment := setupPaymentTest(t)
+	ctx := context.Background()
+	body := `{"id":"evt_1","type":"payment.succeeded","data":{"reference":"pay_1","amount":"150000","currency":"IDR"}}`
+
+	mPayment.EXPECT().FindByReferenceForUpdate(ctx, payment.ProviderFake, "pay_1").Return(pendingPayment(), nil)
+	mPayment.EXPECT().CreateEvent(ctx, mock.Anything).Return(false, nil)
+
+	err := s.HandleWebhook(ctx, signWebhook(body), []byte(body))
+
+	// Neither the payment nor the order may be touched a second time.
+	assert.NoError(t, err)
+}
+
+func TestPaymentService_HandleWebhook_AmountMismatch(t *testing.T) {
+	s, _, mPayment := setupPaymentTest(t)
+	ctx := context.Background()
+	body := `{"id":"evt_2","type":"payment.succeeded","data":{"reference":"pay_1","amount":"1","currency":"IDR"}}`
+
+	mPayment.EXPECT().FindByReferenceForUpdate(ctx, payment.ProviderFake, "pay_1").Return(pendingPayment(), nil)
+	mPayment.EXPECT().CreateEvent(ctx, mock.Anything).Return(true, nil)
+	mPayment.EXPECT().
+		Update(ctx, mock.MatchedBy(func(p *entity.Payment) bool {
+			return p.Status == string(constant.PaymentStatusFailed) && p.FailureReason != ""
+		})).
+		RunAndReturn(func(_ context.Context, p *entity.Payment) (*entity.Payment, error) {
+			return p, nil
+		})
+
+	err := s.HandleWebhook(ctx, signWebhook(body), []byte(body))
+
+	assert.NoError(t, err)
+}
+
+func TestPaymentService_HandleWebhook_Failed(t *testing.T) {
+	s, _, mPayment := setupPaymentTest(t)
+	ctx := context.Background()
+	body := `{"id":"evt_3","type":"payment.failed","data":{"reference":"pay_1","failure_reason":"card declined"}}`
+
+	mPayment.EXPECT().FindByReferenceForUpdate(ctx, payment.ProviderFake, "pay_1").Return(pendingPayment(), nil)
+	mPayment.EXPECT().CreateEvent(ctx, mock.Anything).Return(true, nil)
+	mPayment.EXPECT().
+		Update(ctx, mock.MatchedBy(func(p *entity.Payment) bool {
+			return p.Status == string(constant.PaymentStatusFailed) && p.FailureReason == "card declined" && p.FailedAt != nil
+		})).
+		RunAndReturn(func(_ context.Context, p *entity.Payment) (*entity.Payment, error) {
+			return p, nil
+		})
+
+	err := s.HandleWebhook(ctx, signWebhook(body), []byte(body))
+
+	assert.NoError(t, err)
+}
+
+func TestPaymentService_HandleWebhook_InvalidSignature(t *testing.T) {
+	s, _, _ := setupPaymentTest(t)
+	body := `{"id":"evt_1","type":"payment.succeeded","data":{"reference":"pay_1","amount":"150000","currency":"IDR"}}`
+	header := signWebhook(body)
+	header.Set(payment.HeaderSignature, "deadbeef")
+
+	err := s.HandleWebhook(context.Background(), header, []byte(body))
+
+	var ex *exception.Exception
+	if assert.True(t, errors.As(err, &ex)) {
+		assert.Equal(t, exception.TypeUnauthorized, ex.Type)
+	}
+}
+
+func TestPaymentService_Start_RejectsWhileAttemptPending(t *testing.T) {
+	s, mOrder, _ := setupPaymentTest(t)
+	ctx := context.Background()
+
+	mOrder.EXPECT().FindByID(ctx, uint32(1)).Return(&entity.Order{
+		Base:     entity.Base{ID: 1},
+		Status:   string(constant.OrderStatusPendingPayment),
+		Payments: []*entity.Payment{pendingPayment()},
+	}, nil)
+
+	_, err := s.Start(ctx, 1)
+
+	var ex *exception.Exception
+	if assert.True(t, errors.As(err, &ex)) {
+		assert.Equal(t, exception.TypeConflict, ex.Type)
+	}
+}
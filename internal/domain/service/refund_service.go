@@ -0,0 +1,372 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"order-service/constant"
+	"order-service/internal/adapter/payment"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/domain/audit"
+	"order-service/internal/domain/entity"
+	"order-service/internal/jobqueue"
+	"order-service/internal/shared"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/money"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+var _ RefundService = (*refundService)(nil)
+
+// errNothingToRefund is the cause of the error reserveRefund returns when a
+// full refund is asked for but nothing is left to refund.
+var errNothingToRefund = errors.New("nothing left to refund")
+
+type RefundService interface {
+	Create(ctx context.Context, orderID uint32, refund *entity.Refund) (*entity.Refund, error)
+	Retry(ctx context.Context, id uint32) (*entity.Refund, error)
+	Process(ctx context.Context, id uint32, final bool) error
+}
+
+type refundService struct {
+	Properties
+}
+
+func NewRefundService(props Properties) *refundService {
+	return &refundService{
+		Properties: props,
+	}
+}
+
+// Create refunds the order's captured payment. refund.Items selects order
+// items and quantities to refund; otherwise refund.Amount is refunded, or
+// everything not yet refunded when the amount is zero. The refund is returned
+// as requested and sent to the provider by a job.
+func (s *refundService) Create(ctx context.Context, orderID uint32, refund *entity.Refund) (*entity.Refund, error) {
+	order, err := s.Repo.Postgres().Order().FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, exception.New(exception.TypeNotFound, exception.CodeNotFound, "order not found")
+	}
+
+	captured := capturedPayment(order)
+	if captured == nil {
+		return nil, exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "order has no captured payment")
+	}
+
+	var created *entity.Refund
+
+	err = s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		created, err = reserveRefund(ctx, s.Properties, r, order, captured.ID, refund)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Retry queues a refund that failed to be sent to the provider again. A
+// failed refund no longer counts towards the refunded amount, so the limit is
+// checked again before it is reinstated. A refund still requested already
+// has a job, which is left to run alone; when that job is dead-lettered, it
+// is retried from the job queue.
+func (s *refundService) Retry(ctx context.Context, id uint32) (*entity.Refund, error) {
+	var refund *entity.Refund
+
+	err := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		var err error
+
+		refund, err = r.Refund().FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if refund == nil {
+			return exception.New(exception.TypeNotFound, exception.CodeNotFound, "refund not found")
+		}
+
+		switch refund.Status {
+		case string(constant.RefundStatusSucceeded):
+			return exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "refund has already succeeded")
+		case string(constant.RefundStatusRequested):
+			return exception.New(exception.TypeConflict, exception.CodeConflict, "refund is already queued")
+		}
+
+		p, err := r.Payment().FindByIDForUpdate(ctx, refund.PaymentID)
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return exception.Newf(exception.TypeNotFound, exception.CodeNotFound, "payment %d of refund %d not found", refund.PaymentID, refund.ID)
+		}
+
+		existing, err := r.Refund().FindByPaymentID(ctx, refund.PaymentID)
+		if err != nil {
+			return err
+		}
+
+		refunded, _ := refundedSoFar(existing)
+		if refunded.Add(refund.Amount).Cmp(p.Amount) > 0 {
+			return exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest,
+				"refund of %s exceeds the refundable amount %s", refund.Amount, p.Amount.Sub(refunded))
+		}
+
+		before, err := audit.Snapshot(refund)
+		if err != nil {
+			return err
+		}
+
+		refund.Status = string(constant.RefundStatusRequested)
+		refund.FailedAt = nil
+
+		refund, err = r.Refund().Update(ctx, refund)
+		if err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, r, constant.AuditActionRefundRetry, constant.AuditEntityRefund, refund.ID, before, refund); err != nil {
+			return err
+		}
+
+		return queueRefund(ctx, s.Properties, r, refund.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+// reserveRefund records a requested refund against a captured payment and
+// queues it to be sent to the provider. It must run in a transaction: the
+// payment row lock it takes serialises refunds of the same payment, so
+// together they never exceed the captured amount, and the refund is only sent
+// once it has been committed.
+func reserveRefund(ctx context.Context, props Properties, r postgresrepository.PostgresRepository, order *entity.Order, paymentID uint32, req *entity.Refund) (*entity.Refund, error) {
+	p, err := r.Payment().FindByIDForUpdate(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, exception.New(exception.TypeNotFound, exception.CodeNotFound, "payment not found")
+	}
+
+	if p.Status != string(constant.PaymentStatusSucceeded) {
+		return nil, exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "payment has not been captured")
+	}
+
+	existing, err := r.Refund().FindByPaymentID(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	refunded, refundedQty := refundedSoFar(existing)
+
+	refund := &entity.Refund{
+		OrderID:   order.ID,
+		PaymentID: p.ID,
+		Status:    string(constant.RefundStatusRequested),
+		Currency:  p.Currency,
+		Reason:    req.Reason,
+	}
+
+	switch {
+	case len(req.Items) > 0:
+		if !req.Amount.IsZero() {
+			return nil, exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "specify either items or an amount to refund, not both")
+		}
+
+		if refund.Items, err = refundItems(order, req.Items, refundedQty, p.Currency); err != nil {
+			return nil, err
+		}
+
+		for _, item := range refund.Items {
+			refund.Amount = refund.Amount.Add(item.Amount)
+		}
+	case req.Amount.IsZero():
+		refund.Amount = p.Amount.Sub(refunded)
+		if refund.Amount.IsZero() {
+			return nil, exception.Wrap(errNothingToRefund, exception.TypeBadRequest, exception.CodeBadRequest, "payment is already fully refunded")
+		}
+	case req.Amount.IsNegative():
+		return nil, exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "refund amount must be positive")
+	default:
+		refund.Amount = req.Amount
+	}
+
+	if refund.Amount.IsNegative() || refund.Amount.IsZero() {
+		return nil, exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "refund amount must be positive")
+	}
+
+	if refunded.Add(refund.Amount).Cmp(p.Amount) > 0 {
+		return nil, exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest,
+			"refund of %s exceeds the refundable amount %s", refund.Amount, p.Amount.Sub(refunded))
+	}
+
+	id, err := shared.GenerateUUIDString()
+	if err != nil {
+		return nil, err
+	}
+
+	refund.Reference = "ref_" + id
+
+	created, err := r.Refund().Create(ctx, refund)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordAudit(ctx, r, constant.AuditActionRefundCreate, constant.AuditEntityRefund, created.ID, nil, created); err != nil {
+		return nil, err
+	}
+
+	if err := queueRefund(ctx, props, r, created.ID); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// queueRefund queues a job that sends the refund to the provider, retried
+// with the queue's backoff up to the configured number of attempts.
+func queueRefund(ctx context.Context, props Properties, r postgresrepository.PostgresRepository, id uint32) error {
+	return enqueueJob(ctx, r, JobTypeProcessRefund, processRefundPayload{RefundID: id},
+		jobqueue.MaxAttempts(max(props.Config.Payment.RefundMaxAttempts, 1)))
+}
+
+// refundedSoFar sums the refunds that have not failed, in total and as
+// quantities per order item.
+func refundedSoFar(refunds []*entity.Refund) (money.Money, map[uint32]int) {
+	var total money.Money
+
+	qty := make(map[uint32]int)
+
+	for _, refund := range refunds {
+		if refund.Status == string(constant.RefundStatusFailed) {
+			continue
+		}
+
+		total = total.Add(refund.Amount)
+
+		for _, item := range refund.Items {
+			qty[item.OrderItemID] += item.Quantity
+		}
+	}
+
+	return total, qty
+}
+
+// refundItems prices the requested quantities of order items at what was
+// actually paid for them, after discounts and including tax. Only the units
+// that have not been cancelled can be refunded: those cancelled before the
+// payment was captured were never paid for, and those cancelled after it were
+// refunded when they were cancelled. Units are priced cumulatively so that
+// refunding a line in several parts adds up to exactly what was paid for it.
+func refundItems(order *entity.Order, reqs []*entity.RefundItem, refundedQty map[uint32]int, currency string) ([]*entity.RefundItem, error) {
+	items := make(map[uint32]*entity.OrderItem, len(order.Items))
+	for _, item := range order.Items {
+		items[item.ID] = item
+	}
+
+	places := money.MinorUnits(currency)
+	res := make([]*entity.RefundItem, 0, len(reqs))
+
+	for _, req := range reqs {
+		item, ok := items[req.OrderItemID]
+		if !ok {
+			return nil, exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "order item %d does not belong to this order", req.OrderItemID)
+		}
+
+		active := item.Quantity - item.CancelledQuantity
+		done := refundedQty[item.ID]
+		if req.Quantity <= 0 || done+req.Quantity > active {
+			return nil, exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest,
+				"only %d of order item %d can still be refunded", max(active-done, 0), item.ID)
+		}
+
+		paid := item.TaxableAmount.Add(item.TaxAmount).Sub(unitsCost(item, item.CancelledQuantity, places))
+		upTo := paid.MulRat(big.NewRat(int64(done+req.Quantity), int64(active)), places, money.RoundHalfUp)
+		before := paid.MulRat(big.NewRat(int64(done), int64(active)), places, money.RoundHalfUp)
+
+		refundedQty[item.ID] = done + req.Quantity
+
+		res = append(res, &entity.RefundItem{
+			OrderItemID: item.ID,
+			Quantity:    req.Quantity,
+			Amount:      upTo.Sub(before),
+		})
+	}
+
+	return res, nil
+}
+
+// unitsCost is what the first n units of item cost after discounts and
+// including tax, rounded as cancelUnits takes cancelled units off the order.
+func unitsCost(item *entity.OrderItem, n int, places int) money.Money {
+	share := big.NewRat(int64(n), int64(item.Quantity))
+
+	return item.TaxableAmount.MulRat(share, places, money.RoundHalfUp).
+		Add(item.TaxAmount.MulRat(share, places, money.RoundHalfUp))
+}
+
+// Process sends a requested refund to the provider and records the outcome.
+// A refund that is no longer requested, e.g. because an earlier attempt has
+// succeeded, is left alone. A provider failure is returned so the job is
+// retried, and is recorded as the refund's failure once final is set.
+func (s *refundService) Process(ctx context.Context, id uint32, final bool) error {
+	refund, err := s.Repo.Postgres().Refund().FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if refund == nil || refund.Status != string(constant.RefundStatusRequested) {
+		return nil
+	}
+
+	p, err := s.Repo.Postgres().Payment().FindByID(ctx, refund.PaymentID)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return jobqueue.Permanent(fmt.Errorf("payment %d of refund %s not found", refund.PaymentID, refund.Reference))
+	}
+
+	res, sendErr := s.PaymentProvider.Refund(ctx, &payment.RefundRequest{
+		Reference:                refund.Reference,
+		PaymentReference:         p.Reference,
+		PaymentProviderReference: p.ProviderReference,
+		Amount:                   refund.Amount,
+		Currency:                 refund.Currency,
+		Reason:                   refund.Reason,
+	})
+
+	now := time.Now()
+	refund.Attempts++
+
+	switch {
+	case sendErr == nil:
+		refund.Status = string(constant.RefundStatusSucceeded)
+		refund.ProviderReference = res.ProviderReference
+		refund.LastError = ""
+		refund.RefundedAt = &now
+	case final:
+		refund.Status = string(constant.RefundStatusFailed)
+		refund.LastError = sendErr.Error()
+		refund.FailedAt = &now
+	default:
+		refund.LastError = sendErr.Error()
+	}
+
+	if _, err := s.Repo.Postgres().Refund().Update(ctx, refund); err != nil {
+		return err
+	}
+
+	if sendErr != nil {
+		return fmt.Errorf("refund %s attempt %d failed: %w", refund.Reference, refund.Attempts, sendErr)
+	}
+
+	return nil
+}
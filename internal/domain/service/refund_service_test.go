@@ -0,0 +1,445 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/config"
+	"order-service/constant"
+	"order-service/internal/adapter/payment"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/service"
+	"order-service/internal/shared/exception"
+	"order-service/mocks"
+	"order-service/pkg/logger"
+	"order-service/pkg/money"
+	"order-service/proto/pb"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type refundTest struct {
+	props    service.Properties
+	service  service.RefundService
+	order    *mocks.MockOrderRepository
+	payment  *mocks.MockPaymentRepository
+	refund   *mocks.MockRefundRepository
+	provider *mocks.MockPaymentProvider
+	jobs     []*entity.Job
+}
+
+func setupRefundTest(t *testing.T) *refundTest {
+	mRepo := mocks.NewMockRepository(t)
+	mPostgres := mocks.NewMockPostgresRepository(t)
+	rt := &refundTest{
+		order:    mocks.NewMockOrderRepository(t),
+		payment:  mocks.NewMockPaymentRepository(t),
+		refund:   mocks.NewMockRefundRepository(t),
+		provider: mocks.NewMockPaymentProvider(t),
+	}
+	mJob := mocks.NewMockJobRepository(t)
+
+	mRepo.EXPECT().Postgres().Return(mPostgres).Maybe()
+	mPostgres.EXPECT().Order().Return(rt.order).Maybe()
+	withoutWebhookSubscriptions(t, mPostgres)
+	withAuditLog(t, mPostgres)
+	mPostgres.EXPECT().Payment().Return(rt.payment).Maybe()
+	mPostgres.EXPECT().Refund().Return(rt.refund).Maybe()
+	mPostgres.EXPECT().Job().Return(mJob).Maybe()
+	mPostgres.EXPECT().
+		Atomic(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ *config.Config, fn postgresrepository.RepositoryAtomicCallback) error {
+			return fn(mPostgres)
+		}).
+		Maybe()
+
+	// Refunds are created and updated as they are passed in.
+	rt.refund.EXPECT().
+		Create(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, r *entity.Refund) (*entity.Refund, error) {
+			r.ID = 9
+			return r, nil
+		}).
+		Maybe()
+	rt.refund.EXPECT().
+		Update(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, r *entity.Refund) (*entity.Refund, error) {
+			return r, nil
+		}).
+		Maybe()
+	mJob.EXPECT().
+		Create(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, job *entity.Job) (*entity.Job, error) {
+			rt.jobs = append(rt.jobs, job)
+			return job, nil
+		}).
+		Maybe()
+
+	rt.props = service.Properties{
+		Config: &config.Config{
+			Payment: &config.PaymentConfig{RefundMaxAttempts: 2},
+		},
+		Logger:          logger.NewZerologLogger(false),
+		Repo:            mRepo,
+		PaymentProvider: rt.provider,
+	}
+	rt.service = service.NewRefundService(rt.props)
+
+	return rt
+}
+
+func capturedPayment() *entity.Payment {
+	return &entity.Payment{
+		Base:      entity.Base{ID: 7},
+		OrderID:   1,
+		Reference: "pay_1",
+		Status:    string(constant.PaymentStatusSucceeded),
+		Amount:    money.MustParse("100"),
+		Currency:  "USD",
+	}
+}
+
+// paidOrder has one line of three units that cost 100.00 in total after
+// discounts and tax.
+func paidOrder() *entity.Order {
+	return &entity.Order{
+		Base:     entity.Base{ID: 1},
+		Status:   string(constant.OrderStatusConfirmed),
+		Currency: "USD",
+		Items: []*entity.OrderItem{{
+			Base:          entity.Base{ID: 50},
+			Quantity:      3,
+			TaxableAmount: money.MustParse("90.91"),
+			TaxAmount:     money.MustParse("9.09"),
+			ReservationID: ptr(uint32(900)),
+		}},
+		Payments: []*entity.Payment{capturedPayment()},
+	}
+}
+
+// queuedRefund asserts that exactly one refund job was queued and returns
+// its payload.
+func (rt *refundTest) queuedRefund(t *testing.T) string {
+	t.Helper()
+
+	require.Len(t, rt.jobs, 1)
+	assert.Equal(t, service.JobTypeProcessRefund, rt.jobs[0].Type)
+	assert.Equal(t, 2, rt.jobs[0].MaxAttempts)
+
+	return string(rt.jobs[0].Payload)
+}
+
+func (rt *refundTest) expectPayment(existing []*entity.Refund) {
+	rt.order.EXPECT().FindByID(mock.Anything, uint32(1)).Return(paidOrder(), nil)
+	rt.payment.EXPECT().FindByIDForUpdate(mock.Anything, uint32(7)).Return(capturedPayment(), nil)
+	rt.payment.EXPECT().FindByID(mock.Anything, uint32(7)).Return(capturedPayment(), nil).Maybe()
+	rt.refund.EXPECT().FindByPaymentID(mock.Anything, uint32(7)).Return(existing, nil)
+}
+
+func TestRefundService_Create_ItemsPricedCumulatively(t *testing.T) {
+	rt := setupRefundTest(t)
+	ctx := context.Background()
+
+	// One unit was already refunded at 33.33; the remaining two must make up
+	// exactly the other 66.67.
+	rt.expectPayment([]*entity.Refund{{
+		Status: string(constant.RefundStatusSucceeded),
+		Amount: money.MustParse("33.33"),
+		Items:  []*entity.RefundItem{{OrderItemID: 50, Quantity: 1, Amount: money.MustParse("33.33")}},
+	}})
+
+	refund, err := rt.service.Create(ctx, 1, &entity.Refund{
+		Items: []*entity.RefundItem{{OrderItemID: 50, Quantity: 2}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, money.MustParse("66.67"), refund.Amount)
+	assert.Equal(t, string(constant.RefundStatusRequested), refund.Status)
+	assert.JSONEq(t, `{"refund_id":9}`, rt.queuedRefund(t))
+}
+
+func TestRefundService_Create_FullRefundOfRemainder(t *testing.T) {
+	rt := setupRefundTest(t)
+	ctx := context.Background()
+
+	// Failed refunds do not count towards what has been refunded.
+	rt.expectPayment([]*entity.Refund{
+		{Status: string(constant.RefundStatusSucceeded), Amount: money.MustParse("40")},
+		{Status: string(constant.RefundStatusFailed), Amount: money.MustParse("60")},
+	})
+
+	refund, err := rt.service.Create(ctx, 1, &entity.Refund{})
+
+	require.NoError(t, err)
+	assert.Equal(t, money.MustParse("60"), refund.Amount)
+}
+
+func TestRefundService_Create_ExceedsCapturedAmount(t *testing.T) {
+	rt := setupRefundTest(t)
+
+	rt.expectPayment([]*entity.Refund{
+		{Status: string(constant.RefundStatusRequested), Amount: money.MustParse("70")},
+	})
+
+	_, err := rt.service.Create(context.Background(), 1, &entity.Refund{Amount: money.MustParse("30.01")})
+
+	var ex *exception.Exception
+	require.True(t, errors.As(err, &ex))
+	assert.Equal(t, exception.TypeBadRequest, ex.Type)
+	assert.Contains(t, ex.Message, "exceeds the refundable amount 30")
+}
+
+func TestRefundService_Create_ItemQuantityExceeded(t *testing.T) {
+	rt := setupRefundTest(t)
+
+	rt.expectPayment([]*entity.Refund{{
+		Status: string(constant.RefundStatusSucceeded),
+		Amount: money.MustParse("66.67"),
+		Items:  []*entity.RefundItem{{OrderItemID: 50, Quantity: 2}},
+	}})
+
+	_, err := rt.service.Create(context.Background(), 1, &entity.Refund{
+		Items: []*entity.RefundItem{{OrderItemID: 50, Quantity: 2}},
+	})
+
+	assert.ErrorContains(t, err, "only 1 of order item 50 can still be refunded")
+}
+
+func TestRefundService_Create_PartiallyCancelledLine(t *testing.T) {
+	rt := setupRefundTest(t)
+	ctx := context.Background()
+
+	// One of the three units was cancelled before the payment was captured,
+	// so only the other two, which cost 66.67, were paid for.
+	order := paidOrder()
+	order.Items[0].CancelledQuantity = 1
+
+	rt.order.EXPECT().FindByID(mock.Anything, uint32(1)).Return(order, nil)
+	rt.payment.EXPECT().FindByIDForUpdate(mock.Anything, uint32(7)).Return(capturedPayment(), nil)
+	rt.payment.EXPECT().FindByID(mock.Anything, uint32(7)).Return(capturedPayment(), nil).Maybe()
+	rt.refund.EXPECT().FindByPaymentID(mock.Anything, uint32(7)).Return(nil, nil)
+
+	_, err := rt.service.Create(ctx, 1, &entity.Refund{
+		Items: []*entity.RefundItem{{OrderItemID: 50, Quantity: 3}},
+	})
+	assert.ErrorContains(t, err, "only 2 of order item 50 can still be refunded")
+
+	refund, err := rt.service.Create(ctx, 1, &entity.Refund{
+		Items: []*entity.RefundItem{{OrderItemID: 50, Quantity: 2}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, money.MustParse("66.67"), refund.Amount)
+}
+
+func requestedRefund() *entity.Refund {
+	return &entity.Refund{
+		Base:      entity.Base{ID: 9},
+		PaymentID: 7,
+		Reference: "ref_1",
+		Status:    string(constant.RefundStatusRequested),
+		Amount:    money.MustParse("10"),
+		Currency:  "USD",
+	}
+}
+
+func TestRefundService_Process_Succeeds(t *testing.T) {
+	rt := setupRefundTest(t)
+	ctx := context.Background()
+	refund := requestedRefund()
+
+	rt.refund.EXPECT().FindByID(ctx, uint32(9)).Return(refund, nil)
+	rt.payment.EXPECT().FindByID(ctx, uint32(7)).Return(capturedPayment(), nil)
+	rt.provider.EXPECT().
+		Refund(ctx, mock.MatchedBy(func(r *payment.RefundRequest) bool {
+			return r.Amount == money.MustParse("10") && r.PaymentReference == "pay_1"
+		})).
+		Return(&payment.RefundResponse{ProviderReference: "re_1"}, nil)
+
+	err := rt.service.Process(ctx, 9, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, string(constant.RefundStatusSucceeded), refund.Status)
+	assert.Equal(t, "re_1", refund.ProviderReference)
+	assert.Equal(t, 1, refund.Attempts)
+	assert.NotNil(t, refund.RefundedAt)
+}
+
+func TestRefundService_Process_ProviderFailureIsRetriedThenRecorded(t *testing.T) {
+	rt := setupRefundTest(t)
+	ctx := context.Background()
+	refund := requestedRefund()
+
+	rt.refund.EXPECT().FindByID(ctx, uint32(9)).Return(refund, nil)
+	rt.payment.EXPECT().FindByID(ctx, uint32(7)).Return(capturedPayment(), nil)
+	rt.provider.EXPECT().Refund(ctx, mock.Anything).Return(nil, errors.New("gateway timeout")).Times(2)
+
+	// The failure is returned so the job is retried; the refund stays
+	// requested until the last attempt.
+	err := rt.service.Process(ctx, 9, false)
+
+	require.ErrorContains(t, err, "gateway timeout")
+	assert.Equal(t, string(constant.RefundStatusRequested), refund.Status)
+	assert.Equal(t, "gateway timeout", refund.LastError)
+
+	err = rt.service.Process(ctx, 9, true)
+
+	require.Error(t, err)
+	assert.Equal(t, string(constant.RefundStatusFailed), refund.Status)
+	assert.Equal(t, 2, refund.Attempts)
+	assert.NotNil(t, refund.FailedAt)
+}
+
+func TestRefundService_Process_SkipsCompletedRefund(t *testing.T) {
+	rt := setupRefundTest(t)
+	refund := requestedRefund()
+	refund.Status = string(constant.RefundStatusSucceeded)
+
+	rt.refund.EXPECT().FindByID(mock.Anything, uint32(9)).Return(refund, nil)
+
+	assert.NoError(t, rt.service.Process(context.Background(), 9, false))
+}
+
+func TestRefundService_Retry_RejectsSucceeded(t *testing.T) {
+	rt := setupRefundTest(t)
+
+	rt.refund.EXPECT().FindByID(mock.Anything, uint32(9)).Return(&entity.Refund{
+		Base:   entity.Base{ID: 9},
+		Status: string(constant.RefundStatusSucceeded),
+	}, nil)
+
+	_, err := rt.service.Retry(context.Background(), 9)
+
+	assert.ErrorContains(t, err, "refund has already succeeded")
+}
+
+func TestRefundService_Retry_RejectsRequested(t *testing.T) {
+	rt := setupRefundTest(t)
+
+	rt.refund.EXPECT().FindByID(mock.Anything, uint32(9)).Return(requestedRefund(), nil)
+
+	_, err := rt.service.Retry(context.Background(), 9)
+
+	// Its job is still queued; a second one could send it twice.
+	assert.ErrorContains(t, err, "refund is already queued")
+}
+
+func TestRefundService_Retry_PaymentNotFound(t *testing.T) {
+	rt := setupRefundTest(t)
+	ctx := context.Background()
+	failed := requestedRefund()
+	failed.Status = string(constant.RefundStatusFailed)
+
+	rt.refund.EXPECT().FindByID(ctx, uint32(9)).Return(failed, nil)
+	rt.payment.EXPECT().FindByIDForUpdate(ctx, uint32(7)).Return(nil, nil)
+
+	_, err := rt.service.Retry(ctx, 9)
+
+	assert.ErrorContains(t, err, "payment 7 of refund 9 not found")
+}
+
+func TestRefundService_Retry_QueuesFailedRefund(t *testing.T) {
+	rt := setupRefundTest(t)
+	ctx := context.Background()
+	failed := requestedRefund()
+	failed.Status = string(constant.RefundStatusFailed)
+
+	rt.refund.EXPECT().FindByID(ctx, uint32(9)).Return(failed, nil)
+	rt.payment.EXPECT().FindByIDForUpdate(ctx, uint32(7)).Return(capturedPayment(), nil)
+	rt.refund.EXPECT().FindByPaymentID(ctx, uint32(7)).Return([]*entity.Refund{failed}, nil)
+
+	refund, err := rt.service.Retry(ctx, 9)
+
+	require.NoError(t, err)
+	assert.Equal(t, string(constant.RefundStatusRequested), refund.Status)
+	assert.JSONEq(t, `{"refund_id":9}`, rt.queuedRefund(t))
+}
+
+func TestOrderService_Cancel_RefundsCapturedPayment(t *testing.T) {
+	rt := setupRefundTest(t)
+	ctx := context.Background()
+	mInventory := mocks.NewMockInventoryServiceClient(t)
+	rt.props.InventoryServiceClient = mInventory
+	s := service.NewOrderService(rt.props)
+
+	rt.expectPayment(nil)
+	mInventory.EXPECT().UpdateReservationStatus(ctx, mock.Anything, mock.Anything).Return(&emptypb.Empty{}, nil)
+	rt.order.EXPECT().
+		TransitionStatus(ctx, uint32(1), time.Time{}, string(constant.OrderStatusConfirmed), string(constant.OrderStatusCancelled)).
+		Return(true, nil)
+	rt.order.EXPECT().
+		SetCancelled(ctx, uint32(1), string(constant.CancellationReasonRequested), mock.Anything).
+		Return(nil)
+	rt.refund.EXPECT().
+		Create(ctx, mock.MatchedBy(func(r *entity.Refund) bool {
+			return r.Amount == money.MustParse("100") && r.Reason == "order cancelled"
+		})).
+		Return(&entity.Refund{Base: entity.Base{ID: 9}}, nil)
+
+	err := s.Cancel(ctx, 1)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"refund_id":9}`, rt.queuedRefund(t))
+}
+
+func TestOrderService_CancelItem_RefundsCancelledUnits(t *testing.T) {
+	rt := setupRefundTest(t)
+	ctx := context.Background()
+	mInventory := mocks.NewMockInventoryServiceClient(t)
+	rt.props.InventoryServiceClient = mInventory
+	s := service.NewOrderService(rt.props)
+
+	order := paidOrder()
+	order.Items[0].ProductID = "101"
+
+	rt.order.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+	rt.order.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(order, nil)
+	rt.order.EXPECT().CreateAdjustment(ctx, mock.Anything).RunAndReturn(func(_ context.Context, a *entity.OrderAdjustment) (*entity.OrderAdjustment, error) {
+		return a, nil
+	})
+	rt.order.EXPECT().UpdateTotals(ctx, order).Return(nil)
+	rt.order.EXPECT().UpdateItem(ctx, mock.Anything).Return(nil)
+	rt.order.EXPECT().SwapItemReservation(ctx, uint32(50), ptr(uint32(900)), ptr(uint32(902))).Return(true, nil)
+	rt.payment.EXPECT().FindByIDForUpdate(ctx, uint32(7)).Return(capturedPayment(), nil)
+	rt.refund.EXPECT().FindByPaymentID(ctx, uint32(7)).Return(nil, nil)
+	mInventory.EXPECT().CreateReservation(ctx, mock.Anything).Return(&pb.Reservation{Id: 902}, nil)
+	mInventory.EXPECT().UpdateReservationStatus(ctx, mock.Anything).Return(&emptypb.Empty{}, nil)
+
+	// The cancelled unit is refunded what it cost, as an amount, so that it
+	// is not counted against the units left on the line.
+	rt.refund.EXPECT().
+		Create(ctx, mock.MatchedBy(func(r *entity.Refund) bool {
+			return r.Amount == money.MustParse("33.33") && len(r.Items) == 0
+		})).
+		Return(&entity.Refund{Base: entity.Base{ID: 9}}, nil)
+
+	_, err := s.CancelItem(ctx, 1, 50, 1)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"refund_id":9}`, rt.queuedRefund(t))
+	assert.Equal(t, 1, order.Items[0].CancelledQuantity)
+}
+
+func TestOrderService_CancelItem_RejectsRefundedUnits(t *testing.T) {
+	rt := setupRefundTest(t)
+	ctx := context.Background()
+	s := service.NewOrderService(rt.props)
+
+	rt.order.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+	rt.order.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(paidOrder(), nil)
+	rt.refund.EXPECT().FindByPaymentID(ctx, uint32(7)).Return([]*entity.Refund{{
+		Status: string(constant.RefundStatusSucceeded),
+		Amount: money.MustParse("66.67"),
+		Items:  []*entity.RefundItem{{OrderItemID: 50, Quantity: 2}},
+	}}, nil)
+
+	_, err := s.CancelItem(ctx, 1, 50, 2)
+
+	assert.ErrorContains(t, err, "only 1 of order item 50 can still be cancelled")
+}
@@ -0,0 +1,406 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"order-service/constant"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/domain/audit"
+	"order-service/internal/domain/entity"
+	"order-service/internal/jobqueue"
+	"order-service/internal/shared/exception"
+	"order-service/proto/pb"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+var _ ReturnService = (*returnService)(nil)
+
+// ReturnService runs the return workflow: a customer requests a return, an
+// admin approves or rejects it, and receiving the goods restocks them and
+// refunds what was paid for them.
+type ReturnService interface {
+	Create(ctx context.Context, orderID uint32, orderReturn *entity.OrderReturn) (*entity.OrderReturn, error)
+	Approve(ctx context.Context, id uint32) (*entity.OrderReturn, error)
+	Reject(ctx context.Context, id uint32, reason string) (*entity.OrderReturn, error)
+	Receive(ctx context.Context, id uint32) (*entity.OrderReturn, error)
+	Restock(ctx context.Context, id uint32) error
+}
+
+type returnService struct {
+	Properties
+}
+
+func NewReturnService(props Properties) *returnService {
+	return &returnService{
+		Properties: props,
+	}
+}
+
+func (s *returnService) Create(ctx context.Context, orderID uint32, orderReturn *entity.OrderReturn) (*entity.OrderReturn, error) {
+	var created *entity.OrderReturn
+
+	// The order lock serialises return requests for the same order, so
+	// concurrent ones cannot together exceed the delivered quantity.
+	err := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		createdAt, err := r.Order().LockByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		order, err := r.Order().FindByKey(ctx, orderID, createdAt)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return exception.New(exception.TypeNotFound, exception.CodeNotFound, "order not found")
+		}
+
+		if order.Status != string(constant.OrderStatusDelivered) || order.DeliveredAt == nil {
+			return exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "only delivered orders can be returned")
+		}
+
+		item := findOrderItem(order, orderReturn.OrderItemID)
+		if item == nil {
+			return exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "order item %d does not belong to this order", orderReturn.OrderItemID)
+		}
+
+		deadline, ok := s.ReturnPolicy.Deadline(item.ProductID, *order.DeliveredAt)
+		if !ok {
+			return exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest, "product %s cannot be returned", item.ProductID)
+		}
+		if time.Now().After(deadline) {
+			return exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest,
+				"the return window for order item %d closed on %s", item.ID, deadline.Format(time.DateOnly))
+		}
+
+		existing, err := r.OrderReturn().FindByOrderItemID(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+
+		returned := 0
+		for _, e := range existing {
+			if e.Status != string(constant.ReturnStatusRejected) {
+				returned += e.Quantity
+			}
+		}
+
+		delivered := item.Quantity - item.CancelledQuantity
+		if orderReturn.Quantity <= 0 || returned+orderReturn.Quantity > delivered {
+			return exception.Newf(exception.TypeBadRequest, exception.CodeBadRequest,
+				"only %d of order item %d can still be returned", delivered-returned, item.ID)
+		}
+
+		created, err = r.OrderReturn().Create(ctx, &entity.OrderReturn{
+			OrderID:     order.ID,
+			OrderItemID: item.ID,
+			Quantity:    orderReturn.Quantity,
+			ReasonCode:  orderReturn.ReasonCode,
+			Note:        orderReturn.Note,
+			Status:      string(constant.ReturnStatusRequested),
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, r, constant.AuditActionReturnCreate, constant.AuditEntityReturn, created.ID, nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *returnService) Approve(ctx context.Context, id uint32) (*entity.OrderReturn, error) {
+	return s.transition(ctx, id, constant.AuditActionReturnApprove, func(orderReturn *entity.OrderReturn) error {
+		if orderReturn.Status != string(constant.ReturnStatusRequested) {
+			return exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "only requested returns can be approved")
+		}
+
+		now := time.Now()
+		orderReturn.Status = string(constant.ReturnStatusApproved)
+		orderReturn.ApprovedAt = &now
+
+		return nil
+	})
+}
+
+func (s *returnService) Reject(ctx context.Context, id uint32, reason string) (*entity.OrderReturn, error) {
+	return s.transition(ctx, id, constant.AuditActionReturnReject, func(orderReturn *entity.OrderReturn) error {
+		switch orderReturn.Status {
+		case string(constant.ReturnStatusRequested), string(constant.ReturnStatusApproved):
+		default:
+			return exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "only requested or approved returns can be rejected")
+		}
+
+		now := time.Now()
+		orderReturn.Status = string(constant.ReturnStatusRejected)
+		orderReturn.RejectionReason = reason
+		orderReturn.RejectedAt = &now
+
+		return nil
+	})
+}
+
+func (s *returnService) transition(ctx context.Context, id uint32, action constant.AuditAction, apply func(*entity.OrderReturn) error) (*entity.OrderReturn, error) {
+	var updated *entity.OrderReturn
+
+	err := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		orderReturn, err := r.OrderReturn().FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if orderReturn == nil {
+			return exception.New(exception.TypeNotFound, exception.CodeNotFound, "return not found")
+		}
+
+		before, err := audit.Snapshot(orderReturn)
+		if err != nil {
+			return err
+		}
+
+		if err := apply(orderReturn); err != nil {
+			return err
+		}
+
+		updated, err = r.OrderReturn().Update(ctx, orderReturn)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, r, action, constant.AuditEntityReturn, updated.ID, before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Receive records that the goods of an approved return arrived, refunds them
+// against the order's captured payment and queues a job that puts them back
+// in stock.
+func (s *returnService) Receive(ctx context.Context, id uint32) (*entity.OrderReturn, error) {
+	var orderReturn *entity.OrderReturn
+
+	err := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		var err error
+
+		orderReturn, err = r.OrderReturn().FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if orderReturn == nil {
+			return exception.New(exception.TypeNotFound, exception.CodeNotFound, "return not found")
+		}
+
+		order, err := r.Order().FindByID(ctx, orderReturn.OrderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return exception.New(exception.TypeNotFound, exception.CodeNotFound, "order not found")
+		}
+
+		item := findOrderItem(order, orderReturn.OrderItemID)
+		if item == nil {
+			return exception.Newf(exception.TypeNotFound, exception.CodeNotFound, "order item %d not found", orderReturn.OrderItemID)
+		}
+
+		if orderReturn.Status != string(constant.ReturnStatusApproved) {
+			return exception.New(exception.TypeBadRequest, exception.CodeBadRequest, "only approved returns can be received")
+		}
+
+		before, err := audit.Snapshot(orderReturn)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		orderReturn.Status = string(constant.ReturnStatusReceived)
+		orderReturn.ReceivedAt = &now
+
+		for _, p := range order.Payments {
+			if p.Status != string(constant.PaymentStatusSucceeded) {
+				continue
+			}
+
+			refund, err := reserveRefund(ctx, s.Properties, r, order, p.ID, &entity.Refund{
+				Reason: "return #" + strconv.FormatUint(uint64(orderReturn.ID), 10) + ": " + orderReturn.ReasonCode,
+				Items:  []*entity.RefundItem{{OrderItemID: item.ID, Quantity: orderReturn.Quantity}},
+			})
+			if err != nil {
+				return err
+			}
+
+			orderReturn.RefundID = &refund.ID
+			orderReturn.RefundAmount = refund.Amount
+
+			break
+		}
+
+		orderReturn, err = r.OrderReturn().Update(ctx, orderReturn)
+		if err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, r, constant.AuditActionReturnReceive, constant.AuditEntityReturn, orderReturn.ID, before, orderReturn); err != nil {
+			return err
+		}
+
+		// The return only becomes received once, under its row lock, so
+		// exactly one restock job is queued for it.
+		return enqueueJob(ctx, r, JobTypeRestockReturn, restockReturnPayload{ReturnID: orderReturn.ID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return orderReturn, nil
+}
+
+// restockLockKey is the base of the Postgres advisory lock keys held while
+// the stock of a product is changed; the product ID fills the low 32 bits.
+const restockLockKey int64 = 0x7265737400000000
+
+// errStockNotWritten marks a restock that failed before the product's stock
+// was written, so it can be tried again.
+var errStockNotWritten = errors.New("stock not written")
+
+// Restock puts the goods of a received return back in stock. The return is
+// marked restocked in a transaction before the inventory is changed, so a
+// retried job never restocks it twice. If the restock then fails before the
+// stock is written, the mark is taken back and the job retried; if writing
+// the stock itself fails, it may have been applied, so the job is
+// dead-lettered for the stock to be checked by hand rather than risk
+// restocking twice.
+func (s *returnService) Restock(ctx context.Context, id uint32) error {
+	var (
+		orderReturn *entity.OrderReturn
+		item        *entity.OrderItem
+	)
+
+	err := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		var err error
+
+		orderReturn, err = r.OrderReturn().FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if orderReturn == nil {
+			return jobqueue.Permanent(fmt.Errorf("return %d not found", id))
+		}
+		if orderReturn.RestockedAt != nil {
+			orderReturn = nil
+			return nil
+		}
+		if orderReturn.Status != string(constant.ReturnStatusReceived) {
+			return jobqueue.Permanent(fmt.Errorf("return %d has not been received", id))
+		}
+
+		order, err := r.Order().FindByID(ctx, orderReturn.OrderID)
+		if err != nil {
+			return err
+		}
+
+		item = nil
+		if order != nil {
+			item = findOrderItem(order, orderReturn.OrderItemID)
+		}
+		if item == nil {
+			return jobqueue.Permanent(fmt.Errorf("order item %d of return %d not found", orderReturn.OrderItemID, id))
+		}
+
+		now := time.Now()
+		orderReturn.RestockedAt = &now
+
+		orderReturn, err = r.OrderReturn().Update(ctx, orderReturn)
+
+		return err
+	})
+	if err != nil || orderReturn == nil {
+		return err
+	}
+
+	err = restock(ctx, s.Properties, item.ProductID, orderReturn.Quantity)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, errStockNotWritten) {
+		return jobqueue.Permanent(fmt.Errorf("restock of return %d may have been applied, check the stock of product %s: %w", id, item.ProductID, err))
+	}
+
+	unmarkErr := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		orderReturn, err := r.OrderReturn().FindByIDForUpdate(ctx, id)
+		if err != nil || orderReturn == nil {
+			return err
+		}
+
+		orderReturn.RestockedAt = nil
+		_, err = r.OrderReturn().Update(ctx, orderReturn)
+
+		return err
+	})
+	if unmarkErr != nil {
+		return jobqueue.Permanent(fmt.Errorf("return %d is marked restocked but was not: %w", id, unmarkErr))
+	}
+
+	return err
+}
+
+func findOrderItem(order *entity.Order, id uint32) *entity.OrderItem {
+	for _, item := range order.Items {
+		if item.ID == id {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// restock adds quantity units of a product back to inventory. The inventory
+// service has no increment call, so this reads the product and writes it
+// back with the higher stock. The read and write hold an advisory lock on the
+// product, so restocks of the same product do not overwrite each other; other
+// writers of the product's stock are not held off by it. Errors before the
+// stock is written wrap errStockNotWritten. Callers must make sure it runs
+// once per return.
+func restock(ctx context.Context, props Properties, productID string, quantity int) error {
+	id, err := strconv.ParseUint(productID, 10, 32)
+	if err != nil {
+		return jobqueue.Permanent(fmt.Errorf("%w: invalid product id %q", errStockNotWritten, productID))
+	}
+
+	sent := false
+
+	acquired, err := props.Repo.Postgres().WithAdvisoryLock(ctx, restockLockKey|int64(id), func(ctx context.Context) error {
+		product, err := props.InventoryServiceClient.GetProduct(ctx, &pb.GetProductRequest{Id: uint32(id)})
+		if err != nil {
+			return err
+		}
+
+		sent = true
+
+		_, err = props.InventoryServiceClient.UpdateProduct(ctx, &pb.UpdateProductRequest{
+			Id:    product.GetId(),
+			Name:  product.GetName(),
+			Stock: product.GetStock() + int32(quantity),
+			Price: product.GetPrice(),
+		})
+
+		return err
+	})
+	if err == nil && !acquired {
+		err = fmt.Errorf("product %d is being restocked by another job", id)
+	}
+	if err != nil && !sent {
+		return fmt.Errorf("%w: %w", errStockNotWritten, err)
+	}
+
+	return err
+}
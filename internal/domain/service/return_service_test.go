@@ -0,0 +1,342 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"order-service/config"
+	"order-service/constant"
+	"order-service/internal/adapter/payment"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/returnpolicy"
+	"order-service/internal/domain/service"
+	"order-service/internal/jobqueue"
+	"order-service/mocks"
+	"order-service/pkg/logger"
+	"order-service/pkg/money"
+	"order-service/proto/pb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type returnTest struct {
+	service     service.ReturnService
+	order       *mocks.MockOrderRepository
+	payment     *mocks.MockPaymentRepository
+	refund      *mocks.MockRefundRepository
+	orderReturn *mocks.MockOrderReturnRepository
+	inventory   *mocks.MockInventoryServiceClient
+	job         *mocks.MockJobRepository
+}
+
+func setupReturnTest(t *testing.T) *returnTest {
+	mRepo := mocks.NewMockRepository(t)
+	mPostgres := mocks.NewMockPostgresRepository(t)
+	rt := &returnTest{
+		order:       mocks.NewMockOrderRepository(t),
+		payment:     mocks.NewMockPaymentRepository(t),
+		refund:      mocks.NewMockRefundRepository(t),
+		orderReturn: mocks.NewMockOrderReturnRepository(t),
+		inventory:   mocks.NewMockInventoryServiceClient(t),
+		job:         mocks.NewMockJobRepository(t),
+	}
+
+	mRepo.EXPECT().Postgres().Return(mPostgres).Maybe()
+	mPostgres.EXPECT().Order().Return(rt.order).Maybe()
+	withoutWebhookSubscriptions(t, mPostgres)
+	withAuditLog(t, mPostgres)
+	mPostgres.EXPECT().Payment().Return(rt.payment).Maybe()
+	mPostgres.EXPECT().Refund().Return(rt.refund).Maybe()
+	mPostgres.EXPECT().OrderReturn().Return(rt.orderReturn).Maybe()
+	mPostgres.EXPECT().Job().Return(rt.job).Maybe()
+	mPostgres.EXPECT().
+		Atomic(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ *config.Config, fn postgresrepository.RepositoryAtomicCallback) error {
+			return fn(mPostgres)
+		}).
+		Maybe()
+	mPostgres.EXPECT().
+		WithAdvisoryLock(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, _ int64, fn func(ctx context.Context) error) (bool, error) {
+			return true, fn(ctx)
+		}).
+		Maybe()
+
+	rt.orderReturn.EXPECT().
+		Update(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, r *entity.OrderReturn) (*entity.OrderReturn, error) {
+			return r, nil
+		}).
+		Maybe()
+
+	rt.service = service.NewReturnService(service.Properties{
+		Config: &config.Config{
+			Payment: &config.PaymentConfig{RefundMaxAttempts: 1},
+		},
+		Logger:                 logger.NewZerologLogger(false),
+		Repo:                   mRepo,
+		InventoryServiceClient: rt.inventory,
+		PaymentProvider:        payment.NewFakeProvider("secret", time.Minute),
+		ReturnPolicy: &returnpolicy.Policy{
+			DefaultWindowDays: 30,
+			ProductWindowDays: map[string]int{"102": 0},
+		},
+	})
+
+	return rt
+}
+
+func deliveredOrder(deliveredAt time.Time) *entity.Order {
+	order := paidOrder()
+	order.Status = string(constant.OrderStatusDelivered)
+	order.DeliveredAt = &deliveredAt
+	order.Items[0].ProductID = "101"
+
+	return order
+}
+
+func TestReturnService_Create_Success(t *testing.T) {
+	rt := setupReturnTest(t)
+	ctx := context.Background()
+
+	rt.order.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+	rt.order.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(deliveredOrder(time.Now().AddDate(0, 0, -3)), nil)
+	rt.orderReturn.EXPECT().FindByOrderItemID(ctx, uint32(50)).Return([]*entity.OrderReturn{
+		{Quantity: 2, Status: string(constant.ReturnStatusRejected)},
+		{Quantity: 1, Status: string(constant.ReturnStatusApproved)},
+	}, nil)
+	rt.orderReturn.EXPECT().
+		Create(ctx, mock.MatchedBy(func(r *entity.OrderReturn) bool {
+			return r.Quantity == 2 && r.Status == string(constant.ReturnStatusRequested) && r.ReasonCode == "DAMAGED"
+		})).
+		RunAndReturn(func(_ context.Context, r *entity.OrderReturn) (*entity.OrderReturn, error) {
+			return r, nil
+		})
+
+	created, err := rt.service.Create(ctx, 1, &entity.OrderReturn{OrderItemID: 50, Quantity: 2, ReasonCode: "DAMAGED"})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint32(50), created.OrderItemID)
+}
+
+func TestReturnService_Create_Rules(t *testing.T) {
+	tests := []struct {
+		name        string
+		order       func() *entity.Order
+		existing    []*entity.OrderReturn
+		quantity    int
+		errContains string
+	}{
+		{
+			name: "not delivered",
+			order: func() *entity.Order {
+				o := deliveredOrder(time.Now())
+				o.Status = string(constant.OrderStatusConfirmed)
+				return o
+			},
+			quantity:    1,
+			errContains: "only delivered orders can be returned",
+		},
+		{
+			name:        "window closed",
+			order:       func() *entity.Order { return deliveredOrder(time.Now().AddDate(0, 0, -31)) },
+			quantity:    1,
+			errContains: "return window for order item 50 closed",
+		},
+		{
+			name: "not returnable",
+			order: func() *entity.Order {
+				o := deliveredOrder(time.Now())
+				o.Items[0].ProductID = "102"
+				return o
+			},
+			quantity:    1,
+			errContains: "product 102 cannot be returned",
+		},
+		{
+			name:        "more than delivered",
+			order:       func() *entity.Order { return deliveredOrder(time.Now()) },
+			existing:    []*entity.OrderReturn{{Quantity: 2, Status: string(constant.ReturnStatusRequested)}},
+			quantity:    2,
+			errContains: "only 1 of order item 50 can still be returned",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := setupReturnTest(t)
+			ctx := context.Background()
+
+			rt.order.EXPECT().LockByID(ctx, uint32(1)).Return(orderCreatedAt, nil)
+			rt.order.EXPECT().FindByKey(ctx, uint32(1), orderCreatedAt).Return(tt.order(), nil)
+			rt.orderReturn.EXPECT().FindByOrderItemID(ctx, uint32(50)).Return(tt.existing, nil).Maybe()
+
+			_, err := rt.service.Create(ctx, 1, &entity.OrderReturn{OrderItemID: 50, Quantity: tt.quantity, ReasonCode: "OTHER"})
+
+			assert.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
+
+func TestReturnService_Receive_RefundsAndQueuesRestock(t *testing.T) {
+	rt := setupReturnTest(t)
+	ctx := context.Background()
+
+	rt.orderReturn.EXPECT().FindByIDForUpdate(ctx, uint32(3)).Return(&entity.OrderReturn{
+		Base:        entity.Base{ID: 3},
+		OrderID:     1,
+		OrderItemID: 50,
+		Quantity:    1,
+		ReasonCode:  "DAMAGED",
+		Status:      string(constant.ReturnStatusApproved),
+	}, nil)
+	rt.order.EXPECT().FindByID(ctx, uint32(1)).Return(deliveredOrder(time.Now()), nil)
+	rt.payment.EXPECT().FindByIDForUpdate(ctx, uint32(7)).Return(capturedPayment(), nil)
+	rt.refund.EXPECT().FindByPaymentID(ctx, uint32(7)).Return(nil, nil)
+	rt.refund.EXPECT().
+		Create(ctx, mock.MatchedBy(func(r *entity.Refund) bool {
+			return r.Amount == money.MustParse("33.33") && len(r.Items) == 1 && r.Items[0].Quantity == 1
+		})).
+		RunAndReturn(func(_ context.Context, r *entity.Refund) (*entity.Refund, error) {
+			r.ID = 9
+			return r, nil
+		})
+
+	var queued []string
+	rt.job.EXPECT().
+		Create(ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, job *entity.Job) (*entity.Job, error) {
+			queued = append(queued, job.Type+" "+string(job.Payload))
+			return job, nil
+		}).
+		Times(2)
+
+	received, err := rt.service.Receive(ctx, 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, string(constant.ReturnStatusReceived), received.Status)
+	assert.Equal(t, money.MustParse("33.33"), received.RefundAmount)
+	if assert.NotNil(t, received.RefundID) {
+		assert.Equal(t, uint32(9), *received.RefundID)
+	}
+	assert.Nil(t, received.RestockedAt)
+	assert.Equal(t, []string{
+		service.JobTypeProcessRefund + ` {"refund_id":9}`,
+		service.JobTypeRestockReturn + ` {"return_id":3}`,
+	}, queued)
+}
+
+func TestReturnService_Receive_RejectsReceivedReturn(t *testing.T) {
+	rt := setupReturnTest(t)
+	ctx := context.Background()
+
+	rt.orderReturn.EXPECT().FindByIDForUpdate(ctx, uint32(3)).Return(&entity.OrderReturn{
+		Base:        entity.Base{ID: 3},
+		OrderID:     1,
+		OrderItemID: 50,
+		Quantity:    1,
+		Status:      string(constant.ReturnStatusReceived),
+	}, nil)
+	rt.order.EXPECT().FindByID(ctx, uint32(1)).Return(deliveredOrder(time.Now()), nil)
+
+	_, err := rt.service.Receive(ctx, 3)
+
+	assert.ErrorContains(t, err, "only approved returns can be received")
+}
+
+func receivedReturn() *entity.OrderReturn {
+	return &entity.OrderReturn{
+		Base:        entity.Base{ID: 3},
+		OrderID:     1,
+		OrderItemID: 50,
+		Quantity:    1,
+		Status:      string(constant.ReturnStatusReceived),
+	}
+}
+
+func TestReturnService_Restock(t *testing.T) {
+	rt := setupReturnTest(t)
+	ctx := context.Background()
+
+	orderReturn := receivedReturn()
+
+	rt.orderReturn.EXPECT().FindByIDForUpdate(ctx, uint32(3)).Return(orderReturn, nil)
+	rt.order.EXPECT().FindByID(ctx, uint32(1)).Return(deliveredOrder(time.Now()), nil)
+	rt.inventory.EXPECT().
+		GetProduct(ctx, &pb.GetProductRequest{Id: 101}, mock.Anything).
+		Return(&pb.Product{Id: 101, Name: "Mug", Stock: 4, Price: 10}, nil)
+	rt.inventory.EXPECT().
+		UpdateProduct(ctx, &pb.UpdateProductRequest{Id: 101, Name: "Mug", Stock: 5, Price: 10}, mock.Anything).
+		Return(&pb.Product{Id: 101, Stock: 5}, nil)
+
+	err := rt.service.Restock(ctx, 3)
+
+	require.NoError(t, err)
+	assert.NotNil(t, orderReturn.RestockedAt)
+}
+
+func TestReturnService_Restock_RetriesWhenStockNotWritten(t *testing.T) {
+	rt := setupReturnTest(t)
+	ctx := context.Background()
+
+	marked := receivedReturn()
+
+	rt.orderReturn.EXPECT().FindByIDForUpdate(ctx, uint32(3)).Return(marked, nil).Once()
+	rt.order.EXPECT().FindByID(ctx, uint32(1)).Return(deliveredOrder(time.Now()), nil)
+	rt.inventory.EXPECT().
+		GetProduct(ctx, &pb.GetProductRequest{Id: 101}, mock.Anything).
+		Return(nil, errors.New("inventory unavailable"))
+
+	// The mark is taken back, so the retried job restocks the return.
+	unmarked := receivedReturn()
+	unmarked.RestockedAt = ptr(time.Now())
+	rt.orderReturn.EXPECT().FindByIDForUpdate(ctx, uint32(3)).Return(unmarked, nil).Once()
+
+	err := rt.service.Restock(ctx, 3)
+
+	assert.ErrorContains(t, err, "inventory unavailable")
+	assert.NotErrorIs(t, err, jobqueue.ErrPermanent)
+	assert.NotNil(t, marked.RestockedAt)
+	assert.Nil(t, unmarked.RestockedAt)
+}
+
+func TestReturnService_Restock_DeadLettersUnknownStockWrite(t *testing.T) {
+	rt := setupReturnTest(t)
+	ctx := context.Background()
+
+	orderReturn := receivedReturn()
+
+	rt.orderReturn.EXPECT().FindByIDForUpdate(ctx, uint32(3)).Return(orderReturn, nil).Once()
+	rt.order.EXPECT().FindByID(ctx, uint32(1)).Return(deliveredOrder(time.Now()), nil)
+	rt.inventory.EXPECT().
+		GetProduct(ctx, &pb.GetProductRequest{Id: 101}, mock.Anything).
+		Return(&pb.Product{Id: 101, Name: "Mug", Stock: 4, Price: 10}, nil)
+	rt.inventory.EXPECT().
+		UpdateProduct(ctx, mock.Anything, mock.Anything).
+		Return(nil, errors.New("deadline exceeded"))
+
+	err := rt.service.Restock(ctx, 3)
+
+	// The stock may have been written, so the return stays marked and the
+	// job is not retried.
+	assert.ErrorIs(t, err, jobqueue.ErrPermanent)
+	assert.NotNil(t, orderReturn.RestockedAt)
+}
+
+func TestReturnService_Restock_SkipsRestockedReturn(t *testing.T) {
+	rt := setupReturnTest(t)
+	ctx := context.Background()
+
+	// A retried job must not add the goods back a second time.
+	restocked := receivedReturn()
+	restocked.RestockedAt = ptr(time.Now())
+
+	rt.orderReturn.EXPECT().FindByIDForUpdate(ctx, uint32(3)).Return(restocked, nil)
+
+	assert.NoError(t, rt.service.Restock(ctx, 3))
+}
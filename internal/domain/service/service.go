@@ -1,8 +1,15 @@
 package service
 
 import (
+	"context"
 	"order-service/config"
+	"order-service/internal/adapter/fxrate"
+	"order-service/internal/adapter/payment"
 	"order-service/internal/adapter/repository"
+	"order-service/internal/adapter/shipping"
+	"order-service/internal/adapter/tax"
+	"order-service/internal/adapter/webhook"
+	"order-service/internal/domain/returnpolicy"
 	"order-service/pkg/logger"
 	"order-service/proto/pb"
 )
@@ -11,6 +18,13 @@ var _ Service = (*service)(nil)
 
 type Service interface {
 	Order() OrderService
+	Coupon() CouponService
+	Payment() PaymentService
+	Refund() RefundService
+	Return() ReturnService
+	Webhook() WebhookService
+	Audit() AuditService
+	Database() DatabaseService
 }
 
 type Properties struct {
@@ -18,11 +32,30 @@ type Properties struct {
 	Repo                   repository.Repository
 	Logger                 logger.Logger
 	InventoryServiceClient pb.InventoryServiceClient
+	FXRateProvider         fxrate.FXRateProvider
+	TaxCalculator          tax.TaxCalculator
+	ShippingFeeCalculator  shipping.FeeCalculator
+	PaymentProvider        payment.PaymentProvider
+	ReturnPolicy           *returnpolicy.Policy
+	WebhookSender          webhook.Sender
+}
+
+// log returns the logger of the request or job ctx belongs to, falling back
+// to the service logger.
+func (p Properties) log(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, p.Logger)
 }
 
 type service struct {
 	Properties
-	orderService OrderService
+	orderService    OrderService
+	couponService   CouponService
+	paymentService  PaymentService
+	refundService   RefundService
+	returnService   ReturnService
+	webhookService  WebhookService
+	auditService    AuditService
+	databaseService DatabaseService
 }
 
 func NewService(
@@ -30,20 +63,67 @@ func NewService(
 	repo repository.Repository,
 	logger logger.Logger,
 	inventoryServiceClient pb.InventoryServiceClient,
+	fxRateProvider fxrate.FXRateProvider,
+	taxCalculator tax.TaxCalculator,
+	shippingFeeCalculator shipping.FeeCalculator,
+	paymentProvider payment.PaymentProvider,
+	returnPolicy *returnpolicy.Policy,
+	webhookSender webhook.Sender,
 ) (*service, error) {
 	props := Properties{
 		Config:                 config,
 		Repo:                   repo,
 		Logger:                 logger,
 		InventoryServiceClient: inventoryServiceClient,
+		FXRateProvider:         fxRateProvider,
+		TaxCalculator:          taxCalculator,
+		ShippingFeeCalculator:  shippingFeeCalculator,
+		PaymentProvider:        paymentProvider,
+		ReturnPolicy:           returnPolicy,
+		WebhookSender:          webhookSender,
 	}
 
 	return &service{
-		Properties:   props,
-		orderService: NewOrderService(props),
+		Properties:      props,
+		orderService:    NewOrderService(props),
+		couponService:   NewCouponService(props),
+		paymentService:  NewPaymentService(props),
+		refundService:   NewRefundService(props),
+		returnService:   NewReturnService(props),
+		webhookService:  NewWebhookService(props),
+		auditService:    NewAuditService(props),
+		databaseService: NewDatabaseService(props),
 	}, nil
 }
 
 func (s *service) Order() OrderService {
 	return s.orderService
 }
+
+func (s *service) Coupon() CouponService {
+	return s.couponService
+}
+
+func (s *service) Payment() PaymentService {
+	return s.paymentService
+}
+
+func (s *service) Refund() RefundService {
+	return s.refundService
+}
+
+func (s *service) Return() ReturnService {
+	return s.returnService
+}
+
+func (s *service) Webhook() WebhookService {
+	return s.webhookService
+}
+
+func (s *service) Audit() AuditService {
+	return s.auditService
+}
+
+func (s *service) Database() DatabaseService {
+	return s.databaseService
+}
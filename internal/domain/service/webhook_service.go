@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"order-service/constant"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/adapter/webhook"
+	"order-service/internal/domain/audit"
+	"order-service/internal/domain/entity"
+	"order-service/internal/jobqueue"
+	"order-service/internal/shared"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/money"
+	"time"
+)
+
+var _ WebhookService = (*webhookService)(nil)
+
+// WebhookService manages webhook subscriptions and delivers the events they
+// subscribe to.
+type WebhookService interface {
+	FindSubscriptionByID(ctx context.Context, id uint32) (*entity.WebhookSubscription, error)
+	FindSubscriptions(ctx context.Context, filter *postgresrepository.FilterWebhookSubscriptionPayload) ([]*entity.WebhookSubscription, int, error)
+	CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uint32) error
+	FindDeliveries(ctx context.Context, filter *postgresrepository.FilterWebhookDeliveryPayload) ([]*entity.WebhookDelivery, int, error)
+	Deliver(ctx context.Context, deliveryID uint32, final bool) error
+}
+
+type webhookService struct {
+	Properties
+}
+
+func NewWebhookService(props Properties) *webhookService {
+	return &webhookService{
+		Properties: props,
+	}
+}
+
+// webhookEvent is the body posted to subscribers. Every delivery of the same
+// event carries the same ID, so receivers can drop duplicates.
+type webhookEvent struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+type orderEventData struct {
+	OrderID            uint32      `json:"order_id"`
+	UserID             uint32      `json:"user_id"`
+	Status             string      `json:"status"`
+	Currency           string      `json:"currency"`
+	TotalPrice         money.Money `json:"total_price"`
+	CancellationReason string      `json:"cancellation_reason,omitempty"`
+}
+
+func (s *webhookService) FindSubscriptionByID(ctx context.Context, id uint32) (*entity.WebhookSubscription, error) {
+	subscription, err := s.Repo.Postgres().Webhook().FindSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, exception.New(exception.TypeNotFound, exception.CodeNotFound, "webhook subscription not found")
+	}
+
+	return subscription, nil
+}
+
+func (s *webhookService) FindSubscriptions(ctx context.Context, filter *postgresrepository.FilterWebhookSubscriptionPayload) ([]*entity.WebhookSubscription, int, error) {
+	return s.Repo.Postgres().Webhook().FindSubscriptions(ctx, filter)
+}
+
+func (s *webhookService) CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	subscription.IsActive = true
+
+	var created *entity.WebhookSubscription
+
+	err := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		var err error
+		if created, err = r.Webhook().CreateSubscription(ctx, subscription); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, r, constant.AuditActionWebhookSubscriptionCreate, constant.AuditEntityWebhookSubscription, created.ID, nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateSubscription changes a subscription's URL, event types and active
+// flag, and its secret when one is given. Re-activating a subscription clears
+// its failure count.
+func (s *webhookService) UpdateSubscription(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	var updated *entity.WebhookSubscription
+
+	err := s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		existing, err := r.Webhook().FindSubscriptionByIDForUpdate(ctx, subscription.ID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return exception.New(exception.TypeNotFound, exception.CodeNotFound, "webhook subscription not found")
+		}
+
+		before, err := audit.Snapshot(existing)
+		if err != nil {
+			return err
+		}
+
+		if subscription.IsActive && !existing.IsActive {
+			existing.ConsecutiveFailures = 0
+			existing.DisabledAt = nil
+			existing.DisabledReason = ""
+		}
+
+		existing.URL = subscription.URL
+		existing.EventTypes = subscription.EventTypes
+		existing.IsActive = subscription.IsActive
+
+		if subscription.Secret != "" {
+			existing.Secret = subscription.Secret
+		}
+
+		if updated, err = r.Webhook().UpdateSubscription(ctx, existing); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, r, constant.AuditActionWebhookSubscriptionUpdate, constant.AuditEntityWebhookSubscription, existing.ID, before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *webhookService) DeleteSubscription(ctx context.Context, id uint32) error {
+	return s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		existing, err := r.Webhook().FindSubscriptionByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return exception.New(exception.TypeNotFound, exception.CodeNotFound, "webhook subscription not found")
+		}
+
+		if err := r.Webhook().DeleteSubscription(ctx, id); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, r, constant.AuditActionWebhookSubscriptionDelete, constant.AuditEntityWebhookSubscription, id, existing, nil)
+	})
+}
+
+func (s *webhookService) FindDeliveries(ctx context.Context, filter *postgresrepository.FilterWebhookDeliveryPayload) ([]*entity.WebhookDelivery, int, error) {
+	if _, err := s.FindSubscriptionByID(ctx, filter.SubscriptionID); err != nil {
+		return nil, 0, err
+	}
+
+	return s.Repo.Postgres().Webhook().FindDeliveries(ctx, filter)
+}
+
+// Deliver makes one attempt at posting a delivery to its subscriber. A failed
+// attempt is recorded and returned as an error so the job running it is
+// retried; on the final attempt the delivery is marked failed and counts
+// towards disabling the subscription. A delivery to a disabled or deleted
+// subscription is dropped.
+func (s *webhookService) Deliver(ctx context.Context, deliveryID uint32, final bool) error {
+	delivery, err := s.Repo.Postgres().Webhook().FindDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return err
+	}
+	if delivery == nil || delivery.Status != string(constant.WebhookDeliveryStatusPending) {
+		return nil
+	}
+
+	subscription, err := s.Repo.Postgres().Webhook().FindSubscriptionByID(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	if subscription == nil || !subscription.IsActive {
+		delivery.Status = string(constant.WebhookDeliveryStatusFailed)
+		delivery.LastError = "subscription is disabled"
+		delivery.FailedAt = &now
+
+		_, err := s.Repo.Postgres().Webhook().UpdateDelivery(ctx, delivery)
+
+		return err
+	}
+
+	res, sendErr := s.WebhookSender.Send(ctx, &webhook.Request{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventType:  delivery.EventType,
+		DeliveryID: delivery.ID,
+		Body:       delivery.Payload,
+	})
+
+	delivery.Attempts++
+	delivery.ResponseStatus = 0
+	delivery.ResponseBody = ""
+
+	switch {
+	case sendErr != nil:
+		delivery.LastError = sendErr.Error()
+	case !res.Succeeded():
+		delivery.ResponseStatus = res.StatusCode
+		delivery.ResponseBody = res.Body
+		delivery.LastError = fmt.Sprintf("receiver responded with status %d", res.StatusCode)
+	default:
+		delivery.ResponseStatus = res.StatusCode
+		delivery.ResponseBody = res.Body
+		delivery.LastError = ""
+		delivery.Status = string(constant.WebhookDeliveryStatusSucceeded)
+		delivery.DeliveredAt = &now
+	}
+
+	succeeded := delivery.Status == string(constant.WebhookDeliveryStatusSucceeded)
+	if !succeeded && final {
+		delivery.Status = string(constant.WebhookDeliveryStatusFailed)
+		delivery.FailedAt = &now
+	}
+
+	var disabled bool
+
+	err = s.Repo.Postgres().Atomic(ctx, s.Config, func(r postgresrepository.PostgresRepository) error {
+		disabled = false
+
+		if _, err := r.Webhook().UpdateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+
+		if !succeeded && !final {
+			return nil
+		}
+
+		subscription, err := r.Webhook().FindSubscriptionByIDForUpdate(ctx, delivery.SubscriptionID)
+		if err != nil || subscription == nil {
+			return err
+		}
+
+		disabled, err = s.recordOutcome(ctx, r, subscription, succeeded)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if disabled {
+		s.log(ctx).Warn().Msgf("Disabled webhook subscription %d after %d failed deliveries in a row", delivery.SubscriptionID, s.Config.Webhook.DisableAfterFailures)
+	}
+
+	if !succeeded {
+		return fmt.Errorf("webhook delivery %d failed: %s", delivery.ID, delivery.LastError)
+	}
+
+	return nil
+}
+
+// recordOutcome updates the subscription's count of deliveries failed in a
+// row and disables it once the count reaches the configured limit. It
+// reports whether the subscription was disabled.
+func (s *webhookService) recordOutcome(ctx context.Context, r postgresrepository.PostgresRepository, subscription *entity.WebhookSubscription, succeeded bool) (bool, error) {
+	if succeeded {
+		if subscription.ConsecutiveFailures == 0 {
+			return false, nil
+		}
+
+		subscription.ConsecutiveFailures = 0
+		_, err := r.Webhook().UpdateSubscription(ctx, subscription)
+
+		return false, err
+	}
+
+	before, err := audit.Snapshot(subscription)
+	if err != nil {
+		return false, err
+	}
+
+	subscription.ConsecutiveFailures++
+
+	limit := s.Config.Webhook.DisableAfterFailures
+	disable := subscription.IsActive && limit > 0 && subscription.ConsecutiveFailures >= limit
+
+	if disable {
+		now := time.Now()
+		subscription.IsActive = false
+		subscription.DisabledAt = &now
+		subscription.DisabledReason = fmt.Sprintf("%d deliveries in a row failed", subscription.ConsecutiveFailures)
+	}
+
+	if _, err := r.Webhook().UpdateSubscription(ctx, subscription); err != nil {
+		return false, err
+	}
+
+	// Only disabling is audited; the failure count alone changes too often
+	// to be worth an entry.
+	if disable {
+		if err := recordAudit(ctx, r, constant.AuditActionWebhookSubscriptionUpdate, constant.AuditEntityWebhookSubscription, subscription.ID, before, subscription); err != nil {
+			return false, err
+		}
+	}
+
+	return disable, nil
+}
+
+// publishOrderEvent queues a delivery of an order event to every active
+// subscription to it. It runs in the transaction that changes the order, so
+// an event is sent if and only if the change commits.
+func publishOrderEvent(ctx context.Context, props Properties, r postgresrepository.PostgresRepository, eventType constant.WebhookEventType, order *entity.Order) error {
+	subscriptions, err := r.Webhook().FindActiveSubscriptionsByEventType(ctx, string(eventType))
+	if err != nil || len(subscriptions) == 0 {
+		return err
+	}
+
+	eventID, err := shared.GenerateUUIDString()
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(&webhookEvent{
+		ID:        eventID,
+		Type:      string(eventType),
+		CreatedAt: time.Now(),
+		Data: &orderEventData{
+			OrderID:            order.ID,
+			UserID:             order.UserID,
+			Status:             order.Status,
+			Currency:           order.Currency,
+			TotalPrice:         order.TotalPrice,
+			CancellationReason: order.CancellationReason,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		delivery, err := r.Webhook().CreateDelivery(ctx, &entity.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			EventType:      string(eventType),
+			Payload:        payload,
+			Status:         string(constant.WebhookDeliveryStatusPending),
+		})
+		if err != nil {
+			return err
+		}
+
+		err = enqueueJob(ctx, r, JobTypeDeliverWebhook, deliverWebhookPayload{DeliveryID: delivery.ID},
+			jobqueue.MaxAttempts(props.Config.Webhook.MaxAttempts))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"order-service/config"
+	"order-service/constant"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/adapter/webhook"
+	"order-service/internal/domain/entity"
+	"order-service/internal/domain/service"
+	"order-service/mocks"
+	"order-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// withoutWebhookSubscriptions lets order changes publish events to no one.
+func withoutWebhookSubscriptions(t *testing.T, mPostgres *mocks.MockPostgresRepository) {
+	mWebhook := mocks.NewMockWebhookRepository(t)
+
+	mPostgres.EXPECT().Webhook().Return(mWebhook).Maybe()
+	mWebhook.EXPECT().FindActiveSubscriptionsByEventType(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+}
+
+type webhookTest struct {
+	service  service.WebhookService
+	webhook  *mocks.MockWebhookRepository
+	receiver *httptest.Server
+	status   int
+	received []*http.Request
+	bodies   [][]byte
+}
+
+func setupWebhookTest(t *testing.T) *webhookTest {
+	wt := &webhookTest{status: http.StatusOK}
+
+	wt.receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		wt.received = append(wt.received, r)
+		wt.bodies = append(wt.bodies, body)
+		w.WriteHeader(wt.status)
+	}))
+	t.Cleanup(wt.receiver.Close)
+
+	mRepo := mocks.NewMockRepository(t)
+	mPostgres := mocks.NewMockPostgresRepository(t)
+	wt.webhook = mocks.NewMockWebhookRepository(t)
+
+	mRepo.EXPECT().Postgres().Return(mPostgres).Maybe()
+	mPostgres.EXPECT().Webhook().Return(wt.webhook).Maybe()
+	withAuditLog(t, mPostgres)
+	mPostgres.EXPECT().
+		Atomic(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ *config.Config, fn postgresrepository.RepositoryAtomicCallback) error {
+			return fn(mPostgres)
+		}).
+		Maybe()
+
+	wt.service = service.NewWebhookService(service.Properties{
+		Config: &config.Config{
+			Webhook: &config.WebhookConfig{MaxAttempts: 3, DisableAfterFailures: 2},
+		},
+		Logger:        logger.NewZerologLogger(false),
+		Repo:          mRepo,
+		WebhookSender: webhook.NewHTTPSender(wt.receiver.Client()),
+	})
+
+	return wt
+}
+
+func (wt *webhookTest) subscription(failures int) *entity.WebhookSubscription {
+	return &entity.WebhookSubscription{
+		Base:                entity.Base{ID: 3},
+		URL:                 wt.receiver.URL,
+		Secret:              "0123456789abcdef",
+		EventTypes:          []string{string(constant.WebhookEventOrderCreated)},
+		IsActive:            true,
+		ConsecutiveFailures: failures,
+	}
+}
+
+func pendingDelivery() *entity.WebhookDelivery {
+	return &entity.WebhookDelivery{
+		Base:           entity.Base{ID: 9},
+		SubscriptionID: 3,
+		EventID:        "evt-1",
+		EventType:      string(constant.WebhookEventOrderCreated),
+		Payload:        json.RawMessage(`{"id":"evt-1"}`),
+		Status:         string(constant.WebhookDeliveryStatusPending),
+	}
+}
+
+func TestWebhookService_Deliver_SignsAndRecordsSuccess(t *testing.T) {
+	wt := setupWebhookTest(t)
+	ctx := context.Background()
+	subscription := wt.subscription(1)
+
+	wt.webhook.EXPECT().FindDeliveryByID(ctx, uint32(9)).Return(pendingDelivery(), nil)
+	wt.webhook.EXPECT().FindSubscriptionByID(ctx, uint32(3)).Return(subscription, nil)
+	wt.webhook.EXPECT().FindSubscriptionByIDForUpdate(ctx, uint32(3)).Return(subscription, nil)
+	wt.webhook.EXPECT().
+		UpdateDelivery(ctx, mock.MatchedBy(func(d *entity.WebhookDelivery) bool {
+			return d.Status == string(constant.WebhookDeliveryStatusSucceeded) && d.Attempts == 1 && d.DeliveredAt != nil
+		})).
+		Return(nil, nil)
+	wt.webhook.EXPECT().
+		UpdateSubscription(ctx, mock.MatchedBy(func(s *entity.WebhookSubscription) bool {
+			return s.ConsecutiveFailures == 0 && s.IsActive
+		})).
+		Return(nil, nil)
+
+	err := wt.service.Deliver(ctx, 9, false)
+
+	assert.NoError(t, err)
+	if assert.Len(t, wt.received, 1) {
+		r := wt.received[0]
+		unix, err := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		assert.NoError(t, err)
+
+		assert.Equal(t, webhook.Sign("0123456789abcdef", time.Unix(unix, 0), wt.bodies[0]), r.Header.Get(webhook.HeaderSignature))
+		assert.Equal(t, string(constant.WebhookEventOrderCreated), r.Header.Get(webhook.HeaderEvent))
+		assert.Equal(t, strconv.Itoa(9), r.Header.Get(webhook.HeaderDelivery))
+		assert.JSONEq(t, `{"id":"evt-1"}`, string(wt.bodies[0]))
+	}
+}
+
+func TestWebhookService_Deliver_RetriesFailedAttempt(t *testing.T) {
+	wt := setupWebhookTest(t)
+	wt.status = http.StatusServiceUnavailable
+	ctx := context.Background()
+
+	wt.webhook.EXPECT().FindDeliveryByID(ctx, uint32(9)).Return(pendingDelivery(), nil)
+	wt.webhook.EXPECT().FindSubscriptionByID(ctx, uint32(3)).Return(wt.subscription(0), nil)
+	wt.webhook.EXPECT().
+		UpdateDelivery(ctx, mock.MatchedBy(func(d *entity.WebhookDelivery) bool {
+			return d.Status == string(constant.WebhookDeliveryStatusPending) &&
+				d.Attempts == 1 &&
+				d.ResponseStatus == http.StatusServiceUnavailable &&
+				d.LastError == "receiver responded with status 503"
+		})).
+		Return(nil, nil)
+
+	err := wt.service.Deliver(ctx, 9, false)
+
+	assert.ErrorContains(t, err, "receiver responded with status 503")
+}
+
+func TestWebhookService_Deliver_DisablesSubscriptionAfterFailures(t *testing.T) {
+	wt := setupWebhookTest(t)
+	wt.status = http.StatusInternalServerError
+	ctx := context.Background()
+	subscription := wt.subscription(1)
+
+	wt.webhook.EXPECT().FindDeliveryByID(ctx, uint32(9)).Return(pendingDelivery(), nil)
+	wt.webhook.EXPECT().FindSubscriptionByID(ctx, uint32(3)).Return(subscription, nil)
+	wt.webhook.EXPECT().FindSubscriptionByIDForUpdate(ctx, uint32(3)).Return(subscription, nil)
+	wt.webhook.EXPECT().
+		UpdateDelivery(ctx, mock.MatchedBy(func(d *entity.WebhookDelivery) bool {
+			return d.Status == string(constant.WebhookDeliveryStatusFailed) && d.FailedAt != nil
+		})).
+		Return(nil, nil)
+	wt.webhook.EXPECT().
+		UpdateSubscription(ctx, mock.MatchedBy(func(s *entity.WebhookSubscription) bool {
+			return s.ConsecutiveFailures == 2 && !s.IsActive && s.DisabledAt != nil
+		})).
+		Return(nil, nil)
+
+	err := wt.service.Deliver(ctx, 9, true)
+
+	assert.Error(t, err)
+}
+
+func TestWebhookService_Deliver_DropsDeliveryToDisabledSubscription(t *testing.T) {
+	wt := setupWebhookTest(t)
+	ctx := context.Background()
+	subscription := wt.subscription(2)
+	subscription.IsActive = false
+
+	wt.webhook.EXPECT().FindDeliveryByID(ctx, uint32(9)).Return(pendingDelivery(), nil)
+	wt.webhook.EXPECT().FindSubscriptionByID(ctx, uint32(3)).Return(subscription, nil)
+	wt.webhook.EXPECT().
+		UpdateDelivery(ctx, mock.MatchedBy(func(d *entity.WebhookDelivery) bool {
+			return d.Status == string(constant.WebhookDeliveryStatusFailed) && d.Attempts == 0
+		})).
+		Return(nil, nil)
+
+	err := wt.service.Deliver(ctx, 9, false)
+
+	assert.NoError(t, err)
+	assert.Empty(t, wt.received)
+}
+
+func TestWebhookService_UpdateSubscription_ReactivationResetsFailures(t *testing.T) {
+	wt := setupWebhookTest(t)
+	ctx := context.Background()
+	existing := wt.subscription(5)
+	existing.IsActive = false
+
+	wt.webhook.EXPECT().FindSubscriptionByIDForUpdate(ctx, uint32(3)).Return(existing, nil)
+	wt.webhook.EXPECT().
+		UpdateSubscription(ctx, mock.MatchedBy(func(s *entity.WebhookSubscription) bool {
+			return s.IsActive && s.ConsecutiveFailures == 0 && s.DisabledAt == nil && s.Secret == "0123456789abcdef"
+		})).
+		RunAndReturn(func(_ context.Context, s *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+			return s, nil
+		})
+
+	updated, err := wt.service.UpdateSubscription(ctx, &entity.WebhookSubscription{
+		Base:       entity.Base{ID: 3},
+		URL:        "https://example.com/hooks",
+		EventTypes: []string{string(constant.WebhookEventOrderCancelled)},
+		IsActive:   true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/hooks", updated.URL)
+}
@@ -0,0 +1,258 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"order-service/config"
+	"order-service/internal/adapter/repository"
+	"order-service/internal/domain/audit"
+	"order-service/internal/domain/entity"
+	"order-service/pkg/logger"
+	"sync"
+	"time"
+)
+
+// Handler runs one job. An error that wraps ErrPermanent dead-letters the job
+// at once; any other error retries it with backoff.
+type Handler func(ctx context.Context, job *entity.Job) error
+
+// ErrPermanent marks a job failure that retrying cannot fix.
+var ErrPermanent = errors.New("permanent job failure")
+
+// Permanent wraps err so the failing job is dead-lettered without retries.
+func Permanent(err error) error {
+	return fmt.Errorf("%w: %w", ErrPermanent, err)
+}
+
+// Option sets an optional field of a new job.
+type Option func(job *entity.Job)
+
+// RunAt schedules the job to run no earlier than t.
+func RunAt(t time.Time) Option {
+	return func(job *entity.Job) {
+		job.RunAt = t
+	}
+}
+
+// MaxAttempts overrides the queue's default attempt limit for the job.
+func MaxAttempts(n int) Option {
+	return func(job *entity.Job) {
+		job.MaxAttempts = n
+	}
+}
+
+// NewJob builds a job of jobType with payload encoded as JSON. It is queued
+// by creating it through the job repository, which lets callers queue jobs in
+// the same transaction as the change that requires them.
+func NewJob(jobType string, payload any, opts ...Option) (*entity.Job, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s job payload: %w", jobType, err)
+	}
+
+	job := &entity.Job{
+		Type:    jobType,
+		Payload: raw,
+	}
+
+	for _, opt := range opts {
+		opt(job)
+	}
+
+	return job, nil
+}
+
+// Queue runs jobs from the jobs table with the handlers registered for their
+// types.
+type Queue struct {
+	config   *config.JobsConfig
+	repo     repository.Repository
+	logger   logger.Logger
+	handlers map[string]Handler
+}
+
+func NewQueue(config *config.JobsConfig, repo repository.Repository, logger logger.Logger) *Queue {
+	return &Queue{
+		config:   config,
+		repo:     repo,
+		logger:   logger,
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Handle registers handler for jobType. Handlers must be registered before
+// Run is called; registering a type twice panics.
+func (q *Queue) Handle(jobType string, handler Handler) {
+	if _, ok := q.handlers[jobType]; ok {
+		panic(fmt.Sprintf("jobqueue: handler for %q already registered", jobType))
+	}
+
+	q.handlers[jobType] = handler
+}
+
+// Register registers fn for jobType, decoding each job's payload into T. A
+// payload that cannot be decoded dead-letters the job.
+func Register[T any](q *Queue, jobType string, fn func(ctx context.Context, payload T) error) {
+	q.Handle(jobType, func(ctx context.Context, job *entity.Job) error {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return Permanent(fmt.Errorf("failed to decode payload: %w", err))
+		}
+
+		return fn(ctx, payload)
+	})
+}
+
+// Run starts the configured number of workers and blocks until ctx is
+// cancelled and every job in progress has finished.
+func (q *Queue) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for range max(q.config.Concurrency, 1) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			q.work(ctx)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (q *Queue) work(ctx context.Context) {
+	for {
+		claimed := false
+
+		if ctx.Err() == nil {
+			jobs, err := q.repo.Postgres().Job().Claim(ctx, 1, q.config.LockTimeout)
+			if err != nil {
+				q.logger.Error().Err(err).Msg("Failed to claim job")
+			}
+
+			for _, job := range jobs {
+				claimed = true
+
+				// A job that was claimed is finished even when shutdown
+				// starts, so its outcome is recorded.
+				q.Process(context.WithoutCancel(ctx), job)
+			}
+		}
+
+		if claimed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(q.config.PollInterval):
+		}
+	}
+}
+
+// Process runs a claimed job and records its outcome: completed, queued again
+// after a backoff, or dead-lettered once its attempts are used up. The outcome
+// is only recorded while the job is still held by the claim it was run under.
+func (q *Queue) Process(ctx context.Context, job *entity.Job) {
+	// What the handler logs is attributed to the job.
+	jobCtx := logger.WithContext(ctx, q.logger.WithFields(map[string]any{
+		"job_id":   job.ID,
+		"job_type": job.Type,
+	}))
+
+	var lockedAt time.Time
+	if job.LockedAt != nil {
+		lockedAt = *job.LockedAt
+	}
+
+	err := q.run(jobCtx, job)
+	if err == nil {
+		held, err := q.repo.Postgres().Job().Complete(ctx, job.ID, lockedAt)
+		q.recorded(job, held, err, "complete")
+
+		return
+	}
+
+	if errors.Is(err, ErrPermanent) || q.IsLastAttempt(job) {
+		q.logger.Error().Err(err).Msgf("Job %d (%s) failed after %d attempts, moving it to the dead-letter queue", job.ID, job.Type, job.Attempts)
+
+		held, err := q.repo.Postgres().Job().DeadLetter(ctx, job.ID, lockedAt, err.Error())
+		q.recorded(job, held, err, "dead-letter")
+
+		return
+	}
+
+	runAt := time.Now().Add(q.backoff(job.Attempts))
+	q.logger.Warn().Err(err).Msgf("Job %d (%s) failed on attempt %d, retrying at %s", job.ID, job.Type, job.Attempts, runAt.Format(time.RFC3339))
+
+	held, err := q.repo.Postgres().Job().Reschedule(ctx, job.ID, lockedAt, runAt, err.Error())
+	q.recorded(job, held, err, "reschedule")
+}
+
+// IsLastAttempt reports whether job is on its last attempt, so a failure
+// moves it to the dead-letter queue. A job without a MaxAttempts of its own
+// gets the queue's default.
+func (q *Queue) IsLastAttempt(job *entity.Job) bool {
+	maxAttempts := job.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = q.config.MaxAttempts
+	}
+
+	return job.Attempts >= maxAttempts
+}
+
+// recorded logs an outcome of job that could not be recorded, either because
+// of err or because the job was no longer held by this worker's claim.
+func (q *Queue) recorded(job *entity.Job, held bool, err error, action string) {
+	switch {
+	case err != nil:
+		q.logger.Error().Err(err).Msgf("Failed to %s job %d", action, job.ID)
+	case !held:
+		q.logger.Warn().Msgf("Job %d (%s) was claimed again by another worker, discarding the outcome of attempt %d", job.ID, job.Type, job.Attempts)
+	}
+}
+
+// run calls the job's handler, bounded by the lock timeout so the job is not
+// still running when another worker may claim it again.
+func (q *Queue) run(ctx context.Context, job *entity.Job) (err error) {
+	handler, ok := q.handlers[job.Type]
+	if !ok {
+		return Permanent(fmt.Errorf("no handler registered for job type %q", job.Type))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	// Changes made by the job are audited as the job's own.
+	ctx = audit.WithActor(ctx, audit.System("job:"+job.Type))
+
+	if q.config.LockTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, q.config.LockTimeout)
+		defer cancel()
+	}
+
+	return handler(ctx, job)
+}
+
+// backoff returns the delay before the retry that follows attempt, doubling
+// from BackoffBase and capped at BackoffMax.
+func (q *Queue) backoff(attempt int) time.Duration {
+	delay := q.config.BackoffBase
+
+	for i := 1; i < attempt && (q.config.BackoffMax <= 0 || delay < q.config.BackoffMax); i++ {
+		delay *= 2
+	}
+
+	if q.config.BackoffMax > 0 && delay > q.config.BackoffMax {
+		delay = q.config.BackoffMax
+	}
+
+	return delay
+}
@@ -0,0 +1,220 @@
+package jobqueue_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"order-service/config"
+	"order-service/internal/domain/entity"
+	"order-service/internal/jobqueue"
+	"order-service/mocks"
+	"order-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testPayload struct {
+	OrderID uint32 `json:"order_id"`
+}
+
+func setupQueueTest(t *testing.T) (*jobqueue.Queue, *mocks.MockJobRepository) {
+	mRepo := mocks.NewMockRepository(t)
+	mPostgres := mocks.NewMockPostgresRepository(t)
+	mJob := mocks.NewMockJobRepository(t)
+
+	mRepo.EXPECT().Postgres().Return(mPostgres).Maybe()
+	mPostgres.EXPECT().Job().Return(mJob).Maybe()
+
+	q := jobqueue.NewQueue(&config.JobsConfig{
+		Concurrency:  1,
+		PollInterval: time.Second,
+		MaxAttempts:  3,
+		BackoffBase:  10 * time.Second,
+		BackoffMax:   30 * time.Second,
+		LockTimeout:  time.Minute,
+	}, mRepo, logger.NewZerologLogger(false))
+
+	return q, mJob
+}
+
+func newTestJob(t *testing.T, attempts int, opts ...jobqueue.Option) *entity.Job {
+	job, err := jobqueue.NewJob("test.job", testPayload{OrderID: 7}, opts...)
+	assert.NoError(t, err)
+
+	job.ID = 1
+	job.Attempts = attempts
+	job.LockedAt = &lockedAt
+
+	return job
+}
+
+// lockedAt is when the test jobs were claimed.
+var lockedAt = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// retryingIn matches a retry time the given delay from now.
+func retryingIn(delay time.Duration) any {
+	return mock.MatchedBy(func(runAt time.Time) bool {
+		return runAt.Sub(time.Now().Add(delay)).Abs() < time.Second
+	})
+}
+
+func TestQueue_Process_Success(t *testing.T) {
+	q, mJob := setupQueueTest(t)
+	ctx := context.Background()
+
+	var got testPayload
+	jobqueue.Register(q, "test.job", func(_ context.Context, payload testPayload) error {
+		got = payload
+		return nil
+	})
+
+	mJob.EXPECT().Complete(ctx, uint32(1), lockedAt).Return(true, nil)
+
+	q.Process(ctx, newTestJob(t, 1))
+
+	assert.Equal(t, testPayload{OrderID: 7}, got)
+}
+
+func TestQueue_Process_RetriesWithBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		delay    time.Duration
+	}{
+		{attempts: 1, delay: 10 * time.Second},
+		{attempts: 2, delay: 20 * time.Second},
+		{attempts: 4, delay: 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		q, mJob := setupQueueTest(t)
+		ctx := context.Background()
+
+		jobqueue.Register(q, "test.job", func(context.Context, testPayload) error {
+			return errors.New("inventory unavailable")
+		})
+
+		mJob.EXPECT().Reschedule(ctx, uint32(1), lockedAt, retryingIn(tt.delay), "inventory unavailable").Return(true, nil)
+
+		q.Process(ctx, newTestJob(t, tt.attempts, jobqueue.MaxAttempts(5)))
+	}
+}
+
+func TestQueue_Process_DiscardsOutcomeOfLostClaim(t *testing.T) {
+	q, mJob := setupQueueTest(t)
+	ctx := context.Background()
+
+	jobqueue.Register(q, "test.job", func(context.Context, testPayload) error {
+		return errors.New("inventory unavailable")
+	})
+
+	// The job overran its lock and was claimed again; the stale worker's
+	// retry must not requeue it under the new claim.
+	mJob.EXPECT().Reschedule(ctx, uint32(1), lockedAt, mock.Anything, "inventory unavailable").Return(false, nil).Once()
+
+	q.Process(ctx, newTestJob(t, 1))
+}
+
+func TestQueue_Process_DeadLettersAfterMaxAttempts(t *testing.T) {
+	q, mJob := setupQueueTest(t)
+	ctx := context.Background()
+
+	jobqueue.Register(q, "test.job", func(context.Context, testPayload) error {
+		return errors.New("inventory unavailable")
+	})
+
+	mJob.EXPECT().DeadLetter(ctx, uint32(1), lockedAt, "inventory unavailable").Return(true, nil)
+
+	q.Process(ctx, newTestJob(t, 3))
+}
+
+func TestQueue_IsLastAttempt(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		opts     []jobqueue.Option
+		want     bool
+	}{
+		{name: "own limit not reached", attempts: 4, opts: []jobqueue.Option{jobqueue.MaxAttempts(5)}},
+		{name: "own limit reached", attempts: 5, opts: []jobqueue.Option{jobqueue.MaxAttempts(5)}, want: true},
+		{name: "default limit not reached", attempts: 2},
+		{name: "default limit reached", attempts: 3, want: true},
+		{name: "zero limit uses default", attempts: 3, opts: []jobqueue.Option{jobqueue.MaxAttempts(0)}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := setupQueueTest(t)
+
+			assert.Equal(t, tt.want, q.IsLastAttempt(newTestJob(t, tt.attempts, tt.opts...)))
+		})
+	}
+}
+
+func TestQueue_Process_DeadLettersPermanentFailures(t *testing.T) {
+	q, mJob := setupQueueTest(t)
+	ctx := context.Background()
+
+	jobqueue.Register(q, "test.job", func(context.Context, testPayload) error {
+		panic("unreachable")
+	})
+
+	job := newTestJob(t, 1)
+	job.Payload = json.RawMessage(`"not an object"`)
+
+	unknown := newTestJob(t, 1)
+	unknown.ID = 2
+	unknown.Type = "unknown.job"
+
+	mJob.EXPECT().DeadLetter(ctx, uint32(1), lockedAt, mock.MatchedBy(func(msg string) bool {
+		return strings.Contains(msg, "failed to decode payload")
+	})).Return(true, nil)
+	mJob.EXPECT().DeadLetter(ctx, uint32(2), lockedAt, mock.MatchedBy(func(msg string) bool {
+		return strings.Contains(msg, `no handler registered for job type "unknown.job"`)
+	})).Return(true, nil)
+
+	q.Process(ctx, job)
+	q.Process(ctx, unknown)
+}
+
+func TestQueue_Process_RecoversPanics(t *testing.T) {
+	q, mJob := setupQueueTest(t)
+	ctx := context.Background()
+
+	jobqueue.Register(q, "test.job", func(context.Context, testPayload) error {
+		panic("boom")
+	})
+
+	mJob.EXPECT().Reschedule(ctx, uint32(1), lockedAt, retryingIn(10*time.Second), "job panicked: boom").Return(true, nil)
+
+	q.Process(ctx, newTestJob(t, 1))
+}
+
+func TestQueue_Run_StopsOnCancel(t *testing.T) {
+	q, mJob := setupQueueTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	jobqueue.Register(q, "test.job", func(context.Context, testPayload) error {
+		cancel()
+		return nil
+	})
+
+	mJob.EXPECT().Claim(ctx, 1, time.Minute).Return([]*entity.Job{newTestJob(t, 1)}, nil).Once()
+	mJob.EXPECT().Complete(mock.Anything, uint32(1), lockedAt).Return(true, nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		q.Run(ctx)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("queue did not stop after its context was cancelled")
+	}
+}
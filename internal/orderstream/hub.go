@@ -0,0 +1,210 @@
+package orderstream
+
+import (
+	"context"
+	"encoding/json"
+	"order-service/config"
+	"order-service/internal/adapter/repository"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/domain/entity"
+	"order-service/internal/shared/exception"
+	"order-service/pkg/logger"
+	"sync"
+	"time"
+)
+
+const reconnectDelay = time.Second
+
+// ErrClosed is returned by Subscribe once the hub is shutting down.
+var ErrClosed = exception.New(exception.TypeServiceUnavailable, exception.CodeServiceUnavailable, "order stream is shutting down")
+
+// notification is the payload the orders trigger sends with each event.
+type notification struct {
+	ID        uint64    `json:"id"`
+	OrderID   uint32    `json:"order_id"`
+	UserID    uint32    `json:"user_id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Hub fans the order status events announced by Postgres out to the streams
+// open on this replica. Every replica listens for itself, so a change made
+// through any replica reaches every stream.
+type Hub struct {
+	config *config.StreamConfig
+	repo   repository.Repository
+	logger logger.Logger
+
+	mu            sync.Mutex
+	subscriptions map[*Subscription]struct{}
+	closed        bool
+}
+
+func NewHub(config *config.StreamConfig, repo repository.Repository, logger logger.Logger) *Hub {
+	return &Hub{
+		config:        config,
+		repo:          repo,
+		logger:        logger,
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscription receives the events of one user's orders. Its channel is
+// closed when the subscriber falls too far behind, when the hub loses its
+// connection to Postgres, and when the hub is closed; the client is expected
+// to reconnect and resume from the last event it received.
+type Subscription struct {
+	hub    *Hub
+	userID uint32
+	events chan *entity.OrderStatusEvent
+}
+
+func (s *Subscription) Events() <-chan *entity.OrderStatusEvent {
+	return s.events
+}
+
+// Close stops the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.drop(s)
+}
+
+// Subscribe starts receiving the events of userID's orders.
+func (h *Hub) Subscribe(userID uint32) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrClosed
+	}
+
+	sub := &Subscription{
+		hub:    h,
+		userID: userID,
+		events: make(chan *entity.OrderStatusEvent, max(h.config.BufferSize, 1)),
+	}
+	h.subscriptions[sub] = struct{}{}
+
+	return sub, nil
+}
+
+// Replay returns the events of userID's orders after afterID that are still
+// within the retention window.
+func (h *Hub) Replay(ctx context.Context, userID uint32, afterID uint64) ([]*entity.OrderStatusEvent, error) {
+	return h.repo.Postgres().OrderStatusEvent().FindByUserAfter(ctx, userID, afterID, time.Now().Add(-h.config.Retention))
+}
+
+// Run listens for events until ctx is cancelled, reconnecting when the
+// connection is lost, and purges events older than the retention window.
+func (h *Hub) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		h.purge(ctx)
+	}()
+
+	for {
+		err := h.repo.Postgres().Listen(ctx, postgresrepository.OrderStatusEventChannel, h.dispatch)
+		if ctx.Err() != nil {
+			break
+		}
+
+		// Events may be missed until the listener is back, so streams are
+		// ended rather than left silently incomplete.
+		h.logger.Error().Err(err).Msg("Lost order status listener, reconnecting")
+		h.dropAll()
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(reconnectDelay):
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	wg.Wait()
+}
+
+// Close ends every subscription and refuses new ones, so open streams return
+// and the HTTP server can shut down.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	h.closed = true
+	h.mu.Unlock()
+
+	h.dropAll()
+}
+
+func (h *Hub) dispatch(payload string) {
+	var n notification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		h.logger.Error().Err(err).Msgf("Failed to decode order status event %q", payload)
+		return
+	}
+
+	event := &entity.OrderStatusEvent{
+		ID:        n.ID,
+		OrderID:   n.OrderID,
+		UserID:    n.UserID,
+		Status:    n.Status,
+		CreatedAt: n.CreatedAt,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscriptions {
+		if sub.userID != event.UserID {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			h.logger.Warn().Msgf("Order stream of user %d fell behind, disconnecting it", sub.userID)
+			h.drop(sub)
+		}
+	}
+}
+
+func (h *Hub) purge(ctx context.Context) {
+	ticker := time.NewTicker(max(h.config.Retention, time.Minute))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := h.repo.Postgres().OrderStatusEvent().Purge(ctx, time.Now().Add(-h.config.Retention)); err != nil && ctx.Err() == nil {
+			h.logger.Error().Err(err).Msg("Failed to purge order status events")
+		}
+	}
+}
+
+func (h *Hub) dropAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscriptions {
+		h.drop(sub)
+	}
+}
+
+// drop must be called with h.mu held.
+func (h *Hub) drop(sub *Subscription) {
+	if _, ok := h.subscriptions[sub]; !ok {
+		return
+	}
+
+	delete(h.subscriptions, sub)
+	close(sub.events)
+}
@@ -0,0 +1,167 @@
+package orderstream_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"order-service/config"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/domain/entity"
+	"order-service/internal/orderstream"
+	"order-service/mocks"
+	"order-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupHubTest(t *testing.T, bufferSize int) (*orderstream.Hub, *mocks.MockPostgresRepository) {
+	mRepo := mocks.NewMockRepository(t)
+	mPostgres := mocks.NewMockPostgresRepository(t)
+
+	mRepo.EXPECT().Postgres().Return(mPostgres).Maybe()
+
+	hub := orderstream.NewHub(&config.StreamConfig{
+		Heartbeat:  time.Second,
+		Retention:  time.Hour,
+		BufferSize: bufferSize,
+	}, mRepo, logger.NewZerologLogger(false))
+
+	return hub, mPostgres
+}
+
+// listenAndNotify makes the listener deliver payloads and then wait for its
+// context, as a healthy connection would.
+func listenAndNotify(mPostgres *mocks.MockPostgresRepository, payloads ...string) {
+	mPostgres.EXPECT().
+		Listen(mock.Anything, postgresrepository.OrderStatusEventChannel, mock.Anything).
+		RunAndReturn(func(ctx context.Context, _ string, fn func(payload string)) error {
+			for _, payload := range payloads {
+				fn(payload)
+			}
+
+			<-ctx.Done()
+
+			return ctx.Err()
+		})
+}
+
+func runHub(t *testing.T, hub *orderstream.Hub) context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		hub.Run(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	return cancel
+}
+
+func receive(t *testing.T, sub *orderstream.Subscription) (*entity.OrderStatusEvent, bool) {
+	select {
+	case event, ok := <-sub.Events():
+		return event, ok
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+		return nil, false
+	}
+}
+
+func TestHub_DispatchesToTheOrderOwner(t *testing.T) {
+	hub, mPostgres := setupHubTest(t, 8)
+
+	owner, err := hub.Subscribe(7)
+	assert.NoError(t, err)
+	other, err := hub.Subscribe(8)
+	assert.NoError(t, err)
+
+	listenAndNotify(mPostgres,
+		`{"id":41,"order_id":3,"user_id":7,"status":"CONFIRMED","created_at":"2026-10-18T10:00:00.123456+00:00"}`,
+		`not json`,
+	)
+	runHub(t, hub)
+
+	event, ok := receive(t, owner)
+	if assert.True(t, ok) {
+		assert.Equal(t, uint64(41), event.ID)
+		assert.Equal(t, uint32(3), event.OrderID)
+		assert.Equal(t, "CONFIRMED", event.Status)
+	}
+
+	other.Close()
+	_, ok = <-other.Events()
+	assert.False(t, ok)
+}
+
+func TestHub_DisconnectsSlowSubscriber(t *testing.T) {
+	hub, mPostgres := setupHubTest(t, 1)
+
+	sub, err := hub.Subscribe(7)
+	assert.NoError(t, err)
+
+	notified := make(chan struct{})
+	mPostgres.EXPECT().
+		Listen(mock.Anything, postgresrepository.OrderStatusEventChannel, mock.Anything).
+		RunAndReturn(func(ctx context.Context, _ string, fn func(payload string)) error {
+			fn(`{"id":1,"order_id":3,"user_id":7,"status":"PENDING_PAYMENT"}`)
+			fn(`{"id":2,"order_id":3,"user_id":7,"status":"CONFIRMED"}`)
+			close(notified)
+
+			<-ctx.Done()
+
+			return ctx.Err()
+		})
+	runHub(t, hub)
+
+	// Read only once both events were sent, so the buffer of one overflows.
+	<-notified
+
+	event, ok := receive(t, sub)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), event.ID)
+
+	_, ok = receive(t, sub)
+	assert.False(t, ok, "the stream must end rather than skip an event")
+}
+
+func TestHub_LostListenerEndsStreams(t *testing.T) {
+	hub, mPostgres := setupHubTest(t, 8)
+
+	sub, err := hub.Subscribe(7)
+	assert.NoError(t, err)
+
+	mPostgres.EXPECT().
+		Listen(mock.Anything, postgresrepository.OrderStatusEventChannel, mock.Anything).
+		Return(errors.New("connection reset")).
+		Once()
+	listenAndNotify(mPostgres)
+	runHub(t, hub)
+
+	_, ok := receive(t, sub)
+	assert.False(t, ok)
+}
+
+func TestHub_Close(t *testing.T) {
+	hub, _ := setupHubTest(t, 8)
+
+	sub, err := hub.Subscribe(7)
+	assert.NoError(t, err)
+
+	hub.Close()
+
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+
+	sub.Close()
+
+	_, err = hub.Subscribe(7)
+	assert.ErrorIs(t, err, orderstream.ErrClosed)
+}
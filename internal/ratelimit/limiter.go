@@ -0,0 +1,104 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"order-service/config"
+	"order-service/internal/adapter/repository"
+	"order-service/pkg/logger"
+	"time"
+)
+
+const (
+	StoreMemory   = "memory"
+	StorePostgres = "postgres"
+
+	// purgeInterval is how often a store deletes the buckets that have been
+	// idle long enough to have refilled.
+	purgeInterval = time.Minute
+)
+
+// Bucket is the state of a client's token bucket after a request took a
+// token from it, or was refused one when Allowed is false.
+type Bucket struct {
+	Tokens  float64
+	Allowed bool
+}
+
+// Store keeps the token buckets. Take refills the bucket of key for the time
+// since it was last used, at the rate of limit up to its burst, and takes a
+// token from it if one is left. A new bucket starts full.
+type Store interface {
+	Take(ctx context.Context, key string, limit config.RateLimitRule) (Bucket, error)
+}
+
+// Result is the outcome of a request against its limit.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is how long until a refused request may be retried.
+	RetryAfter time.Duration
+}
+
+// Limiter limits requests with token buckets kept in a Store.
+type Limiter struct {
+	store Store
+}
+
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{store: store}
+}
+
+// NewStore returns the store configured by cfg. Buckets idle for the longest
+// period of the configured rules are purged, as they have refilled by then.
+func NewStore(cfg *config.Config, repo repository.Repository, logger logger.Logger) (Store, error) {
+	idleTTL := cfg.RateLimit.Default.Period
+	for _, rule := range cfg.RateLimit.Routes {
+		idleTTL = max(idleTTL, rule.Period)
+	}
+
+	switch cfg.RateLimit.Store {
+	case "", StoreMemory:
+		return NewMemoryStore(idleTTL), nil
+	case StorePostgres:
+		return NewPostgresStore(repo, idleTTL, logger), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", cfg.RateLimit.Store)
+	}
+}
+
+// Take counts a request of the client key against limit.
+func (l *Limiter) Take(ctx context.Context, key string, limit config.RateLimitRule) (*Result, error) {
+	bucket, err := l.store.Take(ctx, key, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	rate := refillRate(limit)
+
+	res := &Result{
+		Allowed:   bucket.Allowed,
+		Limit:     limit.Requests,
+		Remaining: int(math.Floor(bucket.Tokens)),
+		Reset:     seconds((float64(limit.Requests) - bucket.Tokens) / rate),
+	}
+
+	if !bucket.Allowed {
+		res.RetryAfter = seconds((1 - bucket.Tokens) / rate)
+	}
+
+	return res, nil
+}
+
+// refillRate returns the tokens per second limit refills a bucket with.
+func refillRate(limit config.RateLimitRule) float64 {
+	return float64(limit.Requests) / limit.Period.Seconds()
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Max(s, 0) * float64(time.Second))
+}
@@ -0,0 +1,115 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"order-service/config"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/ratelimit"
+	"order-service/mocks"
+	"order-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_MemoryStore(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(time.Hour))
+	rule := config.RateLimitRule{Requests: 2, Period: time.Hour}
+	ctx := context.Background()
+
+	res, err := limiter.Take(ctx, "ip:10.0.0.1", rule)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 2, res.Limit)
+	assert.Equal(t, 1, res.Remaining)
+	assert.InDelta(t, 30*time.Minute, res.Reset, float64(time.Second))
+
+	res, err = limiter.Take(ctx, "ip:10.0.0.1", rule)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+
+	res, err = limiter.Take(ctx, "ip:10.0.0.1", rule)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.InDelta(t, 30*time.Minute, res.RetryAfter, float64(time.Second))
+
+	res, err = limiter.Take(ctx, "ip:10.0.0.2", rule)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed, "clients have buckets of their own")
+}
+
+func TestLimiter_MemoryStoreRefills(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(time.Second))
+	rule := config.RateLimitRule{Requests: 1, Period: 50 * time.Millisecond}
+	ctx := context.Background()
+
+	res, err := limiter.Take(ctx, "ip:10.0.0.1", rule)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	res, err = limiter.Take(ctx, "ip:10.0.0.1", rule)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+
+	time.Sleep(res.RetryAfter + 10*time.Millisecond)
+
+	res, err = limiter.Take(ctx, "ip:10.0.0.1", rule)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func setupPostgresStore(t *testing.T) (*ratelimit.Limiter, *mocks.MockRateLimitRepository) {
+	mRepo := mocks.NewMockRepository(t)
+	mPostgres := mocks.NewMockPostgresRepository(t)
+	mRateLimit := mocks.NewMockRateLimitRepository(t)
+
+	mRepo.EXPECT().Postgres().Return(mPostgres).Maybe()
+	mPostgres.EXPECT().RateLimit().Return(mRateLimit).Maybe()
+
+	store := ratelimit.NewPostgresStore(mRepo, time.Minute, logger.NewZerologLogger(false))
+
+	return ratelimit.NewLimiter(store), mRateLimit
+}
+
+func TestLimiter_PostgresStore(t *testing.T) {
+	limiter, mRateLimit := setupPostgresStore(t)
+	rule := config.RateLimitRule{Requests: 10, Period: time.Minute}
+
+	mRateLimit.EXPECT().
+		Take(mock.Anything, "ip:10.0.0.1", float64(10), mock.MatchedBy(func(rate float64) bool {
+			return assert.InDelta(t, 10.0/60, rate, 1e-9)
+		})).
+		Return(&postgresrepository.RateLimitBucket{Tokens: 0.5, Allowed: false}, nil)
+
+	res, err := limiter.Take(context.Background(), "ip:10.0.0.1", rule)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.InDelta(t, 3*time.Second, res.RetryAfter, float64(time.Millisecond))
+	assert.InDelta(t, 57*time.Second, res.Reset, float64(time.Millisecond))
+}
+
+func TestLimiter_PostgresStoreError(t *testing.T) {
+	limiter, mRateLimit := setupPostgresStore(t)
+
+	mRateLimit.EXPECT().
+		Take(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("connection refused"))
+
+	_, err := limiter.Take(context.Background(), "ip:10.0.0.1", config.RateLimitRule{Requests: 1, Period: time.Second})
+	assert.Error(t, err)
+}
+
+func TestNewStore_UnknownStore(t *testing.T) {
+	_, err := ratelimit.NewStore(&config.Config{
+		RateLimit: &config.RateLimitConfig{Store: "redis"},
+	}, nil, logger.NewZerologLogger(false))
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"order-service/config"
+	"sync"
+	"time"
+)
+
+var _ Store = (*MemoryStore)(nil)
+
+// MemoryStore keeps the buckets in memory, so each replica limits clients on
+// its own.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	idleTTL   time.Duration
+	lastPurge time.Time
+	now       func() time.Time
+}
+
+type memoryBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func NewMemoryStore(idleTTL time.Duration) *MemoryStore {
+	return &MemoryStore{
+		buckets:   make(map[string]*memoryBucket),
+		idleTTL:   idleTTL,
+		lastPurge: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit config.RateLimitRule) (Bucket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.purge(now)
+
+	capacity := float64(limit.Requests)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	}
+
+	tokens := min(capacity, b.tokens+now.Sub(b.updated).Seconds()*refillRate(limit))
+
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+
+	b.tokens, b.updated = tokens, now
+
+	return Bucket{Tokens: tokens, Allowed: allowed}, nil
+}
+
+func (s *MemoryStore) purge(now time.Time) {
+	if now.Sub(s.lastPurge) < purgeInterval {
+		return
+	}
+
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) >= s.idleTTL {
+			delete(s.buckets, key)
+		}
+	}
+
+	s.lastPurge = now
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"order-service/config"
+	"order-service/internal/adapter/repository"
+	"order-service/pkg/logger"
+	"sync/atomic"
+	"time"
+)
+
+var _ Store = (*PostgresStore)(nil)
+
+// purgeTimeout bounds how long purging idle buckets may take.
+const purgeTimeout = 10 * time.Second
+
+// PostgresStore keeps the buckets in Postgres, so every replica counts a
+// client's requests against the same bucket.
+type PostgresStore struct {
+	repo      repository.Repository
+	logger    logger.Logger
+	idleTTL   time.Duration
+	lastPurge atomic.Int64
+}
+
+func NewPostgresStore(repo repository.Repository, idleTTL time.Duration, logger logger.Logger) *PostgresStore {
+	s := &PostgresStore{
+		repo:    repo,
+		logger:  logger,
+		idleTTL: idleTTL,
+	}
+	s.lastPurge.Store(time.Now().UnixNano())
+
+	return s
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, limit config.RateLimitRule) (Bucket, error) {
+	s.purge(ctx)
+
+	bucket, err := s.repo.Postgres().RateLimit().Take(ctx, key, float64(limit.Requests), refillRate(limit))
+	if err != nil {
+		return Bucket{}, err
+	}
+
+	return Bucket{Tokens: bucket.Tokens, Allowed: bucket.Allowed}, nil
+}
+
+// purge deletes the idle buckets in the background once per purgeInterval.
+func (s *PostgresStore) purge(ctx context.Context) {
+	last := s.lastPurge.Load()
+
+	now := time.Now()
+	if now.Sub(time.Unix(0, last)) < purgeInterval || !s.lastPurge.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), purgeTimeout)
+		defer cancel()
+
+		if _, err := s.repo.Postgres().RateLimit().Purge(ctx, now.Add(-s.idleTTL)); err != nil {
+			logger.FromContext(ctx, s.logger).Warn().Err(err).Msg("Failed to purge idle rate limit buckets")
+		}
+	}()
+}
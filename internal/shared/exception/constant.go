@@ -19,6 +19,7 @@ const (
 	TypeMethodNotAllowed     ErrorType = "Method Not Allowed"
 	TypeConflict             ErrorType = "Conflict"
 	TypeUnsupportedMediaType ErrorType = "Unsupported Media Type"
+	TypePayloadTooLarge      ErrorType = "Payload Too Large"
 	TypeRateLimitExceeded    ErrorType = "Rate Limit Exceeded"
 	TypeQueryError           ErrorType = "Query Error"
 	TypeConnectionError      ErrorType = "Connection Error"
@@ -49,6 +50,11 @@ const (
 	CodeAuthHeaderInvalid     = "AUTH_HEADER_INVALID"
 	CodeAuthUnsupported       = "AUTH_UNSUPPORTED"
 	CodeDBConstraintViolation = "DB_CONSTRAINT_VIOLATION"
+	CodeCouponInvalid         = "COUPON_INVALID"
+	CodeCouponUsageExceeded   = "COUPON_USAGE_EXCEEDED"
+	CodeRateLimitExceeded     = "RATE_LIMIT_EXCEEDED"
+	CodePayloadTooLarge       = "PAYLOAD_TOO_LARGE"
+	CodeUnsupportedMediaType  = "UNSUPPORTED_MEDIA_TYPE"
 )
 
 var (
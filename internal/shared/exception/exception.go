@@ -70,7 +70,9 @@ func Wrap(cause error, kind ErrorType, code string, message string) error {
 
 	marker := newException(kind, code, message)
 
-	return errors.Wrapf(marker, "%w", cause)
+	// Keep both the marker and the cause in the chain so callers can match
+	// either with errors.Is / errors.As.
+	return errors.WithStack(fmt.Errorf("%w: %w", marker, cause))
 }
 
 func Wrapf(cause error, kind ErrorType, code string, format string, args ...any) error {
@@ -81,7 +83,7 @@ func Wrapf(cause error, kind ErrorType, code string, format string, args ...any)
 	message := fmt.Sprintf(format, args...)
 	marker := newException(kind, code, message)
 
-	return errors.Wrapf(marker, "%w", marker)
+	return errors.WithStack(fmt.Errorf("%w: %w", marker, cause))
 }
 
 func GetException(err error) (*Exception, bool) {
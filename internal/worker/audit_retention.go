@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"order-service/config"
+	"order-service/internal/adapter/repository"
+	"order-service/pkg/logger"
+	"time"
+)
+
+// auditRetentionLockKey is the Postgres advisory lock key held while the
+// audit log is purged, so only one replica purges it at a time.
+const auditRetentionLockKey int64 = 0x6175646974726574
+
+// AuditRetentionPurger periodically deletes audit log entries older than the
+// configured retention.
+type AuditRetentionPurger struct {
+	config *config.AuditConfig
+	repo   repository.Repository
+	logger logger.Logger
+}
+
+func NewAuditRetentionPurger(config *config.AuditConfig, repo repository.Repository, logger logger.Logger) *AuditRetentionPurger {
+	return &AuditRetentionPurger{
+		config: config,
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Run purges every configured interval until ctx is cancelled.
+func (w *AuditRetentionPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.purge(context.WithoutCancel(ctx))
+		}
+	}
+}
+
+func (w *AuditRetentionPurger) purge(ctx context.Context) {
+	var purged int
+
+	acquired, err := w.repo.Postgres().WithAdvisoryLock(ctx, auditRetentionLockKey, func(ctx context.Context) error {
+		var err error
+
+		purged, err = w.repo.Postgres().Audit().Purge(ctx, time.Now().Add(-w.config.Retention))
+
+		return err
+	})
+	if err != nil {
+		w.logger.Error().Err(err).Msg("Failed to purge audit log")
+		return
+	}
+
+	if !acquired {
+		w.logger.Debug().Msg("Audit log purge is running on another instance, skipping")
+		return
+	}
+
+	if purged > 0 {
+		w.logger.Info().Msgf("Purged %d audit log entries", purged)
+	}
+}
@@ -0,0 +1,74 @@
+package worker
+
+import (
+	"context"
+	"order-service/config"
+	"order-service/internal/adapter/repository"
+	"order-service/internal/domain/audit"
+	"order-service/internal/domain/service"
+	"order-service/pkg/logger"
+	"time"
+)
+
+// orderExpiryLockKey is the Postgres advisory lock key held while a sweep
+// runs, so only one replica expires orders at a time.
+const orderExpiryLockKey int64 = 0x6f72646572657870
+
+// OrderExpirySweeper periodically cancels orders that are still awaiting
+// payment after the configured TTL, releasing the stock they hold.
+type OrderExpirySweeper struct {
+	config  *config.ExpiryConfig
+	repo    repository.Repository
+	service service.Service
+	logger  logger.Logger
+}
+
+func NewOrderExpirySweeper(config *config.ExpiryConfig, repo repository.Repository, service service.Service, logger logger.Logger) *OrderExpirySweeper {
+	return &OrderExpirySweeper{
+		config:  config,
+		repo:    repo,
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Run sweeps every configured interval until ctx is cancelled. A sweep that
+// is in progress when ctx is cancelled is finished first.
+func (w *OrderExpirySweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.sweep(audit.WithActor(context.WithoutCancel(ctx), audit.System("order_expiry")))
+		}
+	}
+}
+
+func (w *OrderExpirySweeper) sweep(ctx context.Context) {
+	var expired int
+
+	acquired, err := w.repo.Postgres().WithAdvisoryLock(ctx, orderExpiryLockKey, func(ctx context.Context) error {
+		var err error
+
+		expired, err = w.service.Order().ExpirePending(ctx, time.Now().Add(-w.config.PendingTTL), w.config.BatchSize)
+
+		return err
+	})
+	if err != nil {
+		w.logger.Error().Err(err).Msg("Failed to expire pending orders")
+		return
+	}
+
+	if !acquired {
+		w.logger.Debug().Msg("Order expiry is running on another instance, skipping")
+		return
+	}
+
+	if expired > 0 {
+		w.logger.Info().Msgf("Expired %d pending orders", expired)
+	}
+}
@@ -0,0 +1,146 @@
+package worker
+
+import (
+	"context"
+	"order-service/config"
+	"order-service/internal/adapter/repository"
+	"order-service/pkg/logger"
+	"slices"
+	"time"
+)
+
+// partitionLockKey is the Postgres advisory lock key held while partitions
+// are maintained, so only one replica changes them at a time.
+const partitionLockKey int64 = 0x7061727469746e73
+
+// partitionedTables are the tables partitioned by month of their order's
+// created_at, so a month's partitions of each hold the same orders.
+var partitionedTables = []string{"orders", "order_items"}
+
+// PartitionMaintainer keeps the monthly partitions of the orders tables:
+// it creates them ahead of time and detaches or drops expired ones.
+type PartitionMaintainer struct {
+	config *config.PartitionConfig
+	repo   repository.Repository
+	logger logger.Logger
+}
+
+func NewPartitionMaintainer(config *config.PartitionConfig, repo repository.Repository, logger logger.Logger) *PartitionMaintainer {
+	return &PartitionMaintainer{
+		config: config,
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Run maintains the partitions right away and then every configured
+// interval until ctx is cancelled.
+func (w *PartitionMaintainer) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Maintain(context.WithoutCancel(ctx)); err != nil {
+			w.logger.Error().Err(err).Msg("Failed to maintain order partitions")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Maintain creates the partitions of the current month and the configured
+// months ahead, then expires the partitions older than the retention.
+func (w *PartitionMaintainer) Maintain(ctx context.Context) error {
+	acquired, err := w.repo.Postgres().WithAdvisoryLock(ctx, partitionLockKey, func(ctx context.Context) error {
+		thisMonth := startOfMonth(time.Now())
+
+		for _, table := range partitionedTables {
+			if err := w.premake(ctx, table, thisMonth); err != nil {
+				return err
+			}
+		}
+
+		if w.config.RetentionMonths > 0 {
+			return w.expire(ctx, thisMonth.AddDate(0, -w.config.RetentionMonths, 0))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !acquired {
+		w.logger.Debug().Msg("Order partitions are maintained by another instance, skipping")
+	}
+
+	return nil
+}
+
+func (w *PartitionMaintainer) premake(ctx context.Context, table string, thisMonth time.Time) error {
+	for i := 0; i <= w.config.PremakeMonths; i++ {
+		month := thisMonth.AddDate(0, i, 0)
+
+		created, err := w.repo.Postgres().Partition().CreateMonthly(ctx, table, month)
+		if err != nil {
+			return err
+		}
+
+		if created {
+			w.logger.Info().Msgf("Created partition of %s for %s", table, month.Format("2006-01"))
+		}
+	}
+
+	return nil
+}
+
+// expire detaches or drops the partitions whose month ended before cutoff,
+// together with the rows of other tables that belong to their orders. A
+// month with orders still open is kept in every table; it expires once they
+// are closed or archived.
+func (w *PartitionMaintainer) expire(ctx context.Context, cutoff time.Time) error {
+	var months []time.Time
+
+	for _, table := range partitionedTables {
+		partitions, err := w.repo.Postgres().Partition().FindMonthly(ctx, table)
+		if err != nil {
+			return err
+		}
+
+		for _, partition := range partitions {
+			if partition.Month.AddDate(0, 1, 0).After(cutoff) || slices.ContainsFunc(months, partition.Month.Equal) {
+				continue
+			}
+
+			months = append(months, partition.Month)
+		}
+	}
+
+	slices.SortFunc(months, time.Time.Compare)
+
+	for _, month := range months {
+		expired, err := w.repo.Postgres().Partition().Expire(ctx, month, w.config.DropExpired)
+		if err != nil {
+			return err
+		}
+
+		if !expired {
+			w.logger.Warn().Msgf("Keeping expired partitions for %s, which still hold open orders", month.Format("2006-01"))
+			continue
+		}
+
+		w.logger.Info().Msgf("Expired partitions for %s (dropped: %t)", month.Format("2006-01"), w.config.DropExpired)
+	}
+
+	return nil
+}
+
+func startOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
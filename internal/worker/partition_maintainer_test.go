@@ -0,0 +1,134 @@
+package worker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/config"
+	postgresrepository "order-service/internal/adapter/repository/postgres"
+	"order-service/internal/worker"
+	"order-service/mocks"
+	"order-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupPartitionTest(t *testing.T, cfg *config.PartitionConfig) (*worker.PartitionMaintainer, *mocks.MockPartitionRepository) {
+	mRepo := mocks.NewMockRepository(t)
+	mPostgres := mocks.NewMockPostgresRepository(t)
+	mPartition := mocks.NewMockPartitionRepository(t)
+
+	mRepo.EXPECT().Postgres().Return(mPostgres).Maybe()
+	mPostgres.EXPECT().Partition().Return(mPartition).Maybe()
+	mPostgres.EXPECT().
+		WithAdvisoryLock(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, _ int64, fn func(ctx context.Context) error) (bool, error) {
+			return true, fn(ctx)
+		})
+
+	return worker.NewPartitionMaintainer(cfg, mRepo, logger.NewZerologLogger(false)), mPartition
+}
+
+func thisMonth() time.Time {
+	now := time.Now().UTC()
+
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPartitionMaintainer_CreatesUpcomingPartitions(t *testing.T) {
+	w, mPartition := setupPartitionTest(t, &config.PartitionConfig{PremakeMonths: 2})
+	ctx := context.Background()
+
+	for _, table := range []string{"orders", "order_items"} {
+		for i := 0; i <= 2; i++ {
+			mPartition.EXPECT().CreateMonthly(ctx, table, thisMonth().AddDate(0, i, 0)).Return(i > 0, nil).Once()
+		}
+	}
+
+	err := w.Maintain(ctx)
+
+	assert.NoError(t, err)
+}
+
+func TestPartitionMaintainer_ExpiresPartitionsPastRetention(t *testing.T) {
+	tests := []struct {
+		name        string
+		dropExpired bool
+	}{
+		{name: "detach"},
+		{name: "drop", dropExpired: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, mPartition := setupPartitionTest(t, &config.PartitionConfig{RetentionMonths: 2, DropExpired: tt.dropExpired})
+			ctx := context.Background()
+
+			mPartition.EXPECT().CreateMonthly(ctx, mock.Anything, thisMonth()).Return(false, nil)
+
+			for _, table := range []string{"orders", "order_items"} {
+				mPartition.EXPECT().FindMonthly(ctx, table).Return([]*postgresrepository.MonthlyPartition{
+					{Name: table + "_expired", Month: thisMonth().AddDate(0, -3, 0)},
+					{Name: table + "_kept", Month: thisMonth().AddDate(0, -2, 0)},
+					{Name: table + "_current", Month: thisMonth()},
+				}, nil)
+			}
+
+			// Only the month of three months ago ended before the two months
+			// kept, and it is expired once for both tables.
+			mPartition.EXPECT().Expire(ctx, thisMonth().AddDate(0, -3, 0), tt.dropExpired).Return(true, nil).Once()
+
+			err := w.Maintain(ctx)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPartitionMaintainer_ExpiresMonthsOldestFirst(t *testing.T) {
+	w, mPartition := setupPartitionTest(t, &config.PartitionConfig{RetentionMonths: 2})
+	ctx := context.Background()
+
+	mPartition.EXPECT().CreateMonthly(ctx, mock.Anything, thisMonth()).Return(false, nil)
+
+	// An earlier run left the items of four months ago without their orders.
+	mPartition.EXPECT().FindMonthly(ctx, "orders").Return([]*postgresrepository.MonthlyPartition{
+		{Name: "orders_closed", Month: thisMonth().AddDate(0, -3, 0)},
+	}, nil)
+	mPartition.EXPECT().FindMonthly(ctx, "order_items").Return([]*postgresrepository.MonthlyPartition{
+		{Name: "order_items_older", Month: thisMonth().AddDate(0, -4, 0)},
+		{Name: "order_items_closed", Month: thisMonth().AddDate(0, -3, 0)},
+	}, nil)
+
+	older := mPartition.EXPECT().Expire(ctx, thisMonth().AddDate(0, -4, 0), false).Return(true, nil).Once()
+	mPartition.EXPECT().Expire(ctx, thisMonth().AddDate(0, -3, 0), false).Return(true, nil).Once().NotBefore(older)
+
+	err := w.Maintain(ctx)
+
+	assert.NoError(t, err)
+}
+
+func TestPartitionMaintainer_KeepsExpiredPartitionsWithOpenOrders(t *testing.T) {
+	w, mPartition := setupPartitionTest(t, &config.PartitionConfig{RetentionMonths: 2, DropExpired: true})
+	ctx := context.Background()
+
+	mPartition.EXPECT().CreateMonthly(ctx, mock.Anything, thisMonth()).Return(false, nil)
+
+	for _, table := range []string{"orders", "order_items"} {
+		mPartition.EXPECT().FindMonthly(ctx, table).Return([]*postgresrepository.MonthlyPartition{
+			{Name: table + "_closed", Month: thisMonth().AddDate(0, -4, 0)},
+			{Name: table + "_open", Month: thisMonth().AddDate(0, -3, 0)},
+			{Name: table + "_current", Month: thisMonth()},
+		}, nil)
+	}
+
+	// The repository keeps the month with open orders in both tables.
+	mPartition.EXPECT().Expire(ctx, thisMonth().AddDate(0, -4, 0), true).Return(true, nil).Once()
+	mPartition.EXPECT().Expire(ctx, thisMonth().AddDate(0, -3, 0), true).Return(false, nil).Once()
+
+	err := w.Maintain(ctx)
+
+	assert.NoError(t, err)
+}
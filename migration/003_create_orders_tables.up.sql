@@ -0,0 +1,35 @@
+START TRANSACTION;
+
+CREATE TABLE IF NOT EXISTS orders (
+    id          SERIAL PRIMARY KEY,
+    user_id     INTEGER       NOT NULL,
+    status      VARCHAR(50)   NOT NULL,
+    total_price NUMERIC(19,4) NOT NULL DEFAULT 0,
+    created_at  TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at  TIMESTAMPTZ   NULL DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_user_id ON orders (user_id);
+CREATE INDEX IF NOT EXISTS idx_orders_status ON orders (status);
+
+CREATE TABLE IF NOT EXISTS order_items (
+    id          SERIAL PRIMARY KEY,
+    order_id    INTEGER       NOT NULL REFERENCES orders (id) ON DELETE RESTRICT,
+    product_id  VARCHAR(255)  NOT NULL,
+    quantity    INTEGER       NOT NULL,
+    price       NUMERIC(19,4) NOT NULL,
+    subtotal    NUMERIC(19,4) NOT NULL,
+    created_at  TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at  TIMESTAMPTZ   NULL DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items (order_id);
+
+-- Databases created before prices were decimal stored them as floating point.
+ALTER TABLE orders ALTER COLUMN total_price TYPE NUMERIC(19,4) USING round(total_price::numeric, 4);
+ALTER TABLE order_items ALTER COLUMN price TYPE NUMERIC(19,4) USING round(price::numeric, 4);
+ALTER TABLE order_items ALTER COLUMN subtotal TYPE NUMERIC(19,4) USING round(subtotal::numeric, 4);
+
+COMMIT;
@@ -0,0 +1,19 @@
+START TRANSACTION;
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS currency         VARCHAR(3)    NOT NULL DEFAULT 'IDR',
+    ADD COLUMN IF NOT EXISTS base_currency    VARCHAR(3)    NOT NULL DEFAULT 'IDR',
+    ADD COLUMN IF NOT EXISTS base_total_price NUMERIC(19,4) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS fx_rate          NUMERIC(24,12) NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS fx_rate_source   VARCHAR(255)  NOT NULL DEFAULT 'identity',
+    ADD COLUMN IF NOT EXISTS fx_rate_at       TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS base_price NUMERIC(19,4) NOT NULL DEFAULT 0;
+
+-- Orders created before multi-currency support were priced in the base
+-- currency at a rate of one.
+UPDATE orders SET base_total_price = total_price WHERE base_total_price = 0;
+UPDATE order_items SET base_price = price WHERE base_price = 0;
+
+COMMIT;
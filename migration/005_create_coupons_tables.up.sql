@@ -0,0 +1,72 @@
+START TRANSACTION;
+
+CREATE TABLE IF NOT EXISTS coupons (
+    id                   SERIAL PRIMARY KEY,
+    code                 VARCHAR(64)   NOT NULL,
+    name                 VARCHAR(255)  NOT NULL DEFAULT '',
+    description          TEXT          NOT NULL DEFAULT '',
+    type                 VARCHAR(50)   NOT NULL,
+    percent_off          NUMERIC(9,4)  NOT NULL DEFAULT 0,
+    amount_off           NUMERIC(19,4) NOT NULL DEFAULT 0,
+    max_discount         NUMERIC(19,4) NOT NULL DEFAULT 0,
+    min_spend            NUMERIC(19,4) NOT NULL DEFAULT 0,
+    free_product_id      VARCHAR(255)  NOT NULL DEFAULT '',
+    free_quantity        INTEGER       NOT NULL DEFAULT 0,
+    starts_at            TIMESTAMPTZ   NULL DEFAULT NULL,
+    ends_at              TIMESTAMPTZ   NULL DEFAULT NULL,
+    usage_limit          INTEGER       NOT NULL DEFAULT 0,
+    usage_limit_per_user INTEGER       NOT NULL DEFAULT 0,
+    used_count           INTEGER       NOT NULL DEFAULT 0,
+    is_active            BOOLEAN       NOT NULL DEFAULT TRUE,
+    created_at           TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at           TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at           TIMESTAMPTZ   NULL DEFAULT NULL,
+    CONSTRAINT chk_coupons_used_count CHECK (usage_limit = 0 OR used_count <= usage_limit)
+);
+
+-- Codes of deleted coupons may be reused.
+CREATE UNIQUE INDEX IF NOT EXISTS uq_coupons_code ON coupons (code) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS coupon_products (
+    coupon_id  INTEGER      NOT NULL REFERENCES coupons (id) ON DELETE CASCADE,
+    product_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (coupon_id, product_id)
+);
+
+CREATE TABLE IF NOT EXISTS coupon_usages (
+    id         SERIAL PRIMARY KEY,
+    coupon_id  INTEGER     NOT NULL REFERENCES coupons (id) ON DELETE RESTRICT,
+    user_id    INTEGER     NOT NULL,
+    order_id   INTEGER     NOT NULL REFERENCES orders (id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ NULL DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_coupon_usages_coupon_user ON coupon_usages (coupon_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_coupon_usages_order_id ON coupon_usages (order_id);
+
+CREATE TABLE IF NOT EXISTS order_adjustments (
+    id            SERIAL PRIMARY KEY,
+    order_id      INTEGER       NOT NULL REFERENCES orders (id) ON DELETE RESTRICT,
+    order_item_id INTEGER       NULL REFERENCES order_items (id) ON DELETE RESTRICT,
+    coupon_id     INTEGER       NULL REFERENCES coupons (id) ON DELETE RESTRICT,
+    type          VARCHAR(50)   NOT NULL,
+    code          VARCHAR(64)   NOT NULL DEFAULT '',
+    description   TEXT          NOT NULL DEFAULT '',
+    amount        NUMERIC(19,4) NOT NULL DEFAULT 0,
+    created_at    TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at    TIMESTAMPTZ   NULL DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_adjustments_order_id ON order_adjustments (order_id);
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS subtotal       NUMERIC(19,4) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS discount_total NUMERIC(19,4) NOT NULL DEFAULT 0;
+
+-- Orders placed before coupons were supported had no discounts.
+UPDATE orders SET subtotal = total_price WHERE subtotal = 0;
+
+COMMIT;
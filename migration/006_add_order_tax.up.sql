@@ -0,0 +1,17 @@
+START TRANSACTION;
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS tax_total     NUMERIC(19,4) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax_region    VARCHAR(16)   NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tax_inclusive BOOLEAN       NOT NULL DEFAULT FALSE;
+
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS tax_class      VARCHAR(64)   NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tax_rate       NUMERIC(9,4)  NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS taxable_amount NUMERIC(19,4) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax_amount     NUMERIC(19,4) NOT NULL DEFAULT 0;
+
+-- Items ordered before tax was tracked were untaxed.
+UPDATE order_items SET taxable_amount = subtotal WHERE taxable_amount = 0;
+
+COMMIT;
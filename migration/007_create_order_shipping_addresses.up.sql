@@ -0,0 +1,44 @@
+START TRANSACTION;
+
+-- The region tables from 002 are only created here when they do not exist
+-- yet, e.g. on databases bootstrapped from 003 onwards.
+CREATE TABLE IF NOT EXISTS provinces (
+    id   SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS cities (
+    id          SERIAL PRIMARY KEY,
+    province_id INTEGER      NOT NULL REFERENCES provinces (id) ON DELETE RESTRICT,
+    name        VARCHAR(255) NOT NULL UNIQUE
+);
+
+CREATE INDEX IF NOT EXISTS idx_cities_province_id ON cities (province_id);
+
+CREATE TABLE IF NOT EXISTS districts (
+    id      SERIAL PRIMARY KEY,
+    city_id INTEGER      NOT NULL REFERENCES cities (id) ON DELETE RESTRICT,
+    name    VARCHAR(255) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_districts_city_id ON districts (city_id);
+
+CREATE TABLE IF NOT EXISTS order_shipping_addresses (
+    id             SERIAL PRIMARY KEY,
+    order_id       INTEGER      NOT NULL REFERENCES orders (id) ON DELETE RESTRICT,
+    recipient_name VARCHAR(255) NOT NULL DEFAULT '',
+    phone          VARCHAR(20)  NOT NULL DEFAULT '',
+    street         VARCHAR(500) NOT NULL DEFAULT '',
+    postal_code    VARCHAR(10)  NOT NULL DEFAULT '',
+    district_id    INTEGER      NOT NULL REFERENCES districts (id) ON DELETE RESTRICT,
+    created_at     TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at     TIMESTAMPTZ  NULL DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_order_shipping_addresses_order_id ON order_shipping_addresses (order_id) WHERE deleted_at IS NULL;
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS shipping_total NUMERIC(19,4) NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,40 @@
+START TRANSACTION;
+
+CREATE TABLE IF NOT EXISTS payments (
+    id                 SERIAL PRIMARY KEY,
+    order_id           INTEGER       NOT NULL REFERENCES orders (id) ON DELETE RESTRICT,
+    provider           VARCHAR(50)   NOT NULL DEFAULT '',
+    reference          VARCHAR(100)  NOT NULL,
+    provider_reference VARCHAR(255)  NOT NULL DEFAULT '',
+    status             VARCHAR(50)   NOT NULL DEFAULT 'PENDING',
+    amount             NUMERIC(19,4) NOT NULL DEFAULT 0,
+    currency           VARCHAR(3)    NOT NULL DEFAULT 'IDR',
+    checkout_url       TEXT          NOT NULL DEFAULT '',
+    failure_reason     TEXT          NOT NULL DEFAULT '',
+    paid_at            TIMESTAMPTZ   NULL DEFAULT NULL,
+    failed_at          TIMESTAMPTZ   NULL DEFAULT NULL,
+    created_at         TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at         TIMESTAMPTZ   NULL DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payments_provider_reference ON payments (provider, reference);
+CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments (order_id);
+
+-- Every verified callback is stored once; a redelivered event hits the unique
+-- index and is ignored.
+CREATE TABLE IF NOT EXISTS payment_events (
+    id         SERIAL PRIMARY KEY,
+    provider   VARCHAR(50)  NOT NULL DEFAULT '',
+    event_id   VARCHAR(255) NOT NULL,
+    type       VARCHAR(100) NOT NULL DEFAULT '',
+    payment_id INTEGER      NULL REFERENCES payments (id) ON DELETE SET NULL,
+    payload    JSONB        NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ  NULL DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payment_events_provider_event_id ON payment_events (provider, event_id);
+
+COMMIT;
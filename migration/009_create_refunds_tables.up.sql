@@ -0,0 +1,41 @@
+START TRANSACTION;
+
+CREATE TABLE IF NOT EXISTS refunds (
+    id                 SERIAL PRIMARY KEY,
+    order_id           INTEGER       NOT NULL REFERENCES orders (id) ON DELETE RESTRICT,
+    payment_id         INTEGER       NOT NULL REFERENCES payments (id) ON DELETE RESTRICT,
+    reference          VARCHAR(100)  NOT NULL,
+    provider_reference VARCHAR(255)  NOT NULL DEFAULT '',
+    status             VARCHAR(50)   NOT NULL DEFAULT 'REQUESTED',
+    amount             NUMERIC(19,4) NOT NULL DEFAULT 0,
+    currency           VARCHAR(3)    NOT NULL DEFAULT 'IDR',
+    reason             VARCHAR(255)  NOT NULL DEFAULT '',
+    attempts           INTEGER       NOT NULL DEFAULT 0,
+    last_error         TEXT          NOT NULL DEFAULT '',
+    refunded_at        TIMESTAMPTZ   NULL DEFAULT NULL,
+    failed_at          TIMESTAMPTZ   NULL DEFAULT NULL,
+    created_at         TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at         TIMESTAMPTZ   NULL DEFAULT NULL,
+    CONSTRAINT chk_refunds_amount CHECK (amount > 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_refunds_reference ON refunds (reference);
+CREATE INDEX IF NOT EXISTS idx_refunds_order_id ON refunds (order_id);
+CREATE INDEX IF NOT EXISTS idx_refunds_payment_id ON refunds (payment_id);
+
+CREATE TABLE IF NOT EXISTS refund_items (
+    id            SERIAL PRIMARY KEY,
+    refund_id     INTEGER       NOT NULL REFERENCES refunds (id) ON DELETE CASCADE,
+    order_item_id INTEGER       NOT NULL REFERENCES order_items (id) ON DELETE RESTRICT,
+    quantity      INTEGER       NOT NULL DEFAULT 0,
+    amount        NUMERIC(19,4) NOT NULL DEFAULT 0,
+    created_at    TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at    TIMESTAMPTZ   NULL DEFAULT NULL,
+    CONSTRAINT chk_refund_items_quantity CHECK (quantity > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_refund_items_refund_id ON refund_items (refund_id);
+
+COMMIT;
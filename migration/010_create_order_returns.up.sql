@@ -0,0 +1,30 @@
+START TRANSACTION;
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMPTZ NULL DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS order_returns (
+    id               SERIAL PRIMARY KEY,
+    order_id         INTEGER       NOT NULL REFERENCES orders (id) ON DELETE RESTRICT,
+    order_item_id    INTEGER       NOT NULL REFERENCES order_items (id) ON DELETE RESTRICT,
+    quantity         INTEGER       NOT NULL DEFAULT 0,
+    reason_code      VARCHAR(50)   NOT NULL DEFAULT 'OTHER',
+    note             TEXT          NOT NULL DEFAULT '',
+    status           VARCHAR(50)   NOT NULL DEFAULT 'REQUESTED',
+    rejection_reason VARCHAR(255)  NOT NULL DEFAULT '',
+    refund_id        INTEGER       NULL REFERENCES refunds (id) ON DELETE SET NULL,
+    refund_amount    NUMERIC(19,4) NOT NULL DEFAULT 0,
+    approved_at      TIMESTAMPTZ   NULL DEFAULT NULL,
+    rejected_at      TIMESTAMPTZ   NULL DEFAULT NULL,
+    received_at      TIMESTAMPTZ   NULL DEFAULT NULL,
+    restocked_at     TIMESTAMPTZ   NULL DEFAULT NULL,
+    created_at       TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at       TIMESTAMPTZ   NULL DEFAULT NULL,
+    CONSTRAINT chk_order_returns_quantity CHECK (quantity > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_returns_order_id ON order_returns (order_id);
+CREATE INDEX IF NOT EXISTS idx_order_returns_order_item_id ON order_returns (order_item_id);
+
+COMMIT;
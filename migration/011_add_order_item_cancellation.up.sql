@@ -0,0 +1,7 @@
+START TRANSACTION;
+
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS cancelled_quantity INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS reservation_id     INTEGER NULL DEFAULT NULL;
+
+COMMIT;
@@ -0,0 +1,10 @@
+START TRANSACTION;
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS cancellation_reason VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS cancelled_at        TIMESTAMPTZ NULL DEFAULT NULL;
+
+-- The expiry sweeper looks for old orders that are still awaiting payment.
+CREATE INDEX IF NOT EXISTS idx_orders_status_created_at ON orders (status, created_at);
+
+COMMIT;
@@ -0,0 +1,23 @@
+START TRANSACTION;
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id           SERIAL PRIMARY KEY,
+    type         VARCHAR(100) NOT NULL,
+    payload      JSONB        NOT NULL DEFAULT '{}',
+    status       VARCHAR(50)  NOT NULL DEFAULT 'QUEUED',
+    attempts     INTEGER      NOT NULL DEFAULT 0,
+    max_attempts INTEGER      NOT NULL DEFAULT 0,
+    run_at       TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_at    TIMESTAMPTZ  NULL DEFAULT NULL,
+    last_error   TEXT         NOT NULL DEFAULT '',
+    completed_at TIMESTAMPTZ  NULL DEFAULT NULL,
+    failed_at    TIMESTAMPTZ  NULL DEFAULT NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Workers poll for due jobs by status and run_at.
+CREATE INDEX IF NOT EXISTS idx_jobs_status_run_at ON jobs (status, run_at);
+CREATE INDEX IF NOT EXISTS idx_jobs_type ON jobs (type);
+
+COMMIT;
@@ -0,0 +1,40 @@
+START TRANSACTION;
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id                   SERIAL PRIMARY KEY,
+    url                  VARCHAR(2048) NOT NULL,
+    secret               VARCHAR(255)  NOT NULL,
+    event_types          TEXT[]        NOT NULL DEFAULT '{}',
+    is_active            BOOLEAN       NOT NULL DEFAULT TRUE,
+    consecutive_failures INTEGER       NOT NULL DEFAULT 0,
+    disabled_at          TIMESTAMPTZ   NULL DEFAULT NULL,
+    disabled_reason      VARCHAR(255)  NOT NULL DEFAULT '',
+    created_at           TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at           TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at           TIMESTAMPTZ   NULL DEFAULT NULL
+);
+
+-- Publishing an event looks up the active subscriptions to its type.
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_event_types ON webhook_subscriptions USING GIN (event_types);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              SERIAL PRIMARY KEY,
+    subscription_id INTEGER      NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id        VARCHAR(36)  NOT NULL,
+    event_type      VARCHAR(100) NOT NULL,
+    payload         JSONB        NOT NULL DEFAULT '{}',
+    status          VARCHAR(50)  NOT NULL DEFAULT 'PENDING',
+    attempts        INTEGER      NOT NULL DEFAULT 0,
+    response_status INTEGER      NOT NULL DEFAULT 0,
+    response_body   TEXT         NOT NULL DEFAULT '',
+    last_error      TEXT         NOT NULL DEFAULT '',
+    delivered_at    TIMESTAMPTZ  NULL DEFAULT NULL,
+    failed_at       TIMESTAMPTZ  NULL DEFAULT NULL,
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at      TIMESTAMPTZ  NULL DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id, id);
+
+COMMIT;
@@ -0,0 +1,44 @@
+START TRANSACTION;
+
+-- Every status an order takes is recorded here and announced on the
+-- order_status_events channel, so each replica can push it to the order
+-- owner's streams. Rows are kept briefly for clients resuming a stream.
+CREATE TABLE IF NOT EXISTS order_status_events (
+    id         BIGSERIAL PRIMARY KEY,
+    order_id   INTEGER     NOT NULL,
+    user_id    INTEGER     NOT NULL,
+    status     VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_events_user_id ON order_status_events (user_id, id);
+CREATE INDEX IF NOT EXISTS idx_order_status_events_created_at ON order_status_events (created_at);
+
+-- NOTIFY is sent on commit, so rolled back changes are never streamed.
+CREATE OR REPLACE FUNCTION record_order_status_event() RETURNS TRIGGER AS $$
+DECLARE
+    event order_status_events;
+BEGIN
+    INSERT INTO order_status_events (order_id, user_id, status)
+    VALUES (NEW.id, NEW.user_id, NEW.status)
+    RETURNING * INTO event;
+
+    PERFORM pg_notify('order_status_events', row_to_json(event)::TEXT);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_orders_status_created ON orders;
+CREATE TRIGGER trg_orders_status_created
+    AFTER INSERT ON orders
+    FOR EACH ROW EXECUTE FUNCTION record_order_status_event();
+
+DROP TRIGGER IF EXISTS trg_orders_status_changed ON orders;
+CREATE TRIGGER trg_orders_status_changed
+    AFTER UPDATE OF status ON orders
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status)
+    EXECUTE FUNCTION record_order_status_event();
+
+COMMIT;
@@ -0,0 +1,23 @@
+START TRANSACTION;
+
+-- audit_log is append-only: entries are written in the transaction of the
+-- change they describe and only removed by the retention purge.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id          BIGSERIAL PRIMARY KEY,
+    actor_type  VARCHAR(50)  NOT NULL,
+    actor_id    VARCHAR(255) NOT NULL DEFAULT '',
+    request_id  VARCHAR(255) NOT NULL DEFAULT '',
+    ip          VARCHAR(64)  NOT NULL DEFAULT '',
+    user_agent  TEXT         NOT NULL DEFAULT '',
+    action      VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(100) NOT NULL,
+    entity_id   INTEGER      NOT NULL,
+    changes     JSONB        NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity_type, entity_id, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log (actor_type, actor_id, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log (created_at);
+
+COMMIT;
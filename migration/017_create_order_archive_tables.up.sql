@@ -0,0 +1,21 @@
+START TRANSACTION;
+
+-- Closed orders are moved here, with every row that belongs to them, by the
+-- archive command. Each archive table is a copy of its live table without
+-- foreign keys, so rows move between the two with INSERT ... SELECT *; a
+-- column added to a live table must be added to its archive table as well.
+CREATE TABLE IF NOT EXISTS orders_archive (LIKE orders INCLUDING ALL);
+CREATE TABLE IF NOT EXISTS order_items_archive (LIKE order_items INCLUDING ALL);
+CREATE TABLE IF NOT EXISTS order_shipping_addresses_archive (LIKE order_shipping_addresses INCLUDING ALL);
+CREATE TABLE IF NOT EXISTS order_adjustments_archive (LIKE order_adjustments INCLUDING ALL);
+CREATE TABLE IF NOT EXISTS payments_archive (LIKE payments INCLUDING ALL);
+CREATE TABLE IF NOT EXISTS payment_events_archive (LIKE payment_events INCLUDING ALL);
+CREATE TABLE IF NOT EXISTS refunds_archive (LIKE refunds INCLUDING ALL);
+CREATE TABLE IF NOT EXISTS refund_items_archive (LIKE refund_items INCLUDING ALL);
+CREATE TABLE IF NOT EXISTS order_returns_archive (LIKE order_returns INCLUDING ALL);
+
+-- Coupon usages stay live, as per-user coupon limits count them, so they may
+-- outlive their order.
+ALTER TABLE coupon_usages DROP CONSTRAINT IF EXISTS coupon_usages_order_id_fkey;
+
+COMMIT;
@@ -0,0 +1,100 @@
+START TRANSACTION;
+
+-- orders and order_items are range partitioned by created_at, one partition
+-- per calendar month (UTC), named <table>_pYYYY_MM. Partitions are created
+-- ahead of time and expired by the partition maintainer; rows outside every
+-- monthly partition land in <table>_default.
+--
+-- A primary key of a partitioned table must include the partition key, so
+-- ids are only unique by way of their sequences and no foreign key can
+-- reference orders or order_items any more. The references to them are kept
+-- by the service, which only writes them in the transaction that finds the
+-- order.
+
+CREATE OR REPLACE FUNCTION create_monthly_partition(parent TEXT, month DATE) RETURNS BOOLEAN AS $$
+DECLARE
+    partition_name TEXT := parent || '_p' || to_char(month, 'YYYY_MM');
+    lower_bound TIMESTAMPTZ := date_trunc('month', month::TIMESTAMP) AT TIME ZONE 'UTC';
+    upper_bound TIMESTAMPTZ := (date_trunc('month', month::TIMESTAMP) + INTERVAL '1 month') AT TIME ZONE 'UTC';
+BEGIN
+    IF to_regclass(partition_name) IS NOT NULL THEN
+        RETURN FALSE;
+    END IF;
+
+    EXECUTE format('CREATE TABLE %I PARTITION OF %I FOR VALUES FROM (%L) TO (%L)', partition_name, parent, lower_bound, upper_bound);
+
+    RETURN TRUE;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS order_items_order_id_fkey;
+ALTER TABLE order_adjustments DROP CONSTRAINT IF EXISTS order_adjustments_order_id_fkey;
+ALTER TABLE order_adjustments DROP CONSTRAINT IF EXISTS order_adjustments_order_item_id_fkey;
+ALTER TABLE order_shipping_addresses DROP CONSTRAINT IF EXISTS order_shipping_addresses_order_id_fkey;
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_order_id_fkey;
+ALTER TABLE refunds DROP CONSTRAINT IF EXISTS refunds_order_id_fkey;
+ALTER TABLE refund_items DROP CONSTRAINT IF EXISTS refund_items_order_item_id_fkey;
+ALTER TABLE order_returns DROP CONSTRAINT IF EXISTS order_returns_order_id_fkey;
+ALTER TABLE order_returns DROP CONSTRAINT IF EXISTS order_returns_order_item_id_fkey;
+
+-- The partitioned tables are created like the ones they replace, so their
+-- columns keep their order and the archive tables still match them.
+ALTER TABLE orders RENAME TO orders_unpartitioned;
+ALTER TABLE orders_unpartitioned RENAME CONSTRAINT orders_pkey TO orders_unpartitioned_pkey;
+ALTER TABLE order_items RENAME TO order_items_unpartitioned;
+ALTER TABLE order_items_unpartitioned RENAME CONSTRAINT order_items_pkey TO order_items_unpartitioned_pkey;
+
+CREATE TABLE orders (LIKE orders_unpartitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING GENERATED)
+    PARTITION BY RANGE (created_at);
+ALTER TABLE orders ADD PRIMARY KEY (id, created_at);
+ALTER SEQUENCE orders_id_seq OWNED BY orders.id;
+
+CREATE TABLE order_items (LIKE order_items_unpartitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING GENERATED)
+    PARTITION BY RANGE (created_at);
+ALTER TABLE order_items ADD PRIMARY KEY (id, created_at);
+ALTER SEQUENCE order_items_id_seq OWNED BY order_items.id;
+
+CREATE TABLE orders_default PARTITION OF orders DEFAULT;
+CREATE TABLE order_items_default PARTITION OF order_items DEFAULT;
+
+-- Every month with orders, up to three months ahead.
+DO $$
+DECLARE
+    first_month DATE;
+    m DATE;
+BEGIN
+    SELECT date_trunc('month', least(min(created_at), now()) AT TIME ZONE 'UTC')::DATE
+    INTO first_month
+    FROM (SELECT created_at FROM orders_unpartitioned UNION ALL SELECT created_at FROM order_items_unpartitioned) AS t;
+
+    FOR m IN SELECT generate_series(first_month, (now() AT TIME ZONE 'UTC')::DATE + INTERVAL '3 months', INTERVAL '1 month')::DATE LOOP
+        PERFORM create_monthly_partition('orders', m);
+        PERFORM create_monthly_partition('order_items', m);
+    END LOOP;
+END;
+$$;
+
+INSERT INTO orders SELECT * FROM orders_unpartitioned;
+INSERT INTO order_items SELECT * FROM order_items_unpartitioned;
+
+DROP TABLE orders_unpartitioned;
+DROP TABLE order_items_unpartitioned;
+
+CREATE INDEX IF NOT EXISTS idx_orders_user_id ON orders (user_id);
+CREATE INDEX IF NOT EXISTS idx_orders_status ON orders (status);
+CREATE INDEX IF NOT EXISTS idx_orders_status_created_at ON orders (status, created_at);
+CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items (order_id);
+
+-- The status triggers went with the old table. They are created after the
+-- copy, so existing orders are not announced again.
+CREATE TRIGGER trg_orders_status_created
+    AFTER INSERT ON orders
+    FOR EACH ROW EXECUTE FUNCTION record_order_status_event();
+
+CREATE TRIGGER trg_orders_status_changed
+    AFTER UPDATE OF status ON orders
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status)
+    EXECUTE FUNCTION record_order_status_event();
+
+COMMIT;
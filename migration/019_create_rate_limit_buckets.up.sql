@@ -0,0 +1,16 @@
+START TRANSACTION;
+
+-- rate_limit_buckets holds a token bucket per client and route, shared by
+-- every replica when rate limits are kept in Postgres. Buckets are cheap to
+-- lose, so the table is not written to the WAL; after a crash every client
+-- simply starts with a full bucket.
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_buckets (
+    key        VARCHAR(255)     PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    allowed    BOOLEAN          NOT NULL,
+    updated_at TIMESTAMPTZ      NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON rate_limit_buckets (updated_at);
+
+COMMIT;
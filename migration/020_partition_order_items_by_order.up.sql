@@ -0,0 +1,79 @@
+START TRANSACTION;
+
+-- order_items were partitioned by their own created_at, so an item added to
+-- an order in a later month landed in a later partition than its order and
+-- outlived it when the order's partition expired. Items now carry the
+-- created_at of their order as order_created_at and are partitioned by it,
+-- so the partitions of both tables for a month hold the same orders.
+
+ALTER TABLE order_items ADD COLUMN order_created_at TIMESTAMPTZ NULL;
+ALTER TABLE order_items_archive ADD COLUMN order_created_at TIMESTAMPTZ NULL;
+
+UPDATE order_items SET order_created_at = orders.created_at
+FROM orders
+WHERE orders.id = order_items.order_id;
+
+UPDATE order_items_archive SET order_created_at = orders_archive.created_at
+FROM orders_archive
+WHERE orders_archive.id = order_items_archive.order_id;
+
+-- Items whose order is gone keep their own created_at.
+UPDATE order_items SET order_created_at = created_at WHERE order_created_at IS NULL;
+UPDATE order_items_archive SET order_created_at = created_at WHERE order_created_at IS NULL;
+
+ALTER TABLE order_items ALTER COLUMN order_created_at SET NOT NULL;
+ALTER TABLE order_items_archive ALTER COLUMN order_created_at SET NOT NULL;
+
+ALTER TABLE order_items RENAME TO order_items_by_created_at;
+ALTER TABLE order_items_by_created_at RENAME CONSTRAINT order_items_pkey TO order_items_by_created_at_pkey;
+ALTER TABLE order_items_default RENAME TO order_items_by_created_at_default;
+
+-- The old monthly partitions are renamed out of the way, so
+-- create_monthly_partition creates the new ones under their names.
+DO $$
+DECLARE
+    p RECORD;
+BEGIN
+    FOR p IN
+        SELECT c.relname
+        FROM pg_inherits AS i
+        JOIN pg_class AS c ON c.oid = i.inhrelid
+        WHERE i.inhparent = 'order_items_by_created_at'::regclass
+          AND c.relname LIKE 'order\_items\_p%'
+    LOOP
+        EXECUTE format('ALTER TABLE %I RENAME TO %I', p.relname, 'old_' || p.relname);
+    END LOOP;
+END;
+$$;
+
+CREATE TABLE order_items (LIKE order_items_by_created_at INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING GENERATED)
+    PARTITION BY RANGE (order_created_at);
+ALTER TABLE order_items ADD PRIMARY KEY (id, order_created_at);
+ALTER SEQUENCE order_items_id_seq OWNED BY order_items.id;
+
+CREATE TABLE order_items_default PARTITION OF order_items DEFAULT;
+
+-- The same months as orders.
+DO $$
+DECLARE
+    p RECORD;
+BEGIN
+    FOR p IN
+        SELECT c.relname
+        FROM pg_inherits AS i
+        JOIN pg_class AS c ON c.oid = i.inhrelid
+        WHERE i.inhparent = 'orders'::regclass
+          AND c.relname LIKE 'orders\_p%'
+    LOOP
+        PERFORM create_monthly_partition('order_items', to_date(substring(p.relname FROM 'orders_p(.*)$'), 'YYYY_MM'));
+    END LOOP;
+END;
+$$;
+
+INSERT INTO order_items SELECT * FROM order_items_by_created_at;
+
+DROP TABLE order_items_by_created_at;
+
+CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items (order_id);
+
+COMMIT;
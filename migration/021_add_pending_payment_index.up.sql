@@ -0,0 +1,22 @@
+START TRANSACTION;
+
+-- An order has at most one pending payment attempt. Starting a payment
+-- checked for one before creating another, which two concurrent requests
+-- could both pass; the index makes the second one fail instead. Attempts
+-- that already raced are failed, keeping the latest.
+UPDATE payments SET
+    status         = 'FAILED',
+    failure_reason = 'superseded by a later attempt',
+    failed_at      = CURRENT_TIMESTAMP,
+    updated_at     = CURRENT_TIMESTAMP
+WHERE status = 'PENDING'
+  AND EXISTS (
+    SELECT 1 FROM payments AS later
+    WHERE later.order_id = payments.order_id
+      AND later.status = 'PENDING'
+      AND later.id > payments.id
+  );
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payments_pending_order_id ON payments (order_id) WHERE status = 'PENDING';
+
+COMMIT;
@@ -8,6 +8,7 @@ import (
 	"context"
 	"order-service/internal/adapter/repository/postgres"
 	"order-service/internal/domain/entity"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -107,6 +108,74 @@ func (_c *MockOrderRepository_Create_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// CreateAdjustment provides a mock function for the type MockOrderRepository
+func (_mock *MockOrderRepository) CreateAdjustment(ctx context.Context, adjustment *entity.OrderAdjustment) (*entity.OrderAdjustment, error) {
+	ret := _mock.Called(ctx, adjustment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAdjustment")
+	}
+
+	var r0 *entity.OrderAdjustment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.OrderAdjustment) (*entity.OrderAdjustment, error)); ok {
+		return returnFunc(ctx, adjustment)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.OrderAdjustment) *entity.OrderAdjustment); ok {
+		r0 = returnFunc(ctx, adjustment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.OrderAdjustment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entity.OrderAdjustment) error); ok {
+		r1 = returnFunc(ctx, adjustment)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderRepository_CreateAdjustment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAdjustment'
+type MockOrderRepository_CreateAdjustment_Call struct {
+	*mock.Call
+}
+
+// CreateAdjustment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - adjustment *entity.OrderAdjustment
+func (_e *MockOrderRepository_Expecter) CreateAdjustment(ctx interface{}, adjustment interface{}) *MockOrderRepository_CreateAdjustment_Call {
+	return &MockOrderRepository_CreateAdjustment_Call{Call: _e.mock.On("CreateAdjustment", ctx, adjustment)}
+}
+
+func (_c *MockOrderRepository_CreateAdjustment_Call) Run(run func(ctx context.Context, adjustment *entity.OrderAdjustment)) *MockOrderRepository_CreateAdjustment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.OrderAdjustment
+		if args[1] != nil {
+			arg1 = args[1].(*entity.OrderAdjustment)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderRepository_CreateAdjustment_Call) Return(orderAdjustment *entity.OrderAdjustment, err error) *MockOrderRepository_CreateAdjustment_Call {
+	_c.Call.Return(orderAdjustment, err)
+	return _c
+}
+
+func (_c *MockOrderRepository_CreateAdjustment_Call) RunAndReturn(run func(ctx context.Context, adjustment *entity.OrderAdjustment) (*entity.OrderAdjustment, error)) *MockOrderRepository_CreateAdjustment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function for the type MockOrderRepository
 func (_mock *MockOrderRepository) Delete(ctx context.Context, id uint32) error {
 	ret := _mock.Called(ctx, id)
@@ -306,105 +375,244 @@ func (_c *MockOrderRepository_FindByID_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
-// Update provides a mock function for the type MockOrderRepository
-func (_mock *MockOrderRepository) Update(ctx context.Context, order *entity.Order) (*entity.Order, error) {
-	ret := _mock.Called(ctx, order)
+// FindByKey provides a mock function for the type MockOrderRepository
+func (_mock *MockOrderRepository) FindByKey(ctx context.Context, id uint32, createdAt time.Time) (*entity.Order, error) {
+	ret := _mock.Called(ctx, id, createdAt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for FindByKey")
 	}
 
 	var r0 *entity.Order
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Order) (*entity.Order, error)); ok {
-		return returnFunc(ctx, order)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32, time.Time) (*entity.Order, error)); ok {
+		return returnFunc(ctx, id, createdAt)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Order) *entity.Order); ok {
-		r0 = returnFunc(ctx, order)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32, time.Time) *entity.Order); ok {
+		r0 = returnFunc(ctx, id, createdAt)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Order)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *entity.Order) error); ok {
-		r1 = returnFunc(ctx, order)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint32, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, createdAt)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockOrderRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockOrderRepository_Update_Call struct {
+// MockOrderRepository_FindByKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByKey'
+type MockOrderRepository_FindByKey_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// FindByKey is a helper method to define mock.On call
 //   - ctx context.Context
-//   - order *entity.Order
-func (_e *MockOrderRepository_Expecter) Update(ctx interface{}, order interface{}) *MockOrderRepository_Update_Call {
-	return &MockOrderRepository_Update_Call{Call: _e.mock.On("Update", ctx, order)}
+//   - id uint32
+//   - createdAt time.Time
+func (_e *MockOrderRepository_Expecter) FindByKey(ctx interface{}, id interface{}, createdAt interface{}) *MockOrderRepository_FindByKey_Call {
+	return &MockOrderRepository_FindByKey_Call{Call: _e.mock.On("FindByKey", ctx, id, createdAt)}
 }
 
-func (_c *MockOrderRepository_Update_Call) Run(run func(ctx context.Context, order *entity.Order)) *MockOrderRepository_Update_Call {
+func (_c *MockOrderRepository_FindByKey_Call) Run(run func(ctx context.Context, id uint32, createdAt time.Time)) *MockOrderRepository_FindByKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *entity.Order
+		var arg1 uint32
 		if args[1] != nil {
-			arg1 = args[1].(*entity.Order)
+			arg1 = args[1].(uint32)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockOrderRepository_Update_Call) Return(order1 *entity.Order, err error) *MockOrderRepository_Update_Call {
-	_c.Call.Return(order1, err)
+func (_c *MockOrderRepository_FindByKey_Call) Return(order *entity.Order, err error) *MockOrderRepository_FindByKey_Call {
+	_c.Call.Return(order, err)
 	return _c
 }
 
-func (_c *MockOrderRepository_Update_Call) RunAndReturn(run func(ctx context.Context, order *entity.Order) (*entity.Order, error)) *MockOrderRepository_Update_Call {
+func (_c *MockOrderRepository_FindByKey_Call) RunAndReturn(run func(ctx context.Context, id uint32, createdAt time.Time) (*entity.Order, error)) *MockOrderRepository_FindByKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateStatus provides a mock function for the type MockOrderRepository
-func (_mock *MockOrderRepository) UpdateStatus(ctx context.Context, id uint32, status string) error {
-	ret := _mock.Called(ctx, id, status)
+// LockByID provides a mock function for the type MockOrderRepository
+func (_mock *MockOrderRepository) LockByID(ctx context.Context, id uint32) (time.Time, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateStatus")
+		panic("no return value specified for LockByID")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32) (time.Time, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32) time.Time); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderRepository_LockByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockByID'
+type MockOrderRepository_LockByID_Call struct {
+	*mock.Call
+}
+
+// LockByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint32
+func (_e *MockOrderRepository_Expecter) LockByID(ctx interface{}, id interface{}) *MockOrderRepository_LockByID_Call {
+	return &MockOrderRepository_LockByID_Call{Call: _e.mock.On("LockByID", ctx, id)}
+}
+
+func (_c *MockOrderRepository_LockByID_Call) Run(run func(ctx context.Context, id uint32)) *MockOrderRepository_LockByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint32
+		if args[1] != nil {
+			arg1 = args[1].(uint32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderRepository_LockByID_Call) Return(time1 time.Time, err error) *MockOrderRepository_LockByID_Call {
+	_c.Call.Return(time1, err)
+	return _c
+}
+
+func (_c *MockOrderRepository_LockByID_Call) RunAndReturn(run func(ctx context.Context, id uint32) (time.Time, error)) *MockOrderRepository_LockByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockOrderRepository
+func (_mock *MockOrderRepository) Restore(ctx context.Context, id uint32) (bool, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32) (bool, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32) bool); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockOrderRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint32
+func (_e *MockOrderRepository_Expecter) Restore(ctx interface{}, id interface{}) *MockOrderRepository_Restore_Call {
+	return &MockOrderRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *MockOrderRepository_Restore_Call) Run(run func(ctx context.Context, id uint32)) *MockOrderRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint32
+		if args[1] != nil {
+			arg1 = args[1].(uint32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderRepository_Restore_Call) Return(b bool, err error) *MockOrderRepository_Restore_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockOrderRepository_Restore_Call) RunAndReturn(run func(ctx context.Context, id uint32) (bool, error)) *MockOrderRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCancelled provides a mock function for the type MockOrderRepository
+func (_mock *MockOrderRepository) SetCancelled(ctx context.Context, id uint32, reason string, at time.Time) error {
+	ret := _mock.Called(ctx, id, reason, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCancelled")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32, string) error); ok {
-		r0 = returnFunc(ctx, id, status)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, id, reason, at)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockOrderRepository_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
-type MockOrderRepository_UpdateStatus_Call struct {
+// MockOrderRepository_SetCancelled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCancelled'
+type MockOrderRepository_SetCancelled_Call struct {
 	*mock.Call
 }
 
-// UpdateStatus is a helper method to define mock.On call
+// SetCancelled is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uint32
-//   - status string
-func (_e *MockOrderRepository_Expecter) UpdateStatus(ctx interface{}, id interface{}, status interface{}) *MockOrderRepository_UpdateStatus_Call {
-	return &MockOrderRepository_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", ctx, id, status)}
+//   - reason string
+//   - at time.Time
+func (_e *MockOrderRepository_Expecter) SetCancelled(ctx interface{}, id interface{}, reason interface{}, at interface{}) *MockOrderRepository_SetCancelled_Call {
+	return &MockOrderRepository_SetCancelled_Call{Call: _e.mock.On("SetCancelled", ctx, id, reason, at)}
 }
 
-func (_c *MockOrderRepository_UpdateStatus_Call) Run(run func(ctx context.Context, id uint32, status string)) *MockOrderRepository_UpdateStatus_Call {
+func (_c *MockOrderRepository_SetCancelled_Call) Run(run func(ctx context.Context, id uint32, reason string, at time.Time)) *MockOrderRepository_SetCancelled_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -418,21 +626,559 @@ func (_c *MockOrderRepository_UpdateStatus_Call) Run(run func(ctx context.Contex
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockOrderRepository_UpdateStatus_Call) Return(err error) *MockOrderRepository_UpdateStatus_Call {
+func (_c *MockOrderRepository_SetCancelled_Call) Return(err error) *MockOrderRepository_SetCancelled_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockOrderRepository_UpdateStatus_Call) RunAndReturn(run func(ctx context.Context, id uint32, status string) error) *MockOrderRepository_UpdateStatus_Call {
+func (_c *MockOrderRepository_SetCancelled_Call) RunAndReturn(run func(ctx context.Context, id uint32, reason string, at time.Time) error) *MockOrderRepository_SetCancelled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDeliveredAt provides a mock function for the type MockOrderRepository
+func (_mock *MockOrderRepository) SetDeliveredAt(ctx context.Context, id uint32, at time.Time) error {
+	ret := _mock.Called(ctx, id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDeliveredAt")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32, time.Time) error); ok {
+		r0 = returnFunc(ctx, id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOrderRepository_SetDeliveredAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDeliveredAt'
+type MockOrderRepository_SetDeliveredAt_Call struct {
+	*mock.Call
+}
+
+// SetDeliveredAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint32
+//   - at time.Time
+func (_e *MockOrderRepository_Expecter) SetDeliveredAt(ctx interface{}, id interface{}, at interface{}) *MockOrderRepository_SetDeliveredAt_Call {
+	return &MockOrderRepository_SetDeliveredAt_Call{Call: _e.mock.On("SetDeliveredAt", ctx, id, at)}
+}
+
+func (_c *MockOrderRepository_SetDeliveredAt_Call) Run(run func(ctx context.Context, id uint32, at time.Time)) *MockOrderRepository_SetDeliveredAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint32
+		if args[1] != nil {
+			arg1 = args[1].(uint32)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderRepository_SetDeliveredAt_Call) Return(err error) *MockOrderRepository_SetDeliveredAt_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOrderRepository_SetDeliveredAt_Call) RunAndReturn(run func(ctx context.Context, id uint32, at time.Time) error) *MockOrderRepository_SetDeliveredAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SwapItemReservation provides a mock function for the type MockOrderRepository
+func (_mock *MockOrderRepository) SwapItemReservation(ctx context.Context, id uint32, from *uint32, to *uint32) (bool, error) {
+	ret := _mock.Called(ctx, id, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SwapItemReservation")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32, *uint32, *uint32) (bool, error)); ok {
+		return returnFunc(ctx, id, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32, *uint32, *uint32) bool); ok {
+		r0 = returnFunc(ctx, id, from, to)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint32, *uint32, *uint32) error); ok {
+		r1 = returnFunc(ctx, id, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderRepository_SwapItemReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SwapItemReservation'
+type MockOrderRepository_SwapItemReservation_Call struct {
+	*mock.Call
+}
+
+// SwapItemReservation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint32
+//   - from *uint32
+//   - to *uint32
+func (_e *MockOrderRepository_Expecter) SwapItemReservation(ctx interface{}, id interface{}, from interface{}, to interface{}) *MockOrderRepository_SwapItemReservation_Call {
+	return &MockOrderRepository_SwapItemReservation_Call{Call: _e.mock.On("SwapItemReservation", ctx, id, from, to)}
+}
+
+func (_c *MockOrderRepository_SwapItemReservation_Call) Run(run func(ctx context.Context, id uint32, from *uint32, to *uint32)) *MockOrderRepository_SwapItemReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint32
+		if args[1] != nil {
+			arg1 = args[1].(uint32)
+		}
+		var arg2 *uint32
+		if args[2] != nil {
+			arg2 = args[2].(*uint32)
+		}
+		var arg3 *uint32
+		if args[3] != nil {
+			arg3 = args[3].(*uint32)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderRepository_SwapItemReservation_Call) Return(b bool, err error) *MockOrderRepository_SwapItemReservation_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockOrderRepository_SwapItemReservation_Call) RunAndReturn(run func(ctx context.Context, id uint32, from *uint32, to *uint32) (bool, error)) *MockOrderRepository_SwapItemReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransitionStatus provides a mock function for the type MockOrderRepository
+func (_mock *MockOrderRepository) TransitionStatus(ctx context.Context, id uint32, createdAt time.Time, from string, to string) (bool, error) {
+	ret := _mock.Called(ctx, id, createdAt, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransitionStatus")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32, time.Time, string, string) (bool, error)); ok {
+		return returnFunc(ctx, id, createdAt, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32, time.Time, string, string) bool); ok {
+		r0 = returnFunc(ctx, id, createdAt, from, to)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint32, time.Time, string, string) error); ok {
+		r1 = returnFunc(ctx, id, createdAt, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderRepository_TransitionStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransitionStatus'
+type MockOrderRepository_TransitionStatus_Call struct {
+	*mock.Call
+}
+
+// TransitionStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint32
+//   - createdAt time.Time
+//   - from string
+//   - to string
+func (_e *MockOrderRepository_Expecter) TransitionStatus(ctx interface{}, id interface{}, createdAt interface{}, from interface{}, to interface{}) *MockOrderRepository_TransitionStatus_Call {
+	return &MockOrderRepository_TransitionStatus_Call{Call: _e.mock.On("TransitionStatus", ctx, id, createdAt, from, to)}
+}
+
+func (_c *MockOrderRepository_TransitionStatus_Call) Run(run func(ctx context.Context, id uint32, createdAt time.Time, from string, to string)) *MockOrderRepository_TransitionStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint32
+		if args[1] != nil {
+			arg1 = args[1].(uint32)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderRepository_TransitionStatus_Call) Return(b bool, err error) *MockOrderRepository_TransitionStatus_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockOrderRepository_TransitionStatus_Call) RunAndReturn(run func(ctx context.Context, id uint32, createdAt time.Time, from string, to string) (bool, error)) *MockOrderRepository_TransitionStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockOrderRepository
+func (_mock *MockOrderRepository) Update(ctx context.Context, order *entity.Order) (*entity.Order, error) {
+	ret := _mock.Called(ctx, order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *entity.Order
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Order) (*entity.Order, error)); ok {
+		return returnFunc(ctx, order)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Order) *entity.Order); ok {
+		r0 = returnFunc(ctx, order)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Order)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entity.Order) error); ok {
+		r1 = returnFunc(ctx, order)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockOrderRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - order *entity.Order
+func (_e *MockOrderRepository_Expecter) Update(ctx interface{}, order interface{}) *MockOrderRepository_Update_Call {
+	return &MockOrderRepository_Update_Call{Call: _e.mock.On("Update", ctx, order)}
+}
+
+func (_c *MockOrderRepository_Update_Call) Run(run func(ctx context.Context, order *entity.Order)) *MockOrderRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.Order
+		if args[1] != nil {
+			arg1 = args[1].(*entity.Order)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderRepository_Update_Call) Return(order1 *entity.Order, err error) *MockOrderRepository_Update_Call {
+	_c.Call.Return(order1, err)
+	return _c
+}
+
+func (_c *MockOrderRepository_Update_Call) RunAndReturn(run func(ctx context.Context, order *entity.Order) (*entity.Order, error)) *MockOrderRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateItem provides a mock function for the type MockOrderRepository
+func (_mock *MockOrderRepository) UpdateItem(ctx context.Context, item *entity.OrderItem) error {
+	ret := _mock.Called(ctx, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateItem")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.OrderItem) error); ok {
+		r0 = returnFunc(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOrderRepository_UpdateItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateItem'
+type MockOrderRepository_UpdateItem_Call struct {
+	*mock.Call
+}
+
+// UpdateItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item *entity.OrderItem
+func (_e *MockOrderRepository_Expecter) UpdateItem(ctx interface{}, item interface{}) *MockOrderRepository_UpdateItem_Call {
+	return &MockOrderRepository_UpdateItem_Call{Call: _e.mock.On("UpdateItem", ctx, item)}
+}
+
+func (_c *MockOrderRepository_UpdateItem_Call) Run(run func(ctx context.Context, item *entity.OrderItem)) *MockOrderRepository_UpdateItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.OrderItem
+		if args[1] != nil {
+			arg1 = args[1].(*entity.OrderItem)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderRepository_UpdateItem_Call) Return(err error) *MockOrderRepository_UpdateItem_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOrderRepository_UpdateItem_Call) RunAndReturn(run func(ctx context.Context, item *entity.OrderItem) error) *MockOrderRepository_UpdateItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateItems provides a mock function for the type MockOrderRepository
+func (_mock *MockOrderRepository) UpdateItems(ctx context.Context, order *entity.Order, removed []*entity.OrderItem) error {
+	ret := _mock.Called(ctx, order, removed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateItems")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Order, []*entity.OrderItem) error); ok {
+		r0 = returnFunc(ctx, order, removed)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOrderRepository_UpdateItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateItems'
+type MockOrderRepository_UpdateItems_Call struct {
+	*mock.Call
+}
+
+// UpdateItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - order *entity.Order
+//   - removed []*entity.OrderItem
+func (_e *MockOrderRepository_Expecter) UpdateItems(ctx interface{}, order interface{}, removed interface{}) *MockOrderRepository_UpdateItems_Call {
+	return &MockOrderRepository_UpdateItems_Call{Call: _e.mock.On("UpdateItems", ctx, order, removed)}
+}
+
+func (_c *MockOrderRepository_UpdateItems_Call) Run(run func(ctx context.Context, order *entity.Order, removed []*entity.OrderItem)) *MockOrderRepository_UpdateItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.Order
+		if args[1] != nil {
+			arg1 = args[1].(*entity.Order)
+		}
+		var arg2 []*entity.OrderItem
+		if args[2] != nil {
+			arg2 = args[2].([]*entity.OrderItem)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderRepository_UpdateItems_Call) Return(err error) *MockOrderRepository_UpdateItems_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOrderRepository_UpdateItems_Call) RunAndReturn(run func(ctx context.Context, order *entity.Order, removed []*entity.OrderItem) error) *MockOrderRepository_UpdateItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStatus provides a mock function for the type MockOrderRepository
+func (_mock *MockOrderRepository) UpdateStatus(ctx context.Context, id uint32, status string) error {
+	ret := _mock.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32, string) error); ok {
+		r0 = returnFunc(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOrderRepository_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
+type MockOrderRepository_UpdateStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint32
+//   - status string
+func (_e *MockOrderRepository_Expecter) UpdateStatus(ctx interface{}, id interface{}, status interface{}) *MockOrderRepository_UpdateStatus_Call {
+	return &MockOrderRepository_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", ctx, id, status)}
+}
+
+func (_c *MockOrderRepository_UpdateStatus_Call) Run(run func(ctx context.Context, id uint32, status string)) *MockOrderRepository_UpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint32
+		if args[1] != nil {
+			arg1 = args[1].(uint32)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderRepository_UpdateStatus_Call) Return(err error) *MockOrderRepository_UpdateStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOrderRepository_UpdateStatus_Call) RunAndReturn(run func(ctx context.Context, id uint32, status string) error) *MockOrderRepository_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTotals provides a mock function for the type MockOrderRepository
+func (_mock *MockOrderRepository) UpdateTotals(ctx context.Context, order *entity.Order) error {
+	ret := _mock.Called(ctx, order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTotals")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Order) error); ok {
+		r0 = returnFunc(ctx, order)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOrderRepository_UpdateTotals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTotals'
+type MockOrderRepository_UpdateTotals_Call struct {
+	*mock.Call
+}
+
+// UpdateTotals is a helper method to define mock.On call
+//   - ctx context.Context
+//   - order *entity.Order
+func (_e *MockOrderRepository_Expecter) UpdateTotals(ctx interface{}, order interface{}) *MockOrderRepository_UpdateTotals_Call {
+	return &MockOrderRepository_UpdateTotals_Call{Call: _e.mock.On("UpdateTotals", ctx, order)}
+}
+
+func (_c *MockOrderRepository_UpdateTotals_Call) Run(run func(ctx context.Context, order *entity.Order)) *MockOrderRepository_UpdateTotals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.Order
+		if args[1] != nil {
+			arg1 = args[1].(*entity.Order)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderRepository_UpdateTotals_Call) Return(err error) *MockOrderRepository_UpdateTotals_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOrderRepository_UpdateTotals_Call) RunAndReturn(run func(ctx context.Context, order *entity.Order) error) *MockOrderRepository_UpdateTotals_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -8,6 +8,8 @@ import (
 	"context"
 	"order-service/config"
 	"order-service/internal/adapter/repository/postgres"
+	"order-service/pkg/bundb"
+	"order-service/pkg/bundb/querystats"
 
 	mock "github.com/stretchr/testify/mock"
 	"github.com/uptrace/bun"
@@ -103,6 +105,52 @@ func (_c *MockPostgresRepository_Atomic_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// Audit provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) Audit() postgresrepository.AuditRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Audit")
+	}
+
+	var r0 postgresrepository.AuditRepository
+	if returnFunc, ok := ret.Get(0).(func() postgresrepository.AuditRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(postgresrepository.AuditRepository)
+		}
+	}
+	return r0
+}
+
+// MockPostgresRepository_Audit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Audit'
+type MockPostgresRepository_Audit_Call struct {
+	*mock.Call
+}
+
+// Audit is a helper method to define mock.On call
+func (_e *MockPostgresRepository_Expecter) Audit() *MockPostgresRepository_Audit_Call {
+	return &MockPostgresRepository_Audit_Call{Call: _e.mock.On("Audit")}
+}
+
+func (_c *MockPostgresRepository_Audit_Call) Run(run func()) *MockPostgresRepository_Audit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_Audit_Call) Return(auditRepository postgresrepository.AuditRepository) *MockPostgresRepository_Audit_Call {
+	_c.Call.Return(auditRepository)
+	return _c
+}
+
+func (_c *MockPostgresRepository_Audit_Call) RunAndReturn(run func() postgresrepository.AuditRepository) *MockPostgresRepository_Audit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Close provides a mock function for the type MockPostgresRepository
 func (_mock *MockPostgresRepository) Close() error {
 	ret := _mock.Called()
@@ -147,6 +195,52 @@ func (_c *MockPostgresRepository_Close_Call) RunAndReturn(run func() error) *Moc
 	return _c
 }
 
+// Coupon provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) Coupon() postgresrepository.CouponRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Coupon")
+	}
+
+	var r0 postgresrepository.CouponRepository
+	if returnFunc, ok := ret.Get(0).(func() postgresrepository.CouponRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(postgresrepository.CouponRepository)
+		}
+	}
+	return r0
+}
+
+// MockPostgresRepository_Coupon_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Coupon'
+type MockPostgresRepository_Coupon_Call struct {
+	*mock.Call
+}
+
+// Coupon is a helper method to define mock.On call
+func (_e *MockPostgresRepository_Expecter) Coupon() *MockPostgresRepository_Coupon_Call {
+	return &MockPostgresRepository_Coupon_Call{Call: _e.mock.On("Coupon")}
+}
+
+func (_c *MockPostgresRepository_Coupon_Call) Run(run func()) *MockPostgresRepository_Coupon_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_Coupon_Call) Return(couponRepository postgresrepository.CouponRepository) *MockPostgresRepository_Coupon_Call {
+	_c.Call.Return(couponRepository)
+	return _c
+}
+
+func (_c *MockPostgresRepository_Coupon_Call) RunAndReturn(run func() postgresrepository.CouponRepository) *MockPostgresRepository_Coupon_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DB provides a mock function for the type MockPostgresRepository
 func (_mock *MockPostgresRepository) DB() *bun.DB {
 	ret := _mock.Called()
@@ -193,6 +287,161 @@ func (_c *MockPostgresRepository_DB_Call) RunAndReturn(run func() *bun.DB) *Mock
 	return _c
 }
 
+// Job provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) Job() postgresrepository.JobRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Job")
+	}
+
+	var r0 postgresrepository.JobRepository
+	if returnFunc, ok := ret.Get(0).(func() postgresrepository.JobRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(postgresrepository.JobRepository)
+		}
+	}
+	return r0
+}
+
+// MockPostgresRepository_Job_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Job'
+type MockPostgresRepository_Job_Call struct {
+	*mock.Call
+}
+
+// Job is a helper method to define mock.On call
+func (_e *MockPostgresRepository_Expecter) Job() *MockPostgresRepository_Job_Call {
+	return &MockPostgresRepository_Job_Call{Call: _e.mock.On("Job")}
+}
+
+func (_c *MockPostgresRepository_Job_Call) Run(run func()) *MockPostgresRepository_Job_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_Job_Call) Return(jobRepository postgresrepository.JobRepository) *MockPostgresRepository_Job_Call {
+	_c.Call.Return(jobRepository)
+	return _c
+}
+
+func (_c *MockPostgresRepository_Job_Call) RunAndReturn(run func() postgresrepository.JobRepository) *MockPostgresRepository_Job_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Listen provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) Listen(ctx context.Context, channel string, fn func(payload string)) error {
+	ret := _mock.Called(ctx, channel, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Listen")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, func(payload string)) error); ok {
+		r0 = returnFunc(ctx, channel, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPostgresRepository_Listen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Listen'
+type MockPostgresRepository_Listen_Call struct {
+	*mock.Call
+}
+
+// Listen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channel string
+//   - fn func(payload string)
+func (_e *MockPostgresRepository_Expecter) Listen(ctx interface{}, channel interface{}, fn interface{}) *MockPostgresRepository_Listen_Call {
+	return &MockPostgresRepository_Listen_Call{Call: _e.mock.On("Listen", ctx, channel, fn)}
+}
+
+func (_c *MockPostgresRepository_Listen_Call) Run(run func(ctx context.Context, channel string, fn func(payload string))) *MockPostgresRepository_Listen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 func(payload string)
+		if args[2] != nil {
+			arg2 = args[2].(func(payload string))
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_Listen_Call) Return(err error) *MockPostgresRepository_Listen_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPostgresRepository_Listen_Call) RunAndReturn(run func(ctx context.Context, channel string, fn func(payload string)) error) *MockPostgresRepository_Listen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Location provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) Location() postgresrepository.LocationRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Location")
+	}
+
+	var r0 postgresrepository.LocationRepository
+	if returnFunc, ok := ret.Get(0).(func() postgresrepository.LocationRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(postgresrepository.LocationRepository)
+		}
+	}
+	return r0
+}
+
+// MockPostgresRepository_Location_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Location'
+type MockPostgresRepository_Location_Call struct {
+	*mock.Call
+}
+
+// Location is a helper method to define mock.On call
+func (_e *MockPostgresRepository_Expecter) Location() *MockPostgresRepository_Location_Call {
+	return &MockPostgresRepository_Location_Call{Call: _e.mock.On("Location")}
+}
+
+func (_c *MockPostgresRepository_Location_Call) Run(run func()) *MockPostgresRepository_Location_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_Location_Call) Return(locationRepository postgresrepository.LocationRepository) *MockPostgresRepository_Location_Call {
+	_c.Call.Return(locationRepository)
+	return _c
+}
+
+func (_c *MockPostgresRepository_Location_Call) RunAndReturn(run func() postgresrepository.LocationRepository) *MockPostgresRepository_Location_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Order provides a mock function for the type MockPostgresRepository
 func (_mock *MockPostgresRepository) Order() postgresrepository.OrderRepository {
 	ret := _mock.Called()
@@ -238,3 +487,535 @@ func (_c *MockPostgresRepository_Order_Call) RunAndReturn(run func() postgresrep
 	_c.Call.Return(run)
 	return _c
 }
+
+// OrderArchive provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) OrderArchive() postgresrepository.OrderArchiveRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for OrderArchive")
+	}
+
+	var r0 postgresrepository.OrderArchiveRepository
+	if returnFunc, ok := ret.Get(0).(func() postgresrepository.OrderArchiveRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(postgresrepository.OrderArchiveRepository)
+		}
+	}
+	return r0
+}
+
+// MockPostgresRepository_OrderArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OrderArchive'
+type MockPostgresRepository_OrderArchive_Call struct {
+	*mock.Call
+}
+
+// OrderArchive is a helper method to define mock.On call
+func (_e *MockPostgresRepository_Expecter) OrderArchive() *MockPostgresRepository_OrderArchive_Call {
+	return &MockPostgresRepository_OrderArchive_Call{Call: _e.mock.On("OrderArchive")}
+}
+
+func (_c *MockPostgresRepository_OrderArchive_Call) Run(run func()) *MockPostgresRepository_OrderArchive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_OrderArchive_Call) Return(orderArchiveRepository postgresrepository.OrderArchiveRepository) *MockPostgresRepository_OrderArchive_Call {
+	_c.Call.Return(orderArchiveRepository)
+	return _c
+}
+
+func (_c *MockPostgresRepository_OrderArchive_Call) RunAndReturn(run func() postgresrepository.OrderArchiveRepository) *MockPostgresRepository_OrderArchive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OrderReturn provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) OrderReturn() postgresrepository.OrderReturnRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for OrderReturn")
+	}
+
+	var r0 postgresrepository.OrderReturnRepository
+	if returnFunc, ok := ret.Get(0).(func() postgresrepository.OrderReturnRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(postgresrepository.OrderReturnRepository)
+		}
+	}
+	return r0
+}
+
+// MockPostgresRepository_OrderReturn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OrderReturn'
+type MockPostgresRepository_OrderReturn_Call struct {
+	*mock.Call
+}
+
+// OrderReturn is a helper method to define mock.On call
+func (_e *MockPostgresRepository_Expecter) OrderReturn() *MockPostgresRepository_OrderReturn_Call {
+	return &MockPostgresRepository_OrderReturn_Call{Call: _e.mock.On("OrderReturn")}
+}
+
+func (_c *MockPostgresRepository_OrderReturn_Call) Run(run func()) *MockPostgresRepository_OrderReturn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_OrderReturn_Call) Return(orderReturnRepository postgresrepository.OrderReturnRepository) *MockPostgresRepository_OrderReturn_Call {
+	_c.Call.Return(orderReturnRepository)
+	return _c
+}
+
+func (_c *MockPostgresRepository_OrderReturn_Call) RunAndReturn(run func() postgresrepository.OrderReturnRepository) *MockPostgresRepository_OrderReturn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OrderStatusEvent provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) OrderStatusEvent() postgresrepository.OrderStatusEventRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for OrderStatusEvent")
+	}
+
+	var r0 postgresrepository.OrderStatusEventRepository
+	if returnFunc, ok := ret.Get(0).(func() postgresrepository.OrderStatusEventRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(postgresrepository.OrderStatusEventRepository)
+		}
+	}
+	return r0
+}
+
+// MockPostgresRepository_OrderStatusEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OrderStatusEvent'
+type MockPostgresRepository_OrderStatusEvent_Call struct {
+	*mock.Call
+}
+
+// OrderStatusEvent is a helper method to define mock.On call
+func (_e *MockPostgresRepository_Expecter) OrderStatusEvent() *MockPostgresRepository_OrderStatusEvent_Call {
+	return &MockPostgresRepository_OrderStatusEvent_Call{Call: _e.mock.On("OrderStatusEvent")}
+}
+
+func (_c *MockPostgresRepository_OrderStatusEvent_Call) Run(run func()) *MockPostgresRepository_OrderStatusEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_OrderStatusEvent_Call) Return(orderStatusEventRepository postgresrepository.OrderStatusEventRepository) *MockPostgresRepository_OrderStatusEvent_Call {
+	_c.Call.Return(orderStatusEventRepository)
+	return _c
+}
+
+func (_c *MockPostgresRepository_OrderStatusEvent_Call) RunAndReturn(run func() postgresrepository.OrderStatusEventRepository) *MockPostgresRepository_OrderStatusEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Partition provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) Partition() postgresrepository.PartitionRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Partition")
+	}
+
+	var r0 postgresrepository.PartitionRepository
+	if returnFunc, ok := ret.Get(0).(func() postgresrepository.PartitionRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(postgresrepository.PartitionRepository)
+		}
+	}
+	return r0
+}
+
+// MockPostgresRepository_Partition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Partition'
+type MockPostgresRepository_Partition_Call struct {
+	*mock.Call
+}
+
+// Partition is a helper method to define mock.On call
+func (_e *MockPostgresRepository_Expecter) Partition() *MockPostgresRepository_Partition_Call {
+	return &MockPostgresRepository_Partition_Call{Call: _e.mock.On("Partition")}
+}
+
+func (_c *MockPostgresRepository_Partition_Call) Run(run func()) *MockPostgresRepository_Partition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_Partition_Call) Return(partitionRepository postgresrepository.PartitionRepository) *MockPostgresRepository_Partition_Call {
+	_c.Call.Return(partitionRepository)
+	return _c
+}
+
+func (_c *MockPostgresRepository_Partition_Call) RunAndReturn(run func() postgresrepository.PartitionRepository) *MockPostgresRepository_Partition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Payment provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) Payment() postgresrepository.PaymentRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Payment")
+	}
+
+	var r0 postgresrepository.PaymentRepository
+	if returnFunc, ok := ret.Get(0).(func() postgresrepository.PaymentRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(postgresrepository.PaymentRepository)
+		}
+	}
+	return r0
+}
+
+// MockPostgresRepository_Payment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Payment'
+type MockPostgresRepository_Payment_Call struct {
+	*mock.Call
+}
+
+// Payment is a helper method to define mock.On call
+func (_e *MockPostgresRepository_Expecter) Payment() *MockPostgresRepository_Payment_Call {
+	return &MockPostgresRepository_Payment_Call{Call: _e.mock.On("Payment")}
+}
+
+func (_c *MockPostgresRepository_Payment_Call) Run(run func()) *MockPostgresRepository_Payment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_Payment_Call) Return(paymentRepository postgresrepository.PaymentRepository) *MockPostgresRepository_Payment_Call {
+	_c.Call.Return(paymentRepository)
+	return _c
+}
+
+func (_c *MockPostgresRepository_Payment_Call) RunAndReturn(run func() postgresrepository.PaymentRepository) *MockPostgresRepository_Payment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PoolStats provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) PoolStats() []bundb.PoolStats {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PoolStats")
+	}
+
+	var r0 []bundb.PoolStats
+	if returnFunc, ok := ret.Get(0).(func() []bundb.PoolStats); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bundb.PoolStats)
+		}
+	}
+	return r0
+}
+
+// MockPostgresRepository_PoolStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PoolStats'
+type MockPostgresRepository_PoolStats_Call struct {
+	*mock.Call
+}
+
+// PoolStats is a helper method to define mock.On call
+func (_e *MockPostgresRepository_Expecter) PoolStats() *MockPostgresRepository_PoolStats_Call {
+	return &MockPostgresRepository_PoolStats_Call{Call: _e.mock.On("PoolStats")}
+}
+
+func (_c *MockPostgresRepository_PoolStats_Call) Run(run func()) *MockPostgresRepository_PoolStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_PoolStats_Call) Return(poolStatss []bundb.PoolStats) *MockPostgresRepository_PoolStats_Call {
+	_c.Call.Return(poolStatss)
+	return _c
+}
+
+func (_c *MockPostgresRepository_PoolStats_Call) RunAndReturn(run func() []bundb.PoolStats) *MockPostgresRepository_PoolStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryStats provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) QueryStats() *querystats.Recorder {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryStats")
+	}
+
+	var r0 *querystats.Recorder
+	if returnFunc, ok := ret.Get(0).(func() *querystats.Recorder); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*querystats.Recorder)
+		}
+	}
+	return r0
+}
+
+// MockPostgresRepository_QueryStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryStats'
+type MockPostgresRepository_QueryStats_Call struct {
+	*mock.Call
+}
+
+// QueryStats is a helper method to define mock.On call
+func (_e *MockPostgresRepository_Expecter) QueryStats() *MockPostgresRepository_QueryStats_Call {
+	return &MockPostgresRepository_QueryStats_Call{Call: _e.mock.On("QueryStats")}
+}
+
+func (_c *MockPostgresRepository_QueryStats_Call) Run(run func()) *MockPostgresRepository_QueryStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_QueryStats_Call) Return(recorder *querystats.Recorder) *MockPostgresRepository_QueryStats_Call {
+	_c.Call.Return(recorder)
+	return _c
+}
+
+func (_c *MockPostgresRepository_QueryStats_Call) RunAndReturn(run func() *querystats.Recorder) *MockPostgresRepository_QueryStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RateLimit provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) RateLimit() postgresrepository.RateLimitRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RateLimit")
+	}
+
+	var r0 postgresrepository.RateLimitRepository
+	if returnFunc, ok := ret.Get(0).(func() postgresrepository.RateLimitRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(postgresrepository.RateLimitRepository)
+		}
+	}
+	return r0
+}
+
+// MockPostgresRepository_RateLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RateLimit'
+type MockPostgresRepository_RateLimit_Call struct {
+	*mock.Call
+}
+
+// RateLimit is a helper method to define mock.On call
+func (_e *MockPostgresRepository_Expecter) RateLimit() *MockPostgresRepository_RateLimit_Call {
+	return &MockPostgresRepository_RateLimit_Call{Call: _e.mock.On("RateLimit")}
+}
+
+func (_c *MockPostgresRepository_RateLimit_Call) Run(run func()) *MockPostgresRepository_RateLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_RateLimit_Call) Return(rateLimitRepository postgresrepository.RateLimitRepository) *MockPostgresRepository_RateLimit_Call {
+	_c.Call.Return(rateLimitRepository)
+	return _c
+}
+
+func (_c *MockPostgresRepository_RateLimit_Call) RunAndReturn(run func() postgresrepository.RateLimitRepository) *MockPostgresRepository_RateLimit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refund provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) Refund() postgresrepository.RefundRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refund")
+	}
+
+	var r0 postgresrepository.RefundRepository
+	if returnFunc, ok := ret.Get(0).(func() postgresrepository.RefundRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(postgresrepository.RefundRepository)
+		}
+	}
+	return r0
+}
+
+// MockPostgresRepository_Refund_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refund'
+type MockPostgresRepository_Refund_Call struct {
+	*mock.Call
+}
+
+// Refund is a helper method to define mock.On call
+func (_e *MockPostgresRepository_Expecter) Refund() *MockPostgresRepository_Refund_Call {
+	return &MockPostgresRepository_Refund_Call{Call: _e.mock.On("Refund")}
+}
+
+func (_c *MockPostgresRepository_Refund_Call) Run(run func()) *MockPostgresRepository_Refund_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_Refund_Call) Return(refundRepository postgresrepository.RefundRepository) *MockPostgresRepository_Refund_Call {
+	_c.Call.Return(refundRepository)
+	return _c
+}
+
+func (_c *MockPostgresRepository_Refund_Call) RunAndReturn(run func() postgresrepository.RefundRepository) *MockPostgresRepository_Refund_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Webhook provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) Webhook() postgresrepository.WebhookRepository {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Webhook")
+	}
+
+	var r0 postgresrepository.WebhookRepository
+	if returnFunc, ok := ret.Get(0).(func() postgresrepository.WebhookRepository); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(postgresrepository.WebhookRepository)
+		}
+	}
+	return r0
+}
+
+// MockPostgresRepository_Webhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Webhook'
+type MockPostgresRepository_Webhook_Call struct {
+	*mock.Call
+}
+
+// Webhook is a helper method to define mock.On call
+func (_e *MockPostgresRepository_Expecter) Webhook() *MockPostgresRepository_Webhook_Call {
+	return &MockPostgresRepository_Webhook_Call{Call: _e.mock.On("Webhook")}
+}
+
+func (_c *MockPostgresRepository_Webhook_Call) Run(run func()) *MockPostgresRepository_Webhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_Webhook_Call) Return(webhookRepository postgresrepository.WebhookRepository) *MockPostgresRepository_Webhook_Call {
+	_c.Call.Return(webhookRepository)
+	return _c
+}
+
+func (_c *MockPostgresRepository_Webhook_Call) RunAndReturn(run func() postgresrepository.WebhookRepository) *MockPostgresRepository_Webhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithAdvisoryLock provides a mock function for the type MockPostgresRepository
+func (_mock *MockPostgresRepository) WithAdvisoryLock(ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error) {
+	ret := _mock.Called(ctx, key, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithAdvisoryLock")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, func(ctx context.Context) error) (bool, error)); ok {
+		return returnFunc(ctx, key, fn)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, func(ctx context.Context) error) bool); ok {
+		r0 = returnFunc(ctx, key, fn)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, func(ctx context.Context) error) error); ok {
+		r1 = returnFunc(ctx, key, fn)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPostgresRepository_WithAdvisoryLock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithAdvisoryLock'
+type MockPostgresRepository_WithAdvisoryLock_Call struct {
+	*mock.Call
+}
+
+// WithAdvisoryLock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key int64
+//   - fn func(ctx context.Context) error
+func (_e *MockPostgresRepository_Expecter) WithAdvisoryLock(ctx interface{}, key interface{}, fn interface{}) *MockPostgresRepository_WithAdvisoryLock_Call {
+	return &MockPostgresRepository_WithAdvisoryLock_Call{Call: _e.mock.On("WithAdvisoryLock", ctx, key, fn)}
+}
+
+func (_c *MockPostgresRepository_WithAdvisoryLock_Call) Run(run func(ctx context.Context, key int64, fn func(ctx context.Context) error)) *MockPostgresRepository_WithAdvisoryLock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 func(ctx context.Context) error
+		if args[2] != nil {
+			arg2 = args[2].(func(ctx context.Context) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPostgresRepository_WithAdvisoryLock_Call) Return(b bool, err error) *MockPostgresRepository_WithAdvisoryLock_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockPostgresRepository_WithAdvisoryLock_Call) RunAndReturn(run func(ctx context.Context, key int64, fn func(ctx context.Context) error) (bool, error)) *MockPostgresRepository_WithAdvisoryLock_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -7,7 +7,11 @@ import (
 	"math"
 	"order-service/config"
 	"order-service/pkg/bundb/hook"
+	"order-service/pkg/bundb/querystats"
+	"order-service/pkg/bundb/replica"
 	"order-service/pkg/logger"
+	"strconv"
+	"sync"
 	"time"
 
 	migrationFS "order-service/migration"
@@ -17,7 +21,8 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
 )
@@ -26,19 +31,27 @@ var _ BunDB = (*bunDB)(nil)
 
 type BunDB interface {
 	DB() *bun.DB
+	Replicas() *replica.Set
+	PoolStats() []PoolStats
+	QueryStats() *querystats.Recorder
 	Close() error
 	Migrate() error
 	Reset() error
 }
 
 type bunDB struct {
-	config *config.Config
-	logger logger.Logger
-	db     *bun.DB
+	config   *config.Config
+	logger   logger.Logger
+	db       *bun.DB
+	replicas *replica.Set
+	pools    []pool
+	queries  *querystats.Recorder
+	stop     context.CancelFunc
+	watchers sync.WaitGroup
 }
 
 func NewBunDB(config *config.Config, logger logger.Logger) (*bunDB, error) {
-	sqlDB, err := sql.Open("pgx", config.Postgres.DSN)
+	sqlDB, err := openDB(config.Postgres.DSN, config.Postgres)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open postgres connection: %w", err)
 	}
@@ -50,22 +63,124 @@ func NewBunDB(config *config.Config, logger logger.Logger) (*bunDB, error) {
 		return nil, fmt.Errorf("failed to ping postgres: %w", err)
 	}
 
+	queries := querystats.NewRecorder(querystats.DefaultMaxFingerprints)
+
+	db := newDB(sqlDB, config, logger, queries)
+	db.AddQueryHook(replica.NewWriteHook())
+	pools := []pool{{name: PrimaryPool, db: db}}
+
+	// Replicas that cannot be reached yet are taken out of rotation by
+	// their first health check rather than failing the start.
+	replicas := make(map[string]*bun.DB, len(config.Postgres.ReplicaDSNs))
+	for i, dsn := range config.Postgres.ReplicaDSNs {
+		replicaDB, err := openDB(dsn, config.Postgres)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open postgres replica %d connection: %w", i+1, err)
+		}
+
+		name := fmt.Sprintf("replica-%d", i+1)
+		replicas[name] = newDB(replicaDB, config, logger, queries)
+		pools = append(pools, pool{name: name, db: replicas[name]})
+	}
+
+	watchCtx, stop := context.WithCancel(context.Background())
+
+	d := &bunDB{
+		config:   config,
+		logger:   logger,
+		db:       db,
+		replicas: replica.New(db, replicas, config.Postgres.ReplicaCheckInterval, logger),
+		pools:    pools,
+		queries:  queries,
+		stop:     stop,
+	}
+
+	if threshold := config.Postgres.PoolWaitThreshold; threshold > 0 {
+		d.watchers.Add(1)
+		go func() {
+			defer d.watchers.Done()
+			d.watchPools(watchCtx, threshold)
+		}()
+	}
+
+	return d, nil
+}
+
+// openDB opens a connection pool to dsn sized by cfg. The statement and lock
+// timeouts are set as run-time parameters so they hold for every session the
+// pool opens.
+func openDB(dsn string, cfg *config.DatabaseConfig) (*sql.DB, error) {
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.StatementTimeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+
+	if cfg.LockTimeout > 0 {
+		connConfig.RuntimeParams["lock_timeout"] = strconv.FormatInt(cfg.LockTimeout.Milliseconds(), 10)
+	}
+
+	sqlDB := stdlib.OpenDB(*connConfig)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	return sqlDB, nil
+}
+
+func newDB(sqlDB *sql.DB, config *config.Config, logger logger.Logger, queries *querystats.Recorder) *bun.DB {
+	opts := []hook.LoggerOption{
+		hook.WithLogger(logger),
+		hook.WithDebug(config.App.Debug),
+		hook.WithSlowQueryThreshold(config.Postgres.SlowQueryThreshold),
+	}
+
+	if config.Postgres.ExplainSlowQueries && config.App.Debug {
+		opts = append(opts, hook.WithExplain(sqlDB))
+	}
+
+	// Hooks run after a query in reverse order, so the logger hook, added
+	// after the tracer hook, still sees the query's span to log its ID.
 	db := bun.NewDB(sqlDB, pgdialect.New())
-	db.AddQueryHook(hook.NewLoggerHook(hook.WithLogger(logger), hook.WithDebug(config.App.Debug)))
 	db.AddQueryHook(hook.NewTracerHook())
+	db.AddQueryHook(hook.NewLoggerHook(opts...))
+	db.AddQueryHook(hook.NewStatsHook(queries))
 
-	return &bunDB{
-		config: config,
-		logger: logger,
-		db:     db,
-	}, nil
+	return db
 }
 
 func (d *bunDB) DB() *bun.DB {
 	return d.db
 }
 
+// QueryStats aggregates the durations of the queries run on the primary and
+// the replicas by fingerprint.
+func (d *bunDB) QueryStats() *querystats.Recorder {
+	return d.queries
+}
+
+// Replicas routes reads to the read replicas, or to the primary when none
+// are configured.
+func (d *bunDB) Replicas() *replica.Set {
+	return d.replicas
+}
+
 func (d *bunDB) Close() error {
+	if d.stop != nil {
+		d.stop()
+		d.watchers.Wait()
+	}
+
+	if d.replicas != nil {
+		if err := d.replicas.Close(); err != nil {
+			d.logger.Error().Err(err).Msg("Failed to close postgres replica connections")
+		}
+	}
+
 	if d.db != nil {
 		return d.db.Close()
 	}
@@ -0,0 +1,20 @@
+package bundb
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const pgCodeUniqueViolation = "23505"
+
+// IsUniqueViolation reports whether err is a violation of the unique index or
+// constraint named constraint.
+func IsUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == pgCodeUniqueViolation && pgErr.ConstraintName == constraint
+}
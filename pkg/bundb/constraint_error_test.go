@@ -0,0 +1,33 @@
+package bundb_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"order-service/pkg/bundb"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "violation", err: &pgconn.PgError{Code: "23505", ConstraintName: "uq_test"}, want: true},
+		{name: "wrapped", err: fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23505", ConstraintName: "uq_test"}), want: true},
+		{name: "other constraint", err: &pgconn.PgError{Code: "23505", ConstraintName: "uq_other"}, want: false},
+		{name: "other error", err: &pgconn.PgError{Code: "40001", ConstraintName: "uq_test"}, want: false},
+		{name: "not a postgres error", err: errors.New("connection refused"), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, bundb.IsUniqueViolation(tt.err, "uq_test"))
+		})
+	}
+}
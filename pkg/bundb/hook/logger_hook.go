@@ -3,6 +3,7 @@ package hook
 import (
 	"context"
 	"database/sql"
+	"order-service/pkg/bundb/querystats"
 	"order-service/pkg/logger"
 	"strings"
 	"time"
@@ -33,10 +34,23 @@ func WithSlowQueryThreshold(threshold time.Duration) LoggerOption {
 	}
 }
 
+// WithExplain has the plans of slow SELECT queries captured with EXPLAIN
+// (ANALYZE, BUFFERS) on db and logged. ANALYZE runs the query a second time,
+// so this is meant for debugging only. The plans are captured one at a time
+// in the background, in a read-only transaction that is rolled back, and on
+// db directly so the EXPLAIN itself is not hooked.
+func WithExplain(db *sql.DB) LoggerOption {
+	return func(h *LoggerHook) {
+		h.explainDB = db
+	}
+}
+
 type LoggerHook struct {
 	logger             logger.Logger
 	debug              bool
 	slowQueryThreshold time.Duration
+	explainDB          *sql.DB
+	explaining         chan struct{}
 }
 
 func NewLoggerHook(opts ...LoggerOption) *LoggerHook {
@@ -53,6 +67,8 @@ func NewLoggerHook(opts ...LoggerOption) *LoggerHook {
 		h.slowQueryThreshold = time.Duration(100) * time.Millisecond
 	}
 
+	h.explaining = make(chan struct{}, 1)
+
 	return h
 }
 
@@ -66,28 +82,36 @@ func (h *LoggerHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
 		return
 	}
 
+	log := logger.FromContext(ctx, h.logger)
+
 	var subLogger logger.Logger
 	if event.Err != nil {
-		subLogger = h.logger.WithFields(map[string]interface{}{
+		subLogger = log.WithFields(map[string]interface{}{
 			"error": event.Err.Error(),
 		})
 	} else {
-		subLogger = h.logger
+		subLogger = log
 	}
 
-	query := event.Query
+	// The values are logged apart from the statement, as query_args, so
+	// they can be redacted.
+	statement, args := querystats.Normalize(strings.TrimSpace(event.Query))
+
+	query := statement
 	if len(query) > 500 {
 		query = query[:500] + "..."
 	}
 
 	subLogger.WithFields(map[string]interface{}{
-		"operation": event.Operation(),
-		"query":     query,
-		"duration":  duration.String(),
+		"operation":  event.Operation(),
+		"query":      query,
+		"query_args": args,
+		"duration":   duration.String(),
 	}).Info().Msg("SQL Query Executed")
 
-	if duration > h.slowQueryThreshold {
-		h.logger.Warn().Msgf("Slow query detected: %s", query)
+	slow := duration > h.slowQueryThreshold
+	if slow && h.explainDB != nil && event.Err == nil && event.Operation() == "SELECT" {
+		h.explain(ctx, log, statement, event.Query)
 	}
 
 	var logEvent logger.LogEvent
@@ -99,8 +123,8 @@ func (h *LoggerHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
 		} else {
 			logEvent = subLogger.Error().Err(event.Err)
 		}
-	case duration > h.slowQueryThreshold:
-		logEvent = subLogger.Warn()
+	case slow:
+		logEvent = subLogger.Warn().Field("slow", true)
 	default:
 		logEvent = subLogger.Debug()
 	}
@@ -108,6 +132,71 @@ func (h *LoggerHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
 	logEvent.
 		Field("component", "mysql_db").
 		Field("duration_ms", duration.Milliseconds()).
-		Field("query", strings.TrimSpace(event.Query)).
+		Field("query", statement).
+		Field("query_args", args).
 		Msgf("SQL %s", event.Operation())
 }
+
+// explainTimeout bounds how long capturing a plan may take.
+const explainTimeout = 30 * time.Second
+
+// explain logs the plan of query in the background, unless a plan is being
+// captured already.
+func (h *LoggerHook) explain(ctx context.Context, log logger.Logger, statement, query string) {
+	select {
+	case h.explaining <- struct{}{}:
+	default:
+		return
+	}
+
+	go func() {
+		defer func() { <-h.explaining }()
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), explainTimeout)
+		defer cancel()
+
+		plan, err := h.capturePlan(ctx, query)
+		if err != nil {
+			log.Warn().Err(err).Field("query", statement).Msg("Failed to capture slow query plan")
+			return
+		}
+
+		log.Warn().
+			Field("component", "mysql_db").
+			Field("query", statement).
+			Field("plan", plan).
+			Msg("SQL slow query plan")
+	}()
+}
+
+func (h *LoggerHook) capturePlan(ctx context.Context, query string) (string, error) {
+	tx, err := h.explainDB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, "EXPLAIN (ANALYZE, BUFFERS) "+query)
+	if err != nil {
+		return "", err
+	}
+
+	defer rows.Close()
+
+	var lines []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return "", err
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
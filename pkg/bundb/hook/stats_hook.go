@@ -0,0 +1,31 @@
+package hook
+
+import (
+	"context"
+	"database/sql"
+	"order-service/pkg/bundb/querystats"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/uptrace/bun"
+)
+
+var _ bun.QueryHook = (*StatsHook)(nil)
+
+// StatsHook records the duration of every query by fingerprint.
+type StatsHook struct {
+	recorder *querystats.Recorder
+}
+
+func NewStatsHook(recorder *querystats.Recorder) *StatsHook {
+	return &StatsHook{recorder: recorder}
+}
+
+func (h *StatsHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+func (h *StatsHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	failed := event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows)
+	h.recorder.Record(event.Query, time.Since(event.StartTime), failed)
+}
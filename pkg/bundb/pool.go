@@ -0,0 +1,101 @@
+package bundb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/uptrace/bun"
+	apm "go.elastic.co/apm/v2"
+)
+
+// PrimaryPool names the connection pool of the primary in PoolStats; the
+// replicas are named replica-1, replica-2 and so on.
+const PrimaryPool = "primary"
+
+// poolWatchInterval is how often the pools are sampled for slow waits.
+const poolWatchInterval = 15 * time.Second
+
+// PoolStats are the statistics of the connection pool named Name.
+type PoolStats struct {
+	Name string
+	sql.DBStats
+}
+
+type pool struct {
+	name string
+	db   *bun.DB
+}
+
+// PoolStats returns the statistics of the primary pool followed by those of
+// the replicas.
+func (d *bunDB) PoolStats() []PoolStats {
+	stats := make([]PoolStats, 0, len(d.pools))
+	for _, p := range d.pools {
+		stats = append(stats, PoolStats{Name: p.name, DBStats: p.db.Stats()})
+	}
+
+	return stats
+}
+
+// watchPools warns whenever the callers that had to wait for a connection
+// since the previous sample waited longer than threshold on average, which
+// means the pool is too small for the load or connections are held too long.
+func (d *bunDB) watchPools(ctx context.Context, threshold time.Duration) {
+	ticker := time.NewTicker(poolWatchInterval)
+	defer ticker.Stop()
+
+	prev := d.PoolStats()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cur := d.PoolStats()
+			for i, stats := range cur {
+				waits := stats.WaitCount - prev[i].WaitCount
+				if waits <= 0 {
+					continue
+				}
+
+				avg := (stats.WaitDuration - prev[i].WaitDuration) / time.Duration(waits)
+				if avg <= threshold {
+					continue
+				}
+
+				d.logger.Warn().
+					Field("pool", stats.Name).
+					Field("waits", waits).
+					Field("avg_wait", avg.String()).
+					Field("in_use", stats.InUse).
+					Field("max_open", stats.MaxOpenConnections).
+					Msgf("Waits for postgres %s connections exceed %s", stats.Name, threshold)
+			}
+
+			prev = cur
+		}
+	}
+}
+
+// PoolMetrics reports the statistics returned by stats as APM metrics
+// labelled with the pool name.
+func PoolMetrics(stats func() []PoolStats) apm.MetricsGatherer {
+	return apm.GatherMetricsFunc(func(ctx context.Context, m *apm.Metrics) error {
+		for _, s := range stats() {
+			labels := []apm.MetricLabel{{Name: "pool", Value: s.Name}}
+
+			m.Add("db.pool.max_open", labels, float64(s.MaxOpenConnections))
+			m.Add("db.pool.open", labels, float64(s.OpenConnections))
+			m.Add("db.pool.in_use", labels, float64(s.InUse))
+			m.Add("db.pool.idle", labels, float64(s.Idle))
+			m.Add("db.pool.wait_count", labels, float64(s.WaitCount))
+			m.Add("db.pool.wait_duration.ms", labels, float64(s.WaitDuration.Milliseconds()))
+			m.Add("db.pool.max_idle_closed", labels, float64(s.MaxIdleClosed))
+			m.Add("db.pool.max_idle_time_closed", labels, float64(s.MaxIdleTimeClosed))
+			m.Add("db.pool.max_lifetime_closed", labels, float64(s.MaxLifetimeClosed))
+		}
+
+		return nil
+	})
+}
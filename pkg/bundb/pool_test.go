@@ -0,0 +1,43 @@
+package bundb_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"order-service/pkg/bundb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.elastic.co/apm/v2/apmtest"
+	"go.elastic.co/apm/v2/model"
+)
+
+func TestPoolMetrics(t *testing.T) {
+	tracer := apmtest.NewRecordingTracer()
+	t.Cleanup(tracer.Close)
+
+	tracer.RegisterMetricsGatherer(bundb.PoolMetrics(func() []bundb.PoolStats {
+		return []bundb.PoolStats{
+			{Name: bundb.PrimaryPool, DBStats: sql.DBStats{MaxOpenConnections: 25, OpenConnections: 7, InUse: 5, Idle: 2, WaitCount: 3, WaitDuration: 450 * time.Millisecond}},
+			{Name: "replica-1", DBStats: sql.DBStats{MaxOpenConnections: 25, OpenConnections: 1, Idle: 1}},
+		}
+	}))
+	tracer.SendMetrics(nil)
+
+	byPool := make(map[string]map[string]model.Metric)
+	for _, m := range tracer.Payloads().Metrics {
+		for _, label := range m.Labels {
+			if label.Key == "pool" {
+				byPool[label.Value] = m.Samples
+			}
+		}
+	}
+
+	require.Contains(t, byPool, bundb.PrimaryPool)
+	require.Contains(t, byPool, "replica-1")
+	assert.Equal(t, 5.0, byPool[bundb.PrimaryPool]["db.pool.in_use"].Value)
+	assert.Equal(t, 3.0, byPool[bundb.PrimaryPool]["db.pool.wait_count"].Value)
+	assert.Equal(t, 450.0, byPool[bundb.PrimaryPool]["db.pool.wait_duration.ms"].Value)
+	assert.Equal(t, 1.0, byPool["replica-1"]["db.pool.idle"].Value)
+}
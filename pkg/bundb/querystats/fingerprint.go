@@ -0,0 +1,133 @@
+package querystats
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// valueRows matches the second and later rows of a VALUES list.
+	valueRows = regexp.MustCompile(`(?i)(VALUES\s*\([^()]*\))(?:\s*,\s*\([^()]*\))+`)
+	// valueLists matches parenthesised lists of placeholders, as in IN lists.
+	valueLists = regexp.MustCompile(`\(\s*\?(?:\s*,\s*\?)+\s*\)`)
+	// arrays matches array literals of placeholders.
+	arrays = regexp.MustCompile(`(?i)ARRAY\[\s*\?(?:\s*,\s*\?)*\s*\]`)
+)
+
+// Fingerprint normalizes query so that queries differing only in their
+// values share a fingerprint: string and numeric literals and numbered
+// placeholders become ?, lists of values collapse to (...), all rows of a
+// VALUES list but the first are dropped, and runs of whitespace become a
+// single space. Quoted identifiers are kept as they are.
+func Fingerprint(query string) string {
+	fingerprint, _ := normalize(query, false)
+
+	return fingerprint
+}
+
+// Normalize returns the fingerprint of query along with the literals and
+// placeholders it replaced, in order, so a query can be logged without its
+// values inline.
+func Normalize(query string) (string, []string) {
+	return normalize(query, true)
+}
+
+func normalize(query string, withArgs bool) (string, []string) {
+	var (
+		b    strings.Builder
+		args []string
+	)
+
+	b.Grow(len(query))
+
+	space := false
+	for i := 0; i < len(query); {
+		c := query[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = true
+			i++
+
+			continue
+		case c == '"':
+			end := closing(query, i, '"')
+			writeSpace(&b, &space)
+			b.WriteString(query[i:end])
+			i = end
+		case c == '\'':
+			end := closing(query, i, '\'')
+			writeSpace(&b, &space)
+			b.WriteByte('?')
+			args = appendArg(args, withArgs, query[i:end])
+			i = end
+		case c == '$' && i+1 < len(query) && isDigit(query[i+1]):
+			start := i
+			writeSpace(&b, &space)
+			b.WriteByte('?')
+			for i++; i < len(query) && isDigit(query[i]); i++ {
+			}
+			args = appendArg(args, withArgs, query[start:i])
+		case isDigit(c) && (i == 0 || !isWordByte(query[i-1])):
+			start := i
+			writeSpace(&b, &space)
+			b.WriteByte('?')
+			for i++; i < len(query) && (isDigit(query[i]) || query[i] == '.' || query[i] == 'e' || query[i] == 'E'); i++ {
+			}
+			args = appendArg(args, withArgs, query[start:i])
+		default:
+			writeSpace(&b, &space)
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	fingerprint := valueRows.ReplaceAllString(b.String(), "$1")
+	fingerprint = valueLists.ReplaceAllString(fingerprint, "(...)")
+	fingerprint = arrays.ReplaceAllString(fingerprint, "ARRAY[...]")
+
+	return fingerprint, args
+}
+
+func appendArg(args []string, withArgs bool, arg string) []string {
+	if !withArgs {
+		return args
+	}
+
+	return append(args, arg)
+}
+
+// closing returns the index just past the quote closing the one at start,
+// treating a doubled quote as an escaped one.
+func closing(query string, start int, quote byte) int {
+	for i := start + 1; i < len(query); i++ {
+		if query[i] != quote {
+			continue
+		}
+
+		if i+1 < len(query) && query[i+1] == quote {
+			i++
+			continue
+		}
+
+		return i + 1
+	}
+
+	return len(query)
+}
+
+func writeSpace(b *strings.Builder, space *bool) {
+	if *space && b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+
+	*space = false
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordByte(c byte) bool {
+	return isDigit(c) || c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
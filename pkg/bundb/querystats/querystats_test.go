@@ -0,0 +1,96 @@
+package querystats_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"order-service/pkg/bundb/querystats"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "literals",
+			query: `SELECT "o"."id" FROM "orders" AS "o" WHERE ("o"."user_id" = 42) AND ("o"."status" = 'it''s') LIMIT 20 OFFSET 40`,
+			want:  `SELECT "o"."id" FROM "orders" AS "o" WHERE ("o"."user_id" = ?) AND ("o"."status" = ?) LIMIT ? OFFSET ?`,
+		},
+		{
+			name:  "identifiers with digits",
+			query: `SELECT count(*) FROM orders_p2026_10 WHERE "col1" > 1.5e3`,
+			want:  `SELECT count(*) FROM orders_p2026_10 WHERE "col1" > ?`,
+		},
+		{
+			name:  "placeholders and whitespace",
+			query: "SELECT *\n\tFROM jobs   WHERE id = $1 AND queue = $12",
+			want:  "SELECT * FROM jobs WHERE id = ? AND queue = ?",
+		},
+		{
+			name:  "in lists",
+			query: `SELECT * FROM orders WHERE id IN (1, 2, 3) AND status IN ('PENDING')`,
+			want:  `SELECT * FROM orders WHERE id IN (...) AND status IN (?)`,
+		},
+		{
+			name:  "values rows",
+			query: `INSERT INTO "order_items" ("id", "order_id", "quantity") VALUES (DEFAULT, 1, 2), (DEFAULT, 1, 5) RETURNING "id"`,
+			want:  `INSERT INTO "order_items" ("id", "order_id", "quantity") VALUES (DEFAULT, ?, ?) RETURNING "id"`,
+		},
+		{
+			name:  "arrays",
+			query: `SELECT * FROM orders WHERE id = ANY(ARRAY[7, 8])`,
+			want:  `SELECT * FROM orders WHERE id = ANY(ARRAY[...])`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, querystats.Fingerprint(tt.query))
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	statement, args := querystats.Normalize(`UPDATE "payments" SET "token" = 'tok_123', "amount" = 1500.50 WHERE ("id" = $1)`)
+
+	assert.Equal(t, `UPDATE "payments" SET "token" = ?, "amount" = ? WHERE ("id" = ?)`, statement)
+	assert.Equal(t, []string{`'tok_123'`, `1500.50`, `$1`}, args)
+}
+
+func TestRecorder_Report(t *testing.T) {
+	r := querystats.NewRecorder(2)
+
+	for i := 1; i <= 100; i++ {
+		r.Record(`SELECT * FROM orders WHERE id = `+strconv.Itoa(i), time.Duration(i)*time.Millisecond, i == 100)
+	}
+	r.Record(`UPDATE orders SET status = 'CANCELLED' WHERE id = 1`, time.Second, false)
+	r.Record(`DELETE FROM jobs WHERE id = 1`, time.Millisecond, false)
+
+	report := r.Report(querystats.SortByCount, 0)
+	require.Len(t, report.Queries, 2)
+	assert.Equal(t, int64(1), report.Dropped)
+
+	selects := report.Queries[0]
+	assert.Equal(t, `SELECT * FROM orders WHERE id = ?`, selects.Fingerprint)
+	assert.Equal(t, int64(100), selects.Count)
+	assert.Equal(t, int64(1), selects.Errors)
+	assert.Equal(t, 5050*time.Millisecond, selects.Total)
+	assert.Equal(t, 50*time.Millisecond, selects.P50)
+	assert.Equal(t, 99*time.Millisecond, selects.P99)
+	assert.Equal(t, 100*time.Millisecond, selects.Max)
+
+	top := r.Report(querystats.SortByMax, 1)
+	require.Len(t, top.Queries, 1)
+	assert.Equal(t, `UPDATE orders SET status = ? WHERE id = ?`, top.Queries[0].Fingerprint)
+
+	r.Reset()
+	report = r.Report(querystats.SortByTotal, 0)
+	assert.Empty(t, report.Queries)
+	assert.Zero(t, report.Dropped)
+}
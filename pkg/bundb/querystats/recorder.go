@@ -0,0 +1,206 @@
+package querystats
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxFingerprints bounds the fingerprints a Recorder tracks.
+	DefaultMaxFingerprints = 1000
+
+	// samplesPerFingerprint is how many of the latest durations of a
+	// fingerprint its percentiles are computed from.
+	samplesPerFingerprint = 512
+)
+
+// SortKey orders the stats of a Report.
+type SortKey string
+
+const (
+	SortByTotal SortKey = "total"
+	SortByCount SortKey = "count"
+	SortByP50   SortKey = "p50"
+	SortByP99   SortKey = "p99"
+	SortByMax   SortKey = "max"
+)
+
+// Valid reports whether k is one of the sort keys.
+func (k SortKey) Valid() bool {
+	switch k {
+	case SortByTotal, SortByCount, SortByP50, SortByP99, SortByMax:
+		return true
+	default:
+		return false
+	}
+}
+
+// Stat aggregates the executions of the queries sharing a fingerprint.
+// Count, Total and Max cover every execution since the recorder was last
+// reset; P50 and P99 only the latest ones.
+type Stat struct {
+	Fingerprint string
+	Count       int64
+	Errors      int64
+	Total       time.Duration
+	P50         time.Duration
+	P99         time.Duration
+	Max         time.Duration
+	LastSeen    time.Time
+}
+
+type entry struct {
+	count    int64
+	errors   int64
+	total    time.Duration
+	max      time.Duration
+	lastSeen time.Time
+	samples  []time.Duration
+	next     int
+}
+
+// Recorder aggregates query durations by fingerprint in memory. It is safe
+// for concurrent use. Once it tracks its maximum number of fingerprints,
+// queries with new fingerprints are only counted as dropped until it is
+// reset.
+type Recorder struct {
+	mu              sync.Mutex
+	maxFingerprints int
+	entries         map[string]*entry
+	dropped         int64
+	since           time.Time
+}
+
+func NewRecorder(maxFingerprints int) *Recorder {
+	if maxFingerprints <= 0 {
+		maxFingerprints = DefaultMaxFingerprints
+	}
+
+	return &Recorder{
+		maxFingerprints: maxFingerprints,
+		entries:         make(map[string]*entry),
+		since:           time.Now(),
+	}
+}
+
+// Record adds an execution of query that took duration and failed when
+// failed is set.
+func (r *Recorder) Record(query string, duration time.Duration, failed bool) {
+	fingerprint := Fingerprint(query)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entries[fingerprint]
+	if !ok {
+		if len(r.entries) >= r.maxFingerprints {
+			r.dropped++
+			return
+		}
+
+		e = &entry{samples: make([]time.Duration, 0, 16)}
+		r.entries[fingerprint] = e
+	}
+
+	e.count++
+	e.total += duration
+	e.max = max(e.max, duration)
+	e.lastSeen = time.Now()
+
+	if failed {
+		e.errors++
+	}
+
+	if len(e.samples) < samplesPerFingerprint {
+		e.samples = append(e.samples, duration)
+	} else {
+		e.samples[e.next] = duration
+		e.next = (e.next + 1) % samplesPerFingerprint
+	}
+}
+
+// Report is a snapshot of a Recorder.
+type Report struct {
+	// Since is when the recorder started collecting.
+	Since time.Time
+	// Dropped counts the executions not recorded because their fingerprint
+	// would have exceeded the maximum.
+	Dropped int64
+	Queries []Stat
+}
+
+// Report returns the stats of at most limit fingerprints, largest first by
+// key. A limit of zero or less returns all of them.
+func (r *Recorder) Report(key SortKey, limit int) Report {
+	r.mu.Lock()
+	report := Report{
+		Since:   r.since,
+		Dropped: r.dropped,
+		Queries: make([]Stat, 0, len(r.entries)),
+	}
+	for fingerprint, e := range r.entries {
+		report.Queries = append(report.Queries, e.stat(fingerprint))
+	}
+	r.mu.Unlock()
+
+	stats := report.Queries
+	sort.Slice(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		switch key {
+		case SortByCount:
+			return a.Count > b.Count
+		case SortByP50:
+			return a.P50 > b.P50
+		case SortByP99:
+			return a.P99 > b.P99
+		case SortByMax:
+			return a.Max > b.Max
+		default:
+			return a.Total > b.Total
+		}
+	})
+
+	if limit > 0 && len(stats) > limit {
+		report.Queries = stats[:limit]
+	}
+
+	return report
+}
+
+// Reset forgets every fingerprint.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = make(map[string]*entry)
+	r.dropped = 0
+	r.since = time.Now()
+}
+
+func (e *entry) stat(fingerprint string) Stat {
+	samples := append([]time.Duration(nil), e.samples...)
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	return Stat{
+		Fingerprint: fingerprint,
+		Count:       e.count,
+		Errors:      e.errors,
+		Total:       e.total,
+		P50:         percentile(samples, 50),
+		P99:         percentile(samples, 99),
+		Max:         e.max,
+		LastSeen:    e.lastSeen,
+	}
+}
+
+// percentile returns the nearest-rank p-th percentile of the sorted samples.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := (p*len(sorted) + 99) / 100
+
+	return sorted[max(rank, 1)-1]
+}
@@ -0,0 +1,53 @@
+package replica
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"github.com/uptrace/bun"
+)
+
+var _ bun.QueryHook = (*WriteHook)(nil)
+
+type pinKey struct{}
+
+// WithReadYourWrites returns a copy of ctx whose reads go to the primary once
+// a write has been made with it, so a request reads what it wrote rather
+// than a replica that has yet to catch up.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pinKey{}, new(atomic.Bool))
+}
+
+// MarkWritten pins the reads of ctx to the primary, if ctx reads its writes.
+func MarkWritten(ctx context.Context) {
+	if pinned, ok := ctx.Value(pinKey{}).(*atomic.Bool); ok {
+		pinned.Store(true)
+	}
+}
+
+// HasWritten reports whether a write has been made with ctx since
+// WithReadYourWrites.
+func HasWritten(ctx context.Context) bool {
+	pinned, ok := ctx.Value(pinKey{}).(*atomic.Bool)
+
+	return ok && pinned.Load()
+}
+
+// WriteHook marks the context of every query on the primary other than a
+// SELECT as written.
+type WriteHook struct{}
+
+func NewWriteHook() *WriteHook {
+	return &WriteHook{}
+}
+
+func (h *WriteHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	if !strings.EqualFold(event.Operation(), "SELECT") {
+		MarkWritten(ctx)
+	}
+
+	return ctx
+}
+
+func (h *WriteHook) AfterQuery(context.Context, *bun.QueryEvent) {}
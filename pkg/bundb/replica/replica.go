@@ -0,0 +1,146 @@
+// Package replica routes reads to Postgres read replicas while they are
+// healthy, falling back to the primary.
+package replica
+
+import (
+	"context"
+	"errors"
+	"order-service/pkg/logger"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	pingTimeout          = 2 * time.Second
+	defaultCheckInterval = 5 * time.Second
+)
+
+type replica struct {
+	name    string
+	db      *bun.DB
+	healthy atomic.Bool
+}
+
+// Set is the primary and its read replicas. Replicas are checked every
+// interval and taken out of rotation while they fail, and put back once they
+// recover.
+type Set struct {
+	primary  *bun.DB
+	replicas []*replica
+	next     atomic.Uint64
+	logger   logger.Logger
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// New returns a set reading from replicas, which are keyed by a name safe to
+// log, and starts checking them every interval. Replicas start in rotation.
+func New(primary *bun.DB, replicas map[string]*bun.DB, interval time.Duration, logger logger.Logger) *Set {
+	s := &Set{
+		primary: primary,
+		logger:  logger,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	for name, db := range replicas {
+		r := &replica{name: name, db: db}
+		r.healthy.Store(true)
+		s.replicas = append(s.replicas, r)
+	}
+
+	if len(s.replicas) == 0 {
+		close(s.done)
+		return s
+	}
+
+	if interval <= 0 {
+		interval = defaultCheckInterval
+	}
+
+	go s.monitor(interval)
+
+	return s
+}
+
+// Reader returns the database reads made with ctx should go to: the next
+// healthy replica, or the primary when ctx has written or no replica is
+// healthy.
+func (s *Set) Reader(ctx context.Context) bun.IDB {
+	if len(s.replicas) == 0 || HasWritten(ctx) {
+		return s.primary
+	}
+
+	start := s.next.Add(1)
+	for i := range uint64(len(s.replicas)) {
+		r := s.replicas[(start+i)%uint64(len(s.replicas))]
+		if r.healthy.Load() {
+			return r.db
+		}
+	}
+
+	return s.primary
+}
+
+// Check pings every replica once, taking failing ones out of rotation and
+// putting recovered ones back.
+func (s *Set) Check(ctx context.Context) {
+	for _, r := range s.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+		err := r.db.PingContext(pingCtx)
+		cancel()
+
+		switch {
+		case err != nil && r.healthy.Swap(false):
+			s.logger.Warn().Err(err).Msgf("Read replica %s is unhealthy, taking it out of rotation", r.name)
+		case err == nil && !r.healthy.Swap(true):
+			s.logger.Info().Msgf("Read replica %s recovered, putting it back in rotation", r.name)
+		}
+	}
+}
+
+// Close stops checking the replicas and closes them. The primary is left
+// open.
+func (s *Set) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+
+	var errs []error
+	for _, r := range s.replicas {
+		errs = append(errs, r.db.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Set) monitor(interval time.Duration) {
+	defer close(s.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-s.stop
+		cancel()
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package replica_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"order-service/pkg/bundb/replica"
+	"order-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+// fakeConnector opens connections whose pings fail while down is set.
+type fakeConnector struct {
+	down atomic.Bool
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	if c.down.Load() {
+		return nil, errors.New("connection refused")
+	}
+
+	return &fakeConn{connector: c}, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver { return nil }
+
+type fakeConn struct {
+	connector *fakeConnector
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c *fakeConn) Ping(context.Context) error {
+	if c.connector.down.Load() {
+		return driver.ErrBadConn
+	}
+
+	return nil
+}
+
+func newFakeDB() (*bun.DB, *fakeConnector) {
+	connector := new(fakeConnector)
+
+	return bun.NewDB(sql.OpenDB(connector), pgdialect.New()), connector
+}
+
+func TestSet_Reader(t *testing.T) {
+	primary, _ := newFakeDB()
+	first, _ := newFakeDB()
+	second, secondConn := newFakeDB()
+
+	set := replica.New(primary, map[string]*bun.DB{"replica-1": first, "replica-2": second}, time.Hour, logger.NewZerologLogger(false))
+	t.Cleanup(func() { _ = set.Close() })
+
+	ctx := replica.WithReadYourWrites(context.Background())
+	set.Check(ctx)
+
+	// Reads are spread over the healthy replicas.
+	readers := map[bun.IDB]bool{set.Reader(ctx): true, set.Reader(ctx): true}
+	assert.Equal(t, map[bun.IDB]bool{first: true, second: true}, readers)
+
+	// An unhealthy replica is skipped until it recovers.
+	secondConn.down.Store(true)
+	set.Check(ctx)
+	assert.Equal(t, bun.IDB(first), set.Reader(ctx))
+	assert.Equal(t, bun.IDB(first), set.Reader(ctx))
+
+	secondConn.down.Store(false)
+	set.Check(ctx)
+	readers = map[bun.IDB]bool{set.Reader(ctx): true, set.Reader(ctx): true}
+	assert.Equal(t, map[bun.IDB]bool{first: true, second: true}, readers)
+
+	// Once the request has written, it reads from the primary.
+	replica.MarkWritten(ctx)
+	assert.Equal(t, bun.IDB(primary), set.Reader(ctx))
+	assert.NotEqual(t, bun.IDB(primary), set.Reader(context.Background()), "other requests still read from replicas")
+}
+
+func TestSet_ReaderWithoutHealthyReplicas(t *testing.T) {
+	primary, _ := newFakeDB()
+	down, downConn := newFakeDB()
+	downConn.down.Store(true)
+
+	set := replica.New(primary, map[string]*bun.DB{"replica-1": down}, time.Hour, logger.NewZerologLogger(false))
+	t.Cleanup(func() { _ = set.Close() })
+
+	set.Check(context.Background())
+
+	assert.Equal(t, bun.IDB(primary), set.Reader(context.Background()))
+}
+
+func TestSet_ReaderWithoutReplicas(t *testing.T) {
+	primary, _ := newFakeDB()
+
+	set := replica.New(primary, nil, time.Hour, logger.NewZerologLogger(false))
+	t.Cleanup(func() { _ = set.Close() })
+
+	assert.Equal(t, bun.IDB(primary), set.Reader(context.Background()))
+}
+
+func TestWriteHook(t *testing.T) {
+	hook := replica.NewWriteHook()
+
+	ctx := replica.WithReadYourWrites(context.Background())
+	hook.BeforeQuery(ctx, &bun.QueryEvent{Query: "SELECT 1"})
+	assert.False(t, replica.HasWritten(ctx))
+
+	hook.BeforeQuery(ctx, &bun.QueryEvent{Query: "UPDATE orders SET status = 'CANCELLED'"})
+	assert.True(t, replica.HasWritten(ctx))
+}
@@ -0,0 +1,23 @@
+package bundb
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	pgCodeSerializationFailure = "40001"
+	pgCodeDeadlockDetected     = "40P01"
+)
+
+// IsRetryableTxError reports whether err aborted a transaction only because
+// it conflicted with a concurrent one, so running it again may succeed.
+func IsRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == pgCodeSerializationFailure || pgErr.Code == pgCodeDeadlockDetected
+}
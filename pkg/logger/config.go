@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+	// FormatECS is JSON with the field names of the Elastic Common Schema.
+	FormatECS = "ecs"
+
+	ecsVersion = "1.6.0"
+)
+
+type Config struct {
+	// Format is FormatConsole, FormatJSON or FormatECS; JSON by default.
+	Format string
+	// Level is the lowest level logged; info by default.
+	Level string
+
+	// Out receives the logs unless File is set; stdout by default.
+	Out io.Writer
+	// File receives the logs, rotated once it grows beyond FileMaxSizeMB,
+	// when set. FileMaxBackups rotated files are kept for FileMaxAgeDays.
+	File           string
+	FileMaxSizeMB  int
+	FileMaxBackups int
+	FileMaxAgeDays int
+	FileCompress   bool
+
+	// Beyond SamplingBurst info and debug logs per SamplingPeriod, only one
+	// in SamplingEvery is kept. A zero burst keeps them all.
+	SamplingBurst  int
+	SamplingPeriod time.Duration
+	SamplingEvery  int
+
+	// RedactKeys are the keys of the fields logged redacted, ignoring case.
+	RedactKeys []string
+
+	// The service is named in every ECS log.
+	ServiceName    string
+	ServiceVersion string
+	Environment    string
+}
+
+// New returns a logger writing as configured by config. The ECS format sets
+// zerolog's field names, which are global, so it applies to every logger in
+// the process.
+func New(config *Config) (Logger, error) {
+	if config == nil {
+		config = new(Config)
+	}
+
+	level := zerolog.InfoLevel
+	if config.Level != "" {
+		var err error
+		if level, err = zerolog.ParseLevel(config.Level); err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", config.Level, err)
+		}
+	}
+
+	out := config.Out
+	if out == nil {
+		out = os.Stdout
+	}
+
+	if config.File != "" {
+		out = &lumberjack.Logger{
+			Filename:   config.File,
+			MaxSize:    config.FileMaxSizeMB,
+			MaxBackups: config.FileMaxBackups,
+			MaxAge:     config.FileMaxAgeDays,
+			Compress:   config.FileCompress,
+		}
+	}
+
+	var base zerolog.Context
+
+	switch config.Format {
+	case FormatConsole:
+		base = zerolog.New(newConsoleWriter(out)).With()
+	case FormatJSON, "":
+		base = zerolog.New(out).With()
+	case FormatECS:
+		zerolog.TimestampFieldName = "@timestamp"
+		zerolog.LevelFieldName = "log.level"
+		zerolog.ErrorFieldName = "error.message"
+		zerolog.TimeFieldFormat = time.RFC3339Nano
+
+		base = zerolog.New(out).With().
+			Str("ecs.version", ecsVersion).
+			Str("service.name", config.ServiceName).
+			Str("service.version", config.ServiceVersion).
+			Str("service.environment", config.Environment)
+	default:
+		return nil, fmt.Errorf("invalid log format %q", config.Format)
+	}
+
+	logger := base.Timestamp().Logger().Level(level)
+
+	if config.SamplingBurst > 0 {
+		sampler := &zerolog.BurstSampler{
+			Burst:       uint32(config.SamplingBurst),
+			Period:      config.SamplingPeriod,
+			NextSampler: &zerolog.BasicSampler{N: uint32(max(config.SamplingEvery, 0))},
+		}
+		logger = logger.Sample(zerolog.LevelSampler{DebugSampler: sampler, InfoSampler: sampler})
+	}
+
+	return &zerologLogger{
+		logger: logger,
+		fields: make(map[string]any),
+		debug:  level <= zerolog.DebugLevel,
+		redact: newRedactor(config.RedactKeys),
+	}, nil
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"context"
+
+	apm "go.elastic.co/apm/v2"
+)
+
+type ctxKey struct{}
+
+// WithContext returns a copy of ctx carrying logger, for FromContext to find
+// in the layers ctx is passed to.
+func WithContext(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or fallback when it carries
+// none. The IDs of the APM trace, transaction and span ctx is part of are
+// attached under their ECS names, so the log lines can be correlated with
+// the trace.
+func FromContext(ctx context.Context, fallback Logger) Logger {
+	logger, ok := ctx.Value(ctxKey{}).(Logger)
+	if !ok {
+		logger = fallback
+	}
+
+	if tx := apm.TransactionFromContext(ctx); tx != nil {
+		traceContext := tx.TraceContext()
+		logger = logger.WithFields(map[string]any{
+			"trace.id":       traceContext.Trace.String(),
+			"transaction.id": traceContext.Span.String(),
+		})
+	}
+
+	if span := apm.SpanFromContext(ctx); span != nil {
+		logger = logger.Field("span.id", span.TraceContext().Span.String())
+	}
+
+	return logger
+}
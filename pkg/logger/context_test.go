@@ -0,0 +1,49 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"order-service/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apm "go.elastic.co/apm/v2"
+	"go.elastic.co/apm/v2/apmtest"
+)
+
+func TestFromContext(t *testing.T) {
+	var fallbackOut, requestOut bytes.Buffer
+
+	fallback, err := logger.New(&logger.Config{Out: &fallbackOut})
+	require.NoError(t, err)
+
+	request, err := logger.New(&logger.Config{Out: &requestOut})
+	require.NoError(t, err)
+
+	logger.FromContext(context.Background(), fallback).Info().Msg("no request")
+	assert.Len(t, decodeLines(t, &fallbackOut), 1)
+
+	tracer := apmtest.NewRecordingTracer()
+	t.Cleanup(tracer.Close)
+
+	tx := tracer.StartTransaction("GET /api/v1/orders", "request")
+	defer tx.End()
+
+	ctx := logger.WithContext(context.Background(), request.Field("request_id", "req-1"))
+	ctx = apm.ContextWithTransaction(ctx, tx)
+
+	span, ctx := apm.StartSpan(ctx, "SQL SELECT", "db.query")
+	defer span.End()
+
+	logger.FromContext(ctx, fallback).Info().Msg("in request")
+
+	lines := decodeLines(t, &requestOut)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "req-1", lines[0]["request_id"])
+	assert.Equal(t, tx.TraceContext().Trace.String(), lines[0]["trace.id"])
+	assert.Equal(t, tx.TraceContext().Span.String(), lines[0]["transaction.id"])
+	assert.Equal(t, span.TraceContext().Span.String(), lines[0]["span.id"])
+	assert.Len(t, decodeLines(t, &fallbackOut), 1, "the fallback is not used when ctx carries a logger")
+}
@@ -0,0 +1,140 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"order-service/pkg/logger"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		lines = append(lines, entry)
+	}
+
+	return lines
+}
+
+func TestNew_JSONLevelAndRedaction(t *testing.T) {
+	var buf bytes.Buffer
+
+	log, err := logger.New(&logger.Config{
+		Format:     logger.FormatJSON,
+		Level:      "info",
+		Out:        &buf,
+		RedactKeys: []string{"authorization", "token"},
+	})
+	require.NoError(t, err)
+
+	log.Debug().Msg("hidden")
+	log.Field("Token", "abc").Info().
+		Field("authorization", "Bearer secret").
+		Field("headers", http.Header{"Authorization": {"Bearer secret"}, "Accept": {"*/*"}}).
+		Field("user_id", 7).
+		Msg("visible")
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "visible", lines[0]["message"])
+	assert.Equal(t, "info", lines[0]["level"])
+	assert.Equal(t, logger.Redacted, lines[0]["Token"])
+	assert.Equal(t, logger.Redacted, lines[0]["authorization"])
+	assert.Equal(t, map[string]any{"Authorization": logger.Redacted, "Accept": []any{"*/*"}}, lines[0]["headers"])
+	assert.Equal(t, float64(7), lines[0]["user_id"])
+}
+
+func TestNew_ECS(t *testing.T) {
+	t.Cleanup(func() {
+		zerolog.TimestampFieldName = "time"
+		zerolog.LevelFieldName = "level"
+		zerolog.ErrorFieldName = "error"
+		zerolog.TimeFieldFormat = time.RFC3339
+	})
+
+	var buf bytes.Buffer
+
+	log, err := logger.New(&logger.Config{
+		Format:         logger.FormatECS,
+		Out:            &buf,
+		ServiceName:    "order-service",
+		ServiceVersion: "1.2.3",
+		Environment:    "staging",
+	})
+	require.NoError(t, err)
+
+	log.Warn().Err(assert.AnError).Msg("something happened")
+
+	lines := decodeLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "warn", lines[0]["log.level"])
+	assert.Equal(t, "something happened", lines[0]["message"])
+	assert.Equal(t, assert.AnError.Error(), lines[0]["error.message"])
+	assert.Equal(t, "order-service", lines[0]["service.name"])
+	assert.Equal(t, "1.2.3", lines[0]["service.version"])
+	assert.Equal(t, "staging", lines[0]["service.environment"])
+	assert.NotEmpty(t, lines[0]["ecs.version"])
+	assert.NotEmpty(t, lines[0]["@timestamp"])
+}
+
+func TestNew_Sampling(t *testing.T) {
+	var buf bytes.Buffer
+
+	log, err := logger.New(&logger.Config{
+		Out:            &buf,
+		SamplingBurst:  2,
+		SamplingPeriod: time.Hour,
+		SamplingEvery:  5,
+	})
+	require.NoError(t, err)
+
+	for range 12 {
+		log.Info().Msg("busy")
+	}
+	log.Error().Msg("kept")
+
+	lines := decodeLines(t, &buf)
+	// The burst of two, then one in five of the remaining ten, and every
+	// error.
+	assert.Len(t, lines, 5)
+	assert.Equal(t, "kept", lines[len(lines)-1]["message"])
+}
+
+func TestNew_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "service.log")
+
+	log, err := logger.New(&logger.Config{File: path, FileMaxSizeMB: 1})
+	require.NoError(t, err)
+
+	log.Info().Msg("to file")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"message":"to file"`)
+}
+
+func TestNew_Invalid(t *testing.T) {
+	_, err := logger.New(&logger.Config{Level: "loud"})
+	require.Error(t, err)
+
+	_, err = logger.New(&logger.Config{Format: "xml"})
+	require.Error(t, err)
+}
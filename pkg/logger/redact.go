@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces the value of a field whose key is redacted.
+const Redacted = "[REDACTED]"
+
+// redactor redacts fields by key, ignoring case. A nil redactor redacts
+// nothing.
+type redactor struct {
+	keys map[string]struct{}
+}
+
+func newRedactor(keys []string) *redactor {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	r := &redactor{keys: make(map[string]struct{}, len(keys))}
+	for _, key := range keys {
+		r.keys[strings.ToLower(key)] = struct{}{}
+	}
+
+	return r
+}
+
+func (r *redactor) redacts(key string) bool {
+	if r == nil {
+		return false
+	}
+
+	_, ok := r.keys[strings.ToLower(key)]
+
+	return ok
+}
+
+// value returns the value to log for key. Maps of fields, such as headers,
+// have their own redacted keys replaced one level deep.
+func (r *redactor) value(key string, value any) any {
+	if r == nil {
+		return value
+	}
+
+	if r.redacts(key) {
+		return Redacted
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		return redactMap(r, v)
+	case map[string]string:
+		return redactMap(r, v)
+	case map[string][]string:
+		return redactMap(r, v)
+	case http.Header:
+		return redactMap(r, v)
+	default:
+		return value
+	}
+}
+
+func redactMap[M ~map[string]V, V any](r *redactor, m M) map[string]any {
+	redacted := make(map[string]any, len(m))
+	for k, v := range m {
+		if r.redacts(k) {
+			redacted[k] = Redacted
+		} else {
+			redacted[k] = v
+		}
+	}
+
+	return redacted
+}
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"maps"
 	"os"
 	"strings"
@@ -33,11 +34,23 @@ type zerologLogger struct {
 	debug  bool
 	logger zerolog.Logger
 	fields map[string]any
+	redact *redactor
 }
 
 func NewZerologLogger(debug bool) Logger {
-	output := zerolog.ConsoleWriter{
-		Out: os.Stdout,
+	baseLogger := zerolog.New(newConsoleWriter(os.Stdout)).With().Timestamp().Logger()
+
+	return &zerologLogger{
+		logger: baseLogger,
+		fields: make(map[string]any),
+		debug:  debug,
+	}
+}
+
+func newConsoleWriter(out io.Writer) zerolog.ConsoleWriter {
+	return zerolog.ConsoleWriter{
+		Out:     out,
+		NoColor: out != os.Stdout,
 		FormatTimestamp: func(i any) string {
 			t, ok := i.(string)
 			if !ok {
@@ -77,13 +90,6 @@ func NewZerologLogger(debug bool) Logger {
 		},
 		TimeFormat: time.RFC3339,
 	}
-	baseLogger := zerolog.New(output).With().Timestamp().Logger()
-
-	return &zerologLogger{
-		logger: baseLogger,
-		fields: make(map[string]any),
-		debug:  debug,
-	}
 }
 
 func (l *zerologLogger) NewInstance() Logger {
@@ -94,19 +100,22 @@ func (l *zerologLogger) NewInstance() Logger {
 		logger: l.logger,
 		fields: fields,
 		debug:  l.debug,
+		redact: l.redact,
 	}
 }
 
 func (l *zerologLogger) Field(key string, value any) Logger {
 	newLogger := l.NewInstance().(*zerologLogger)
-	newLogger.fields[key] = value
+	newLogger.fields[key] = l.redact.value(key, value)
 
 	return newLogger
 }
 
 func (l *zerologLogger) WithFields(fields map[string]any) Logger {
 	newLogger := l.NewInstance().(*zerologLogger)
-	maps.Copy(newLogger.fields, fields)
+	for key, value := range fields {
+		newLogger.fields[key] = l.redact.value(key, value)
+	}
 
 	return newLogger
 }
@@ -116,35 +125,36 @@ func (l *zerologLogger) Logger() Logger {
 }
 
 func (l *zerologLogger) Debug() LogEvent {
-	return newZerologEvent(l.fields, l.logger.Debug())
+	return newZerologEvent(l.fields, l.logger.Debug(), l.redact)
 }
 
 func (l *zerologLogger) Info() LogEvent {
-	return newZerologEvent(l.fields, l.logger.Info())
+	return newZerologEvent(l.fields, l.logger.Info(), l.redact)
 }
 
 func (l *zerologLogger) Warn() LogEvent {
-	return newZerologEvent(l.fields, l.logger.Warn())
+	return newZerologEvent(l.fields, l.logger.Warn(), l.redact)
 }
 
 func (l *zerologLogger) Error() LogEvent {
-	return newZerologEvent(l.fields, l.logger.Error())
+	return newZerologEvent(l.fields, l.logger.Error(), l.redact)
 }
 
 func (l *zerologLogger) Fatal() LogEvent {
-	return newZerologEvent(l.fields, l.logger.Fatal())
+	return newZerologEvent(l.fields, l.logger.Fatal(), l.redact)
 }
 
 type zerologEvent struct {
-	event *zerolog.Event
+	event  *zerolog.Event
+	redact *redactor
 }
 
-func newZerologEvent(initialFields map[string]any, event *zerolog.Event) LogEvent {
+func newZerologEvent(initialFields map[string]any, event *zerolog.Event, redact *redactor) LogEvent {
 	if len(initialFields) > 0 {
 		event.Fields(initialFields)
 	}
 
-	return &zerologEvent{event: event}
+	return &zerologEvent{event: event, redact: redact}
 }
 
 func (e *zerologEvent) Err(err error) LogEvent {
@@ -153,7 +163,7 @@ func (e *zerologEvent) Err(err error) LogEvent {
 }
 
 func (e *zerologEvent) Field(key string, value any) LogEvent {
-	e.event.Any(key, value)
+	e.event.Any(key, e.redact.value(key, value))
 	return e
 }
 
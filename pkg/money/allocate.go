@@ -0,0 +1,83 @@
+package money
+
+import "math/big"
+
+// Allocate splits m across weights in proportion to each weight, in whole
+// units of the given decimal places. Remainders go to the largest fractional
+// shares first (ties to the earliest index), so the parts always sum to m
+// rounded to places. Zero or negative weights receive nothing.
+func (m Money) Allocate(weights []Money, places int) []Money {
+	parts := make([]Money, len(weights))
+
+	var total Money
+	for _, w := range weights {
+		if w > 0 {
+			total += w
+		}
+	}
+
+	if total == 0 || len(weights) == 0 {
+		return parts
+	}
+
+	step := Money(1)
+	if places < Scale {
+		step = Money(pow10(Scale - places))
+	}
+
+	amount := m.Round(places, RoundHalfUp)
+	steps := int64(amount / step)
+
+	type share struct {
+		index int
+		rem   *big.Int
+	}
+
+	shares := make([]share, 0, len(weights))
+	allocated := int64(0)
+
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+
+		num := new(big.Int).Mul(big.NewInt(steps), big.NewInt(int64(w)))
+		q, r := new(big.Int).QuoRem(num, big.NewInt(int64(total)), new(big.Int))
+
+		parts[i] = Money(q.Int64()) * step
+		allocated += q.Int64()
+
+		shares = append(shares, share{index: i, rem: r.Abs(r)})
+	}
+
+	leftover := steps - allocated
+	sign := int64(1)
+
+	if leftover < 0 {
+		sign, leftover = -1, -leftover
+	}
+
+	for leftover > 0 {
+		best := -1
+		for j := range shares {
+			if best == -1 || shares[j].rem.Cmp(shares[best].rem) > 0 {
+				best = j
+			}
+		}
+
+		parts[shares[best].index] += Money(sign) * step
+		shares[best].rem = big.NewInt(-1)
+		leftover--
+	}
+
+	return parts
+}
+
+func pow10(n int) int64 {
+	v := int64(1)
+	for range n {
+		v *= 10
+	}
+
+	return v
+}
@@ -0,0 +1,40 @@
+package money
+
+import "regexp"
+
+var currencyCodeRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// minorUnits lists ISO 4217 currencies whose minor unit differs from the
+// default of two decimal places.
+var minorUnits = map[string]int{
+	"BHD": 3,
+	"CLP": 0,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"UGX": 0,
+	"VND": 0,
+}
+
+// IsCurrencyCode reports whether code looks like an ISO 4217 alphabetic code.
+func IsCurrencyCode(code string) bool {
+	return currencyCodeRegex.MatchString(code)
+}
+
+// MinorUnits returns the number of decimal places used by a currency.
+func MinorUnits(code string) int {
+	if n, ok := minorUnits[code]; ok {
+		return n
+	}
+
+	return 2
+}
+
+// RoundTo rounds m to the minor unit of currency using mode.
+func (m Money) RoundTo(currency string, mode RoundingMode) Money {
+	return m.Round(MinorUnits(currency), mode)
+}
@@ -0,0 +1,379 @@
+// Package money provides a fixed-point decimal type for prices and totals.
+//
+// A Money value stores an amount in ten-thousandths (Scale decimal places) as
+// an int64, so sums and multiplications by quantities are exact. Rounding only
+// happens when a value enters the system from a float or is explicitly rounded
+// with a RoundingMode.
+//
+// Amounts range from Min to Max, about ±922 trillion. Add, Sub, Mul and
+// FromInt wrap around past that range like int64 arithmetic does; amounts
+// derived from input use the Checked variants, which return ErrOverflow
+// instead.
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Scale is the number of decimal places kept by Money.
+const Scale = 4
+
+const unit = 10000
+
+var (
+	ErrInvalidAmount = errors.New("invalid money amount")
+	ErrTooPrecise    = errors.New("money amount has more than 4 decimal places")
+	ErrOverflow      = errors.New("money amount out of range")
+)
+
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds halves away from zero (1.005 -> 1.01).
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds halves to the nearest even digit (1.005 -> 1.00).
+	RoundHalfEven
+	// RoundDown truncates toward zero.
+	RoundDown
+)
+
+type Money int64
+
+var Zero Money
+
+// Max and Min are the largest and smallest amounts a Money can hold.
+const (
+	Max Money = math.MaxInt64
+	Min Money = math.MinInt64
+)
+
+// FromInt returns a Money for a whole amount. v must be within the whole
+// part of Min and Max.
+func FromInt(v int64) Money {
+	return Money(v * unit)
+}
+
+// FromIntChecked is like FromInt but returns ErrOverflow when v is out of
+// range.
+func FromIntChecked(v int64) (Money, error) {
+	if v > math.MaxInt64/unit || v < math.MinInt64/unit {
+		return Zero, ErrOverflow
+	}
+
+	return Money(v * unit), nil
+}
+
+// FromFloat converts a float using its shortest round-tripping decimal
+// representation and rounds it to Scale places with mode.
+func FromFloat(v float64, mode RoundingMode) Money {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return Zero
+	}
+
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(v, 'f', -1, 64))
+	if !ok {
+		return Zero
+	}
+
+	return fromRat(r, Scale, mode)
+}
+
+// Parse reads a decimal string such as "12.5" or "-0.0125". Amounts with more
+// than Scale decimal places are rejected rather than silently rounded.
+func Parse(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Zero, ErrInvalidAmount
+	}
+
+	neg := false
+
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return Zero, ErrInvalidAmount
+	}
+
+	if len(frac) > Scale {
+		if strings.TrimRight(frac[Scale:], "0") != "" {
+			return Zero, ErrTooPrecise
+		}
+
+		frac = frac[:Scale]
+	}
+
+	frac += strings.Repeat("0", Scale-len(frac))
+
+	if whole == "" {
+		whole = "0"
+	}
+
+	w, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return Zero, ErrOverflow
+	}
+
+	f, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil {
+		return Zero, ErrInvalidAmount
+	}
+
+	if w > (math.MaxInt64-f)/unit {
+		return Zero, ErrOverflow
+	}
+
+	v := w*unit + f
+	if neg {
+		v = -v
+	}
+
+	return Money(v), nil
+}
+
+// isDigits reports whether s holds only the digits 0 to 9.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MustParse is like Parse but panics on error. It is intended for constants
+// and tests.
+func MustParse(s string) Money {
+	m, err := Parse(s)
+	if err != nil {
+		panic(fmt.Sprintf("money: MustParse(%q): %v", s, err))
+	}
+
+	return m
+}
+
+func (m Money) Add(o Money) Money {
+	return m + o
+}
+
+func (m Money) Sub(o Money) Money {
+	return m - o
+}
+
+func (m Money) Mul(qty int64) Money {
+	return m * Money(qty)
+}
+
+// AddChecked is like Add but returns ErrOverflow when the sum is out of
+// range.
+func (m Money) AddChecked(o Money) (Money, error) {
+	sum := m + o
+	if (o > 0 && sum < m) || (o < 0 && sum > m) {
+		return Zero, ErrOverflow
+	}
+
+	return sum, nil
+}
+
+// MulChecked is like Mul but returns ErrOverflow when the product is out of
+// range.
+func (m Money) MulChecked(qty int64) (Money, error) {
+	if m == 0 || qty == 0 {
+		return Zero, nil
+	}
+
+	product := m * Money(qty)
+	if product/Money(qty) != m || (qty == -1 && m == Min) || (m == -1 && qty == math.MinInt64) {
+		return Zero, ErrOverflow
+	}
+
+	return product, nil
+}
+
+func (m Money) Neg() Money {
+	return -m
+}
+
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m < 0
+}
+
+func (m Money) Cmp(o Money) int {
+	switch {
+	case m < o:
+		return -1
+	case m > o:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Round rounds m to the given number of decimal places (0 to Scale).
+func (m Money) Round(places int, mode RoundingMode) Money {
+	return fromRat(m.Rat(), places, mode)
+}
+
+// MulRat multiplies m by r and rounds the result to places with mode.
+func (m Money) MulRat(r *big.Rat, places int, mode RoundingMode) Money {
+	return fromRat(new(big.Rat).Mul(m.Rat(), r), places, mode)
+}
+
+// Rat returns m as an exact rational number.
+func (m Money) Rat() *big.Rat {
+	return big.NewRat(int64(m), unit)
+}
+
+// String formats m with at least two and at most Scale decimal places.
+func (m Money) String() string {
+	v := int64(m)
+	sign := ""
+
+	if v < 0 {
+		sign = "-"
+	}
+
+	abs := new(big.Int).Abs(big.NewInt(v))
+	whole, frac := new(big.Int).QuoRem(abs, big.NewInt(unit), new(big.Int))
+
+	fracStr := fmt.Sprintf("%0*d", Scale, frac.Int64())
+	fracStr = strings.TrimRight(fracStr, "0")
+
+	if len(fracStr) < 2 {
+		fracStr += strings.Repeat("0", 2-len(fracStr))
+	}
+
+	return sign + whole.String() + "." + fracStr
+}
+
+// Float64 returns an approximation of m. It must not be used for arithmetic.
+func (m Money) Float64() float64 {
+	return float64(m) / unit
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return ErrInvalidAmount
+		}
+
+		s = n.String()
+	}
+
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	*m = v
+
+	return nil
+}
+
+// Value stores m as a NUMERIC-compatible decimal string.
+func (m Money) Value() (driver.Value, error) {
+	return m.Rat().FloatString(Scale), nil
+}
+
+// Scan reads a NUMERIC column. Values with more than Scale decimal places are
+// rounded half-even, matching how Postgres rounds NUMERIC casts.
+func (m *Money) Scan(src any) error {
+	var s string
+
+	switch v := src.(type) {
+	case nil:
+		*m = Zero
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case int64:
+		amount, err := FromIntChecked(v)
+		if err != nil {
+			return errors.Wrapf(err, "money: cannot scan %d", v)
+		}
+
+		*m = amount
+		return nil
+	case float64:
+		*m = FromFloat(v, RoundHalfEven)
+		return nil
+	default:
+		return errors.Newf("money: cannot scan %T", src)
+	}
+
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return errors.Wrapf(ErrInvalidAmount, "money: cannot scan %q", s)
+	}
+
+	*m = fromRat(r, Scale, RoundHalfEven)
+
+	return nil
+}
+
+func fromRat(r *big.Rat, places int, mode RoundingMode) Money {
+	if places < 0 {
+		places = 0
+	}
+
+	if places > Scale {
+		places = Scale
+	}
+
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow))
+
+	q, rem := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if rem.Sign() != 0 {
+		// twice the remainder compared with the denominator tells us whether
+		// the discarded part is below, at, or above one half.
+		half := new(big.Int).Abs(rem)
+		half.Mul(half, big.NewInt(2))
+		cmp := half.Cmp(scaled.Denom())
+
+		step := big.NewInt(int64(r.Sign()))
+
+		switch mode {
+		case RoundHalfUp:
+			if cmp >= 0 {
+				q.Add(q, step)
+			}
+		case RoundHalfEven:
+			if cmp > 0 || (cmp == 0 && q.Bit(0) == 1) {
+				q.Add(q, step)
+			}
+		case RoundDown:
+		}
+	}
+
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(Scale-places)), nil)
+
+	return Money(q.Mul(q, factor).Int64())
+}
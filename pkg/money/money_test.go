@@ -0,0 +1,179 @@
+package money_test
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"order-service/pkg/money"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+		err  error
+	}{
+		{in: "12", want: "12.00"},
+		{in: "12.5", want: "12.50"},
+		{in: "-0.0125", want: "-0.0125"},
+		{in: ".75", want: "0.75"},
+		{in: "1.23450", want: "1.2345"},
+		{in: "1.23456", err: money.ErrTooPrecise},
+		{in: "abc", err: money.ErrInvalidAmount},
+		{in: "", err: money.ErrInvalidAmount},
+		{in: "+1.5", want: "1.50"},
+		{in: "1.+5", err: money.ErrInvalidAmount},
+		{in: "1.-5", err: money.ErrInvalidAmount},
+		{in: "+-1", err: money.ErrInvalidAmount},
+		{in: "--1", err: money.ErrInvalidAmount},
+		{in: "1 .5", err: money.ErrInvalidAmount},
+		{in: "-", err: money.ErrInvalidAmount},
+		{in: "922337203685477.5807", want: "922337203685477.5807"},
+		{in: "-922337203685477.5807", want: "-922337203685477.5807"},
+		{in: "922337203685477.5808", err: money.ErrOverflow},
+		{in: "99999999999999999999", err: money.ErrOverflow},
+	}
+
+	for _, tc := range cases {
+		got, err := money.Parse(tc.in)
+		if tc.err != nil {
+			require.ErrorIs(t, err, tc.err, tc.in)
+			continue
+		}
+
+		require.NoError(t, err, tc.in)
+		assert.Equal(t, tc.want, got.String(), tc.in)
+	}
+}
+
+func TestCheckedArithmetic(t *testing.T) {
+	maxWhole := int64(math.MaxInt64 / 10000)
+
+	got, err := money.FromIntChecked(maxWhole)
+	require.NoError(t, err)
+	assert.Equal(t, money.FromInt(maxWhole), got)
+
+	_, err = money.FromIntChecked(maxWhole + 1)
+	assert.ErrorIs(t, err, money.ErrOverflow)
+
+	_, err = money.FromIntChecked(-maxWhole - 1)
+	assert.ErrorIs(t, err, money.ErrOverflow)
+
+	sum, err := money.Max.Sub(money.MustParse("1")).AddChecked(money.MustParse("1"))
+	require.NoError(t, err)
+	assert.Equal(t, money.Max, sum)
+
+	_, err = money.Max.AddChecked(money.MustParse("0.0001"))
+	assert.ErrorIs(t, err, money.ErrOverflow)
+
+	_, err = money.Min.AddChecked(money.MustParse("-0.0001"))
+	assert.ErrorIs(t, err, money.ErrOverflow)
+
+	product, err := money.MustParse("2.50").MulChecked(3)
+	require.NoError(t, err)
+	assert.Equal(t, "7.50", product.String())
+
+	product, err = money.MustParse("-2.50").MulChecked(-3)
+	require.NoError(t, err)
+	assert.Equal(t, "7.50", product.String())
+
+	_, err = money.FromInt(maxWhole/2 + 1).MulChecked(2)
+	assert.ErrorIs(t, err, money.ErrOverflow)
+
+	_, err = money.Min.MulChecked(-1)
+	assert.ErrorIs(t, err, money.ErrOverflow)
+
+	_, err = money.Money(-1).MulChecked(math.MinInt64)
+	assert.ErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestFromFloat_AvoidsBinaryDrift(t *testing.T) {
+	var total money.Money
+	for range 10 {
+		total = total.Add(money.FromFloat(0.1, money.RoundHalfUp))
+	}
+
+	assert.Equal(t, money.FromInt(1), total)
+	assert.Equal(t, "1.0050", money.FromFloat(1.005, money.RoundHalfUp).Rat().FloatString(4))
+}
+
+func TestRound(t *testing.T) {
+	cases := []struct {
+		in   string
+		mode money.RoundingMode
+		want string
+	}{
+		{in: "1.005", mode: money.RoundHalfUp, want: "1.01"},
+		{in: "1.005", mode: money.RoundHalfEven, want: "1.00"},
+		{in: "1.015", mode: money.RoundHalfEven, want: "1.02"},
+		{in: "1.009", mode: money.RoundDown, want: "1.00"},
+		{in: "-1.005", mode: money.RoundHalfUp, want: "-1.01"},
+		{in: "-1.005", mode: money.RoundHalfEven, want: "-1.00"},
+	}
+
+	for _, tc := range cases {
+		got := money.MustParse(tc.in).Round(2, tc.mode)
+		assert.Equal(t, tc.want, got.String(), "%s mode=%d", tc.in, tc.mode)
+	}
+}
+
+func TestMulRat(t *testing.T) {
+	price := money.MustParse("19.99")
+	got := price.MulRat(big.NewRat(11, 100), 2, money.RoundHalfUp)
+
+	assert.Equal(t, "2.20", got.String())
+}
+
+func TestJSONAndSQLRoundTrip(t *testing.T) {
+	m := money.MustParse("1234.5")
+
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	assert.JSONEq(t, `"1234.50"`, string(data))
+
+	var decoded money.Money
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, m, decoded)
+
+	v, err := m.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "1234.5000", v)
+
+	var scanned money.Money
+	require.NoError(t, scanned.Scan([]byte("1234.50000")))
+	assert.Equal(t, m, scanned)
+}
+
+func TestAllocate(t *testing.T) {
+	parts := money.MustParse("10").Allocate([]money.Money{
+		money.FromInt(1),
+		money.FromInt(1),
+		money.FromInt(1),
+	}, 2)
+
+	assert.Equal(t, []money.Money{
+		money.MustParse("3.34"),
+		money.MustParse("3.33"),
+		money.MustParse("3.33"),
+	}, parts)
+
+	parts = money.MustParse("5").Allocate([]money.Money{
+		money.FromInt(30),
+		money.Zero,
+		money.FromInt(70),
+	}, 0)
+
+	assert.Equal(t, []money.Money{money.FromInt(2), money.Zero, money.FromInt(3)}, parts)
+}
+
+func TestPercent(t *testing.T) {
+	p := money.MustParsePercent("12.5")
+
+	assert.Equal(t, "12.5", p.String())
+	assert.Equal(t, money.MustParse("2.50"), p.Of(money.FromInt(20), 2, money.RoundHalfUp))
+}
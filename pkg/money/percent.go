@@ -0,0 +1,83 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math/big"
+)
+
+// Percent is a percentage with Scale decimal places, e.g. 12.5 for 12.5%.
+type Percent int64
+
+func ParsePercent(s string) (Percent, error) {
+	m, err := Parse(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return Percent(m), nil
+}
+
+func MustParsePercent(s string) Percent {
+	return Percent(MustParse(s))
+}
+
+// Rat returns p as a fraction, so 12.5% becomes 1/8.
+func (p Percent) Rat() *big.Rat {
+	return big.NewRat(int64(p), unit*100)
+}
+
+// Of returns p percent of m rounded to places with mode.
+func (p Percent) Of(m Money, places int, mode RoundingMode) Money {
+	return m.MulRat(p.Rat(), places, mode)
+}
+
+func (p Percent) IsZero() bool {
+	return p == 0
+}
+
+func (p Percent) String() string {
+	s := Money(p).String()
+
+	// Money always shows two decimals; percentages read better without
+	// trailing zeros.
+	for len(s) > 0 && s[len(s)-1] == '0' {
+		s = s[:len(s)-1]
+	}
+
+	if s[len(s)-1] == '.' {
+		s = s[:len(s)-1]
+	}
+
+	return s
+}
+
+func (p Percent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+func (p *Percent) UnmarshalJSON(data []byte) error {
+	var m Money
+	if err := m.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	*p = Percent(m)
+
+	return nil
+}
+
+func (p Percent) Value() (driver.Value, error) {
+	return Money(p).Value()
+}
+
+func (p *Percent) Scan(src any) error {
+	var m Money
+	if err := m.Scan(src); err != nil {
+		return err
+	}
+
+	*p = Percent(m)
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// RateScale is the number of decimal places kept when a Rate is stored.
+const RateScale = 12
+
+var ErrInvalidRate = errors.New("invalid exchange rate")
+
+// Rate is an exact, positive conversion factor such as an exchange rate. The
+// zero value is treated as 1.
+type Rate struct {
+	value *big.Rat
+}
+
+func ParseRate(s string) (Rate, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok || r.Sign() <= 0 {
+		return Rate{}, ErrInvalidRate
+	}
+
+	return Rate{value: r}, nil
+}
+
+func MustParseRate(s string) Rate {
+	r, err := ParseRate(s)
+	if err != nil {
+		panic("money: MustParseRate(" + s + "): " + err.Error())
+	}
+
+	return r
+}
+
+func OneRate() Rate {
+	return Rate{value: big.NewRat(1, 1)}
+}
+
+// Rat returns a copy of the rate as a rational number.
+func (r Rate) Rat() *big.Rat {
+	if r.value == nil {
+		return big.NewRat(1, 1)
+	}
+
+	return new(big.Rat).Set(r.value)
+}
+
+func (r Rate) Inverse() Rate {
+	return Rate{value: new(big.Rat).Inv(r.Rat())}
+}
+
+// Mul composes two rates, e.g. USD->IDR followed by IDR->SGD.
+func (r Rate) Mul(o Rate) Rate {
+	return Rate{value: new(big.Rat).Mul(r.Rat(), o.Rat())}
+}
+
+// Rounded returns the rate as it will be persisted, so conversions can be
+// reproduced later from the stored value.
+func (r Rate) Rounded() Rate {
+	v, _ := new(big.Rat).SetString(r.Rat().FloatString(RateScale))
+	if v.Sign() <= 0 {
+		return r
+	}
+
+	return Rate{value: v}
+}
+
+func (r Rate) String() string {
+	s := r.Rat().FloatString(RateScale)
+	s = strings.TrimRight(s, "0")
+
+	return strings.TrimSuffix(s, ".")
+}
+
+func (r Rate) Equal(o Rate) bool {
+	return r.Rat().Cmp(o.Rat()) == 0
+}
+
+// Convert applies the rate to m and rounds to the minor unit of currency.
+func (r Rate) Convert(m Money, currency string, mode RoundingMode) Money {
+	return m.MulRat(r.Rat(), MinorUnits(currency), mode)
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return ErrInvalidRate
+		}
+
+		s = n.String()
+	}
+
+	v, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+
+	*r = v
+
+	return nil
+}
+
+func (r Rate) Value() (driver.Value, error) {
+	return r.Rat().FloatString(RateScale), nil
+}
+
+func (r *Rate) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*r = Rate{}
+		return nil
+	case string:
+		return r.scanString(v)
+	case []byte:
+		return r.scanString(string(v))
+	case float64:
+		return r.scanString(big.NewFloat(v).Text('f', -1))
+	case int64:
+		*r = Rate{value: big.NewRat(v, 1)}
+		return nil
+	default:
+		return errors.Newf("money: cannot scan %T into Rate", src)
+	}
+}
+
+func (r *Rate) scanString(s string) error {
+	v, err := ParseRate(s)
+	if err != nil {
+		return errors.Wrapf(err, "money: cannot scan %q into Rate", s)
+	}
+
+	*r = v
+
+	return nil
+}